@@ -0,0 +1,888 @@
+// Package binary implements a compact binary encoding of ast Documents, for caching parsed documents.
+//
+// Encoded documents begin with a header holding the Version of the encoding. Documents encoded with a different
+// Version can not be decoded, and must be re-parsed from source.
+package binary
+
+import (
+	"bytes"
+	bin "encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The Version of the encoding. It is incremented whenever the encoding, or the ast it encodes, changes.
+const Version = 5
+
+// The magic bytes which begin every encoded document.
+var magic = []byte("GQLB")
+
+var (
+	// Corrupt is returned when decoding malformed data.
+	Corrupt = errors.New("corrupt binary document")
+	// UnrecognizedFormat is returned when decoding data which was not produced by Marshal.
+	UnrecognizedFormat = errors.New("unrecognized binary document format")
+)
+
+// A VersionError is returned when decoding a document encoded with a different Version.
+type VersionError struct {
+	Version int
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("unsupported binary document version %d; expected %d", e.Version, Version)
+}
+
+// Definition tags.
+const (
+	opDefTag byte = iota + 1
+	fragmentDefTag
+	objTypeDefTag
+	interfaceTypeDefTag
+	unionTypeDefTag
+	scalarTypeDefTag
+	enumTypeDefTag
+	inputObjTypeDefTag
+	typeExtDefTag
+	schemaDefTag
+	directiveDefTag
+)
+
+// Selection tags.
+const (
+	fieldTag byte = iota + 1
+	fragmentSpreadTag
+	inlineFragmentTag
+)
+
+// Value tags. A zero tag encodes a nil Value.
+const (
+	variableTag byte = iota + 1
+	intTag
+	floatTag
+	stringTag
+	booleanTag
+	enumTag
+	listTag
+	objectTag
+)
+
+// RefType tags. A zero tag encodes a nil RefType.
+const (
+	namedTypeTag byte = iota + 1
+	listTypeTag
+	nonNullTypeTag
+)
+
+// The Marshal function returns the binary encoding of d.
+//
+// Layout: magic, version, string table, document. Strings are encoded once in the table, and referenced by index.
+func Marshal(d *ast.Document) ([]byte, error) {
+	e := encoder{strings: make(map[string]int)}
+	e.document(d)
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	body := e.buf
+	e.buf = make([]byte, 0, len(magic)+len(body)+16*len(e.table))
+	e.buf = append(e.buf, magic...)
+	e.uint(Version)
+	e.uint(uint64(len(e.table)))
+	for _, s := range e.table {
+		e.uint(uint64(len(s)))
+		e.buf = append(e.buf, s...)
+	}
+	return append(e.buf, body...), nil
+}
+
+// The Unmarshal function decodes a Document from data produced by Marshal.
+// A *VersionError is returned if data was encoded with a different Version.
+func Unmarshal(data []byte) (*ast.Document, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return nil, UnrecognizedFormat
+	}
+	dec := decoder{buf: data, pos: len(magic)}
+	if v := dec.uint(); dec.err != nil {
+		return nil, dec.err
+	} else if v != Version {
+		return nil, &VersionError{int(v)}
+	}
+
+	n := dec.len()
+	dec.table = make([]string, n)
+	for i := range dec.table {
+		l := dec.len()
+		if dec.err != nil {
+			return nil, dec.err
+		}
+		dec.table[i] = string(dec.buf[dec.pos : dec.pos+l])
+		dec.pos += l
+	}
+
+	d := dec.document()
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	if dec.pos != len(dec.buf) {
+		return nil, Corrupt
+	}
+	return d, nil
+}
+
+// An encoder holds the state for encoding a single Document.
+type encoder struct {
+	buf []byte
+	err error
+
+	// String table, and the index of each string in it.
+	table   []string
+	strings map[string]int
+
+	scratch [bin.MaxVarintLen64]byte
+}
+
+func (e *encoder) byte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *encoder) bool(b bool) {
+	if b {
+		e.byte(1)
+	} else {
+		e.byte(0)
+	}
+}
+
+func (e *encoder) uint(v uint64) {
+	n := bin.PutUvarint(e.scratch[:], v)
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) int(v int) {
+	n := bin.PutVarint(e.scratch[:], int64(v))
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) len(n int) {
+	e.uint(uint64(n))
+}
+
+// The string method encodes the index of s in the string table, adding it if necessary.
+func (e *encoder) string(s string) {
+	i, ok := e.strings[s]
+	if !ok {
+		i = len(e.table)
+		e.table = append(e.table, s)
+		e.strings[s] = i
+	}
+	e.len(i)
+}
+
+func (e *encoder) loc(l ast.Loc) {
+	e.int(l.Start)
+	e.int(l.End)
+}
+
+func (e *encoder) name(n *ast.Name) {
+	e.loc(n.Loc)
+	e.string(n.Value)
+}
+
+func (e *encoder) document(d *ast.Document) {
+	e.loc(d.Loc)
+	e.string(d.Source)
+	e.len(len(d.Definitions))
+	for _, def := range d.Definitions {
+		e.definition(def)
+	}
+}
+
+func (e *encoder) definition(d ast.Definition) {
+	switch t := d.(type) {
+	case *ast.OpDef:
+		e.byte(opDefTag)
+		e.loc(t.Loc)
+		e.uint(uint64(t.OpType))
+		e.name(&t.Name)
+		e.len(len(t.VarDefs))
+		for i := range t.VarDefs {
+			e.varDef(&t.VarDefs[i])
+		}
+		e.directives(t.Directives)
+		e.selectionSet(&t.SelectionSet)
+	case *ast.FragmentDef:
+		e.byte(fragmentDefTag)
+		e.loc(t.Loc)
+		e.name(&t.Name)
+		e.name((*ast.Name)(&t.TypeCondition))
+		e.directives(t.Directives)
+		e.selectionSet(&t.SelectionSet)
+	case *ast.SchemaDef:
+		e.byte(schemaDefTag)
+		e.loc(t.Loc)
+		e.directives(t.Directives)
+		e.len(len(t.OpTypeDefs))
+		for i := range t.OpTypeDefs {
+			e.loc(t.OpTypeDefs[i].Loc)
+			e.uint(uint64(t.OpTypeDefs[i].OpType))
+			e.name((*ast.Name)(&t.OpTypeDefs[i].NamedType))
+		}
+	case *ast.ObjTypeDef:
+		e.byte(objTypeDefTag)
+		e.objTypeDef(t)
+	case *ast.InterfaceTypeDef:
+		e.byte(interfaceTypeDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+		e.fieldDefs(t.FieldDefs)
+	case *ast.UnionTypeDef:
+		e.byte(unionTypeDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+		e.namedTypes(t.NamedTypes)
+	case *ast.ScalarTypeDef:
+		e.byte(scalarTypeDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+	case *ast.EnumTypeDef:
+		e.byte(enumTypeDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+		e.len(len(t.EnumValueDefs))
+		for i := range t.EnumValueDefs {
+			e.loc(t.EnumValueDefs[i].Loc)
+			e.description(t.EnumValueDefs[i].Description)
+			e.name(&t.EnumValueDefs[i].Name)
+			e.directives(t.EnumValueDefs[i].Directives)
+		}
+	case *ast.InputObjTypeDef:
+		e.byte(inputObjTypeDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+		e.inputValueDefs(t.Fields)
+	case *ast.TypeExtDef:
+		e.byte(typeExtDefTag)
+		e.objTypeDef((*ast.ObjTypeDef)(t))
+	case *ast.DirectiveDef:
+		e.byte(directiveDefTag)
+		e.loc(t.Loc)
+		e.description(t.Description)
+		e.name(&t.Name)
+		e.inputValueDefs(t.Arguments)
+		e.bool(t.Repeatable)
+		e.len(len(t.Locations))
+		for i := range t.Locations {
+			e.name(&t.Locations[i])
+		}
+	default:
+		e.err = fmt.Errorf("unable to encode unrecognized Definition type: %T", d)
+	}
+}
+
+func (e *encoder) varDef(v *ast.VarDef) {
+	e.loc(v.Loc)
+	e.loc(v.Variable.Loc)
+	e.name(&v.Variable.Name)
+	e.refType(v.RefType)
+	e.value(v.DefaultValue)
+	e.directives(v.Directives)
+}
+
+func (e *encoder) selectionSet(ss *ast.SelectionSet) {
+	e.loc(ss.Loc)
+	e.len(len(ss.Selections))
+	for _, s := range ss.Selections {
+		e.selection(s)
+	}
+}
+
+func (e *encoder) selection(s ast.Selection) {
+	switch t := s.(type) {
+	case *ast.Field:
+		e.byte(fieldTag)
+		e.loc(t.Loc)
+		e.name(&t.Alias)
+		e.name(&t.Name)
+		e.arguments(t.Arguments)
+		e.directives(t.Directives)
+		e.selectionSet(&t.SelectionSet)
+	case *ast.FragmentSpread:
+		e.byte(fragmentSpreadTag)
+		e.loc(t.Loc)
+		e.name(&t.Name)
+		e.directives(t.Directives)
+	case *ast.InlineFragment:
+		e.byte(inlineFragmentTag)
+		e.loc(t.Loc)
+		e.name((*ast.Name)(&t.NamedType))
+		e.directives(t.Directives)
+		e.selectionSet(&t.SelectionSet)
+	default:
+		e.err = fmt.Errorf("unable to encode unrecognized Selection type: %T", s)
+	}
+}
+
+func (e *encoder) arguments(as []ast.Argument) {
+	e.len(len(as))
+	for i := range as {
+		e.loc(as[i].Loc)
+		e.name(&as[i].Name)
+		e.value(as[i].Value)
+	}
+}
+
+func (e *encoder) directives(ds []ast.Directive) {
+	e.len(len(ds))
+	for i := range ds {
+		e.loc(ds[i].Loc)
+		e.name(&ds[i].Name)
+		e.arguments(ds[i].Arguments)
+	}
+}
+
+func (e *encoder) value(v ast.Value) {
+	switch t := v.(type) {
+	case nil:
+		e.byte(0)
+	case *ast.Variable:
+		e.byte(variableTag)
+		e.loc(t.Loc)
+		e.name(&t.Name)
+	case *ast.Int:
+		e.byte(intTag)
+		e.loc(t.Loc)
+		e.string(t.Value)
+	case *ast.Float:
+		e.byte(floatTag)
+		e.loc(t.Loc)
+		e.string(t.Value)
+	case *ast.String:
+		e.byte(stringTag)
+		e.loc(t.Loc)
+		e.string(t.Value)
+	case *ast.Boolean:
+		e.byte(booleanTag)
+		e.loc(t.Loc)
+		e.bool(t.Value)
+	case *ast.Enum:
+		e.byte(enumTag)
+		e.loc(t.Loc)
+		e.string(t.Value)
+	case *ast.List:
+		e.byte(listTag)
+		e.loc(t.Loc)
+		e.len(len(t.Values))
+		for _, lv := range t.Values {
+			e.value(lv)
+		}
+	case *ast.Object:
+		e.byte(objectTag)
+		e.loc(t.Loc)
+		e.len(len(t.Fields))
+		for i := range t.Fields {
+			e.loc(t.Fields[i].Loc)
+			e.name(&t.Fields[i].Name)
+			e.value(t.Fields[i].Value)
+		}
+	default:
+		e.err = fmt.Errorf("unable to encode unrecognized Value type: %T", v)
+	}
+}
+
+// The description method encodes an optional description as a String Value.
+func (e *encoder) description(s *ast.String) {
+	if s == nil {
+		e.value(nil)
+	} else {
+		e.value(s)
+	}
+}
+
+func (e *encoder) refType(rt ast.RefType) {
+	switch t := rt.(type) {
+	case nil:
+		e.byte(0)
+	case *ast.NamedType:
+		e.byte(namedTypeTag)
+		e.name((*ast.Name)(t))
+	case *ast.ListType:
+		e.byte(listTypeTag)
+		e.loc(t.Loc)
+		e.refType(t.RefType)
+	case *ast.NonNullType:
+		e.byte(nonNullTypeTag)
+		e.loc(t.Loc)
+		e.refType(t.RefType)
+	default:
+		e.err = fmt.Errorf("unable to encode unrecognized RefType type: %T", rt)
+	}
+}
+
+func (e *encoder) namedTypes(nts []ast.NamedType) {
+	e.len(len(nts))
+	for i := range nts {
+		e.name((*ast.Name)(&nts[i]))
+	}
+}
+
+func (e *encoder) objTypeDef(o *ast.ObjTypeDef) {
+	e.loc(o.Loc)
+	e.description(o.Description)
+	e.name(&o.Name)
+	e.namedTypes(o.Interfaces)
+	e.directives(o.Directives)
+	e.fieldDefs(o.FieldDefs)
+}
+
+func (e *encoder) fieldDefs(fds []ast.FieldDef) {
+	e.len(len(fds))
+	for i := range fds {
+		e.loc(fds[i].Loc)
+		e.description(fds[i].Description)
+		e.name(&fds[i].Name)
+		e.inputValueDefs(fds[i].Arguments)
+		e.refType(fds[i].RefType)
+		e.directives(fds[i].Directives)
+	}
+}
+
+func (e *encoder) inputValueDefs(ivds []ast.InputValueDef) {
+	e.len(len(ivds))
+	for i := range ivds {
+		e.loc(ivds[i].Loc)
+		e.description(ivds[i].Description)
+		e.name(&ivds[i].Name)
+		e.refType(ivds[i].RefType)
+		e.value(ivds[i].DefaultValue)
+		e.directives(ivds[i].Directives)
+	}
+}
+
+// A decoder holds the state for decoding a single Document.
+// Once err is set, all methods return zero values.
+type decoder struct {
+	buf   []byte
+	pos   int
+	err   error
+	table []string
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if d.pos >= len(d.buf) {
+		d.err = Corrupt
+		return 0
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *decoder) bool() bool {
+	return d.byte() == 1
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := bin.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		d.err = Corrupt
+		return 0
+	}
+	d.pos += n
+	return v
+}
+
+func (d *decoder) int() int {
+	if d.err != nil {
+		return 0
+	}
+	v, n := bin.Varint(d.buf[d.pos:])
+	if n <= 0 {
+		d.err = Corrupt
+		return 0
+	}
+	d.pos += n
+	return int(v)
+}
+
+// The len method decodes a length, which may not exceed the number of remaining bytes.
+func (d *decoder) len() int {
+	l := d.uint()
+	if l > uint64(len(d.buf)-d.pos) {
+		d.err = Corrupt
+		return 0
+	}
+	return int(l)
+}
+
+func (d *decoder) string() string {
+	i := d.uint()
+	if d.err != nil {
+		return ""
+	}
+	if i >= uint64(len(d.table)) {
+		d.err = Corrupt
+		return ""
+	}
+	return d.table[i]
+}
+
+func (d *decoder) loc() ast.Loc {
+	return ast.Loc{Start: d.int(), End: d.int()}
+}
+
+func (d *decoder) name() ast.Name {
+	return ast.Name{Loc: d.loc(), Value: d.string()}
+}
+
+func (d *decoder) document() *ast.Document {
+	doc := &ast.Document{Loc: d.loc(), Source: d.string()}
+	if n := d.len(); n > 0 {
+		doc.Definitions = make([]ast.Definition, n)
+		for i := range doc.Definitions {
+			doc.Definitions[i] = d.definition()
+		}
+	}
+	return doc
+}
+
+func (d *decoder) definition() ast.Definition {
+	switch tag := d.byte(); tag {
+	case opDefTag:
+		o := &ast.OpDef{Loc: d.loc()}
+		o.OpType = d.opType()
+		o.Name = d.name()
+		if n := d.len(); n > 0 {
+			o.VarDefs = make([]ast.VarDef, n)
+			for i := range o.VarDefs {
+				d.varDef(&o.VarDefs[i])
+			}
+		}
+		o.Directives = d.directives()
+		o.SelectionSet = d.selectionSet()
+		return o
+	case schemaDefTag:
+		s := &ast.SchemaDef{Loc: d.loc()}
+		s.Directives = d.directives()
+		if n := d.len(); n > 0 {
+			s.OpTypeDefs = make([]ast.OpTypeDef, n)
+			for i := range s.OpTypeDefs {
+				s.OpTypeDefs[i].Loc = d.loc()
+				s.OpTypeDefs[i].OpType = d.opType()
+				s.OpTypeDefs[i].NamedType = ast.NamedType(d.name())
+			}
+		}
+		return s
+	case fragmentDefTag:
+		f := &ast.FragmentDef{Loc: d.loc()}
+		f.Name = d.name()
+		f.TypeCondition = ast.NamedType(d.name())
+		f.Directives = d.directives()
+		f.SelectionSet = d.selectionSet()
+		return f
+	case objTypeDefTag:
+		return d.objTypeDef()
+	case interfaceTypeDefTag:
+		i := &ast.InterfaceTypeDef{Loc: d.loc()}
+		i.Description = d.description()
+		i.Name = d.name()
+		i.Directives = d.directives()
+		i.FieldDefs = d.fieldDefs()
+		return i
+	case unionTypeDefTag:
+		u := &ast.UnionTypeDef{Loc: d.loc()}
+		u.Description = d.description()
+		u.Name = d.name()
+		u.Directives = d.directives()
+		u.NamedTypes = d.namedTypes()
+		return u
+	case scalarTypeDefTag:
+		s := &ast.ScalarTypeDef{Loc: d.loc()}
+		s.Description = d.description()
+		s.Name = d.name()
+		s.Directives = d.directives()
+		return s
+	case enumTypeDefTag:
+		e := &ast.EnumTypeDef{Loc: d.loc()}
+		e.Description = d.description()
+		e.Name = d.name()
+		e.Directives = d.directives()
+		if n := d.len(); n > 0 {
+			e.EnumValueDefs = make([]ast.EnumValueDef, n)
+			for i := range e.EnumValueDefs {
+				e.EnumValueDefs[i].Loc = d.loc()
+				e.EnumValueDefs[i].Description = d.description()
+				e.EnumValueDefs[i].Name = d.name()
+				e.EnumValueDefs[i].Directives = d.directives()
+			}
+		}
+		return e
+	case inputObjTypeDefTag:
+		i := &ast.InputObjTypeDef{Loc: d.loc()}
+		i.Description = d.description()
+		i.Name = d.name()
+		i.Directives = d.directives()
+		i.Fields = d.inputValueDefs()
+		return i
+	case typeExtDefTag:
+		return (*ast.TypeExtDef)(d.objTypeDef())
+	case directiveDefTag:
+		dd := &ast.DirectiveDef{Loc: d.loc()}
+		dd.Description = d.description()
+		dd.Name = d.name()
+		dd.Arguments = d.inputValueDefs()
+		dd.Repeatable = d.bool()
+		if n := d.len(); n > 0 {
+			dd.Locations = make([]ast.Name, n)
+			for i := range dd.Locations {
+				dd.Locations[i] = d.name()
+			}
+		}
+		return dd
+	default:
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return nil
+	}
+}
+
+func (d *decoder) opType() ast.OpType {
+	o := d.uint()
+	if o > uint64(ast.Subscription) {
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return ast.Query
+	}
+	return ast.OpType(o)
+}
+
+func (d *decoder) varDef(v *ast.VarDef) {
+	v.Loc = d.loc()
+	v.Variable.Loc = d.loc()
+	v.Variable.Name = d.name()
+	v.RefType = d.refType()
+	v.DefaultValue = d.value()
+	v.Directives = d.directives()
+}
+
+func (d *decoder) selectionSet() ast.SelectionSet {
+	ss := ast.SelectionSet{Loc: d.loc()}
+	if n := d.len(); n > 0 {
+		ss.Selections = make([]ast.Selection, n)
+		for i := range ss.Selections {
+			ss.Selections[i] = d.selection()
+		}
+	}
+	return ss
+}
+
+func (d *decoder) selection() ast.Selection {
+	switch tag := d.byte(); tag {
+	case fieldTag:
+		f := &ast.Field{Loc: d.loc()}
+		f.Alias = d.name()
+		f.Name = d.name()
+		f.Arguments = d.arguments()
+		f.Directives = d.directives()
+		f.SelectionSet = d.selectionSet()
+		return f
+	case fragmentSpreadTag:
+		f := &ast.FragmentSpread{Loc: d.loc()}
+		f.Name = d.name()
+		f.Directives = d.directives()
+		return f
+	case inlineFragmentTag:
+		i := &ast.InlineFragment{Loc: d.loc()}
+		i.NamedType = ast.NamedType(d.name())
+		i.Directives = d.directives()
+		i.SelectionSet = d.selectionSet()
+		return i
+	default:
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return nil
+	}
+}
+
+func (d *decoder) arguments() []ast.Argument {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	as := make([]ast.Argument, n)
+	for i := range as {
+		as[i].Loc = d.loc()
+		as[i].Name = d.name()
+		as[i].Value = d.value()
+	}
+	return as
+}
+
+func (d *decoder) directives() []ast.Directive {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	ds := make([]ast.Directive, n)
+	for i := range ds {
+		ds[i].Loc = d.loc()
+		ds[i].Name = d.name()
+		ds[i].Arguments = d.arguments()
+	}
+	return ds
+}
+
+func (d *decoder) value() ast.Value {
+	switch tag := d.byte(); tag {
+	case 0:
+		return nil
+	case variableTag:
+		return &ast.Variable{Loc: d.loc(), Name: d.name()}
+	case intTag:
+		return &ast.Int{Loc: d.loc(), Value: d.string()}
+	case floatTag:
+		return &ast.Float{Loc: d.loc(), Value: d.string()}
+	case stringTag:
+		return &ast.String{Loc: d.loc(), Value: d.string()}
+	case booleanTag:
+		return &ast.Boolean{Loc: d.loc(), Value: d.bool()}
+	case enumTag:
+		return &ast.Enum{Loc: d.loc(), Value: d.string()}
+	case listTag:
+		l := &ast.List{Loc: d.loc()}
+		if n := d.len(); n > 0 {
+			l.Values = make([]ast.Value, n)
+			for i := range l.Values {
+				l.Values[i] = d.value()
+			}
+		}
+		return l
+	case objectTag:
+		o := &ast.Object{Loc: d.loc()}
+		if n := d.len(); n > 0 {
+			o.Fields = make([]ast.ObjectField, n)
+			for i := range o.Fields {
+				o.Fields[i].Loc = d.loc()
+				o.Fields[i].Name = d.name()
+				o.Fields[i].Value = d.value()
+			}
+		}
+		return o
+	default:
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return nil
+	}
+}
+
+// The description method decodes an optional description, which must be a String Value.
+func (d *decoder) description() *ast.String {
+	switch v := d.value().(type) {
+	case nil:
+		return nil
+	case *ast.String:
+		return v
+	default:
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return nil
+	}
+}
+
+func (d *decoder) refType() ast.RefType {
+	switch tag := d.byte(); tag {
+	case 0:
+		return nil
+	case namedTypeTag:
+		nt := ast.NamedType(d.name())
+		return &nt
+	case listTypeTag:
+		return &ast.ListType{Loc: d.loc(), RefType: d.refType()}
+	case nonNullTypeTag:
+		return &ast.NonNullType{Loc: d.loc(), RefType: d.refType()}
+	default:
+		if d.err == nil {
+			d.err = Corrupt
+		}
+		return nil
+	}
+}
+
+func (d *decoder) namedTypes() []ast.NamedType {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	nts := make([]ast.NamedType, n)
+	for i := range nts {
+		nts[i] = ast.NamedType(d.name())
+	}
+	return nts
+}
+
+func (d *decoder) objTypeDef() *ast.ObjTypeDef {
+	o := &ast.ObjTypeDef{Loc: d.loc()}
+	o.Description = d.description()
+	o.Name = d.name()
+	o.Interfaces = d.namedTypes()
+	o.Directives = d.directives()
+	o.FieldDefs = d.fieldDefs()
+	return o
+}
+
+func (d *decoder) fieldDefs() []ast.FieldDef {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	fds := make([]ast.FieldDef, n)
+	for i := range fds {
+		fds[i].Loc = d.loc()
+		fds[i].Description = d.description()
+		fds[i].Name = d.name()
+		fds[i].Arguments = d.inputValueDefs()
+		fds[i].RefType = d.refType()
+		fds[i].Directives = d.directives()
+	}
+	return fds
+}
+
+func (d *decoder) inputValueDefs() []ast.InputValueDef {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+	ivds := make([]ast.InputValueDef, n)
+	for i := range ivds {
+		ivds[i].Loc = d.loc()
+		ivds[i].Description = d.description()
+		ivds[i].Name = d.name()
+		ivds[i].RefType = d.refType()
+		ivds[i].DefaultValue = d.value()
+		ivds[i].Directives = d.directives()
+	}
+	return ivds
+}
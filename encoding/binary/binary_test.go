@@ -0,0 +1,127 @@
+package binary
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kr/pretty"
+
+	"github.com/jmank88/gql/lang/parser"
+)
+
+var inputs = []string{
+	`{ user(id: 4) { name } }`,
+	`query q ($a:Int=1, $b:[String!]!) @dir(x:$a) {alias:f(l:[1,2.5,"s",true,ENUM], o:{a:{b:$b}}) ...frag ...on T @skip(if:true) {a} ... {b}}`,
+	`mutation m {a} subscription s {b} fragment frag on T @d {a}`,
+	`type T implements I J {a(x:Int=7):[String]!} interface I {a:Int} union U=A|B scalar S enum E {A,B} input In {a:Int, b:String="s"} extend type T {b:Int}`,
+	`schema @d {query: Q, mutation: M, subscription: S}`,
+	`"""T""" type T @a {"f" f("x" x:Int @b):Int @deprecated(reason:"r")} "e" enum E @c {"A" A @d, B} "s" scalar S @e "i" interface I @f {a:Int} "u" union U @g = T "in" input In @h {"a" a:Int=1 @i}`,
+	`query ($a:Int=1 @x, $b:Int @y(z:1) @w) {a}`,
+	`"d" directive @d("a" a:Int=1 @x) repeatable on FIELD | OBJECT directive @e on | QUERY`,
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, input := range inputs {
+		d, err := parser.ParseString(input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		b, err := Marshal(d)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		actual, err := Unmarshal(b)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		if !reflect.DeepEqual(d, actual) {
+			t.Errorf("input %q; diff:\n %v", input, pretty.Diff(d, actual))
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	d, err := parser.ParseString(inputs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every truncation must fail cleanly.
+	for i := range b {
+		if _, err := Unmarshal(b[:i]); err == nil {
+			t.Errorf("expected error decoding %d of %d bytes", i, len(b))
+		}
+	}
+
+	if _, err := Unmarshal(append(b, 0)); err != Corrupt {
+		t.Errorf("expected %q but got %v", Corrupt, err)
+	}
+
+	if _, err := Unmarshal([]byte("{a}")); err != UnrecognizedFormat {
+		t.Errorf("expected %q but got %v", UnrecognizedFormat, err)
+	}
+
+	old := append([]byte(nil), b...)
+	old[len(magic)] = Version + 1
+	if _, err := Unmarshal(old); err == nil {
+		t.Error("expected error")
+	} else if ve, ok := err.(*VersionError); !ok {
+		t.Errorf("expected %T but got %#v", ve, err)
+	} else if ve.Version != Version+1 {
+		t.Errorf("expected version %d but got %d", Version+1, ve.Version)
+	}
+}
+
+var benchmarkInput = strings.Repeat(`query q ($a:Int=1, $b:[String!]!) @dir(x:$a) {
+	alias:f(l:[1,2.5,"s",true,ENUM], o:{a:{b:$b}}) {
+		id
+		name
+		friends(first: 10) { edges { node { id name ...frag } } }
+		...on T @skip(if:true) {a b c}
+	}
+}
+fragment frag on User { id name email avatar(size: 64) }
+`, 20)
+
+func BenchmarkParseString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.ParseString(benchmarkInput); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	d, err := parser.ParseString(benchmarkInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	data, err := Marshal(d)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Unmarshal(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	d, err := parser.ParseString(benchmarkInput)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Marshal(d); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package errors implements GraphQL errors, as described by the spec's response format.
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// A GraphQLError describes a problem with a schema, a document, or the execution of a document.
+type GraphQLError struct {
+	Message string
+	// Locations of the nodes associated with the error, if any.
+	Locations []ast.Loc
+	// Path of the response field associated with the error, if any. Elements are field names (string) or list
+	// indexes (int).
+	Path []interface{}
+	// Source of the document containing Locations, if known. It is required to encode Locations as JSON.
+	Source string
+}
+
+// A Location is a line and column of a source, both starting from 1, as in the locations of a response error.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// The Locate function returns the Location of the rune at offset in source. Lines are terminated by "\n", "\r\n",
+// or "\r", and columns count runes.
+func Locate(source string, offset int) Location {
+	l := Location{Line: 1, Column: 1}
+	i := 0
+	cr := false
+	for _, r := range source {
+		if i == offset {
+			break
+		}
+		i++
+		switch {
+		case r == '\n' && cr:
+			// The "\r\n" terminator was counted at the "\r".
+		case r == '\n' || r == '\r':
+			l.Line++
+			l.Column = 1
+		default:
+			l.Column++
+		}
+		cr = r == '\r'
+	}
+	return l
+}
+
+// The New function returns a GraphQLError with message, associated with the nodes at locs.
+func New(message string, locs ...ast.Loc) *GraphQLError {
+	return &GraphQLError{Message: message, Locations: locs}
+}
+
+// The Newf function returns a GraphQLError associated with the node at loc, with a message formatted by fmt.Sprintf.
+func Newf(loc ast.Loc, format string, args ...interface{}) *GraphQLError {
+	return &GraphQLError{Message: fmt.Sprintf(format, args...), Locations: []ast.Loc{loc}}
+}
+
+func (e *GraphQLError) Error() string {
+	if len(e.Locations) == 0 {
+		return e.Message
+	}
+	var b bytes.Buffer
+	b.WriteString(e.Message)
+	b.WriteString(" (at position")
+	if len(e.Locations) > 1 {
+		b.WriteByte('s')
+	}
+	for i, l := range e.Locations {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, " %d", l.Start)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// The MarshalJSON method encodes e in the spec's response format, as an object with a "message", and the
+// "locations" and "path" if there are any. Locations are omitted if the Source is unknown.
+func (e *GraphQLError) MarshalJSON() ([]byte, error) {
+	var locs []Location
+	if e.Source != "" {
+		for _, l := range e.Locations {
+			locs = append(locs, Locate(e.Source, l.Start))
+		}
+	}
+	return json.Marshal(struct {
+		Message   string        `json:"message"`
+		Locations []Location    `json:"locations,omitempty"`
+		Path      []interface{} `json:"path,omitempty"`
+	}{e.Message, locs, e.Path})
+}
+
+// A List is a list of GraphQLErrors. A non-empty List is an error.
+type List []*GraphQLError
+
+func (l List) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+	}
+}
+
+// The WithSource method returns l, with copies of the errors lacking a Source having source instead.
+func (l List) WithSource(source string) List {
+	if len(l) == 0 {
+		return l
+	}
+	located := make(List, len(l))
+	for i, e := range l {
+		if e.Source == "" {
+			copied := *e
+			copied.Source = source
+			e = &copied
+		}
+		located[i] = e
+	}
+	return located
+}
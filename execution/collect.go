@@ -0,0 +1,157 @@
+// Package execution implements the execution of GraphQL operations against a Schema.
+package execution
+
+import (
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// A GroupedFields is an ordered map of response keys to the fields selected with them.
+type GroupedFields struct {
+	// Response keys, in selection order.
+	Keys   []string
+	Fields map[string][]*ast.Field
+}
+
+// The add method adds f to the fields selected with key.
+func (g *GroupedFields) add(key string, f *ast.Field) {
+	if _, ok := g.Fields[key]; !ok {
+		g.Keys = append(g.Keys, key)
+	}
+	g.Fields[key] = append(g.Fields[key], f)
+}
+
+// The CollectFields function returns the fields selected by ss on a value of type o, grouped by response key, as
+// described by the spec's CollectFields algorithm. Fragments are looked up by name in fragments, and only apply if
+// their type condition includes o. Selections are skipped as directed by the @skip and @include directives, whose
+// arguments are evaluated against variables.
+func CollectFields(s *schema.Schema, fragments map[string]*ast.FragmentDef, variables map[string]interface{}, o *schema.Object, ss ast.SelectionSet) (*GroupedFields, error) {
+	c := &collector{
+		schema:    s,
+		fragments: fragments,
+		variables: variables,
+		visited:   make(map[string]bool),
+	}
+	g := &GroupedFields{Fields: make(map[string][]*ast.Field)}
+	if err := c.collect(o, ss, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type collector struct {
+	schema    *schema.Schema
+	fragments map[string]*ast.FragmentDef
+	variables map[string]interface{}
+	visited   map[string]bool
+}
+
+// The collect method adds the fields selected by ss on a value of type o to g.
+func (c *collector) collect(o *schema.Object, ss ast.SelectionSet, g *GroupedFields) error {
+	for _, sel := range ss.Selections {
+		include, err := ShouldInclude(directivesOf(sel), c.variables)
+		if err != nil {
+			return err
+		}
+		if !include {
+			continue
+		}
+		switch sel := sel.(type) {
+		case *ast.Field:
+			key := sel.Name.Value
+			if sel.Alias.Value != "" {
+				key = sel.Alias.Value
+			}
+			g.add(key, sel)
+
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			if c.visited[name] {
+				continue
+			}
+			c.visited[name] = true
+			f, ok := c.fragments[name]
+			if !ok || !c.applies(o, f.TypeCondition.Value) {
+				continue
+			}
+			if err := c.collect(o, f.SelectionSet, g); err != nil {
+				return err
+			}
+
+		case *ast.InlineFragment:
+			if sel.NamedType.Value != "" && !c.applies(o, sel.NamedType.Value) {
+				continue
+			}
+			if err := c.collect(o, sel.SelectionSet, g); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The directivesOf function returns the directives of sel.
+func directivesOf(sel ast.Selection) []ast.Directive {
+	switch sel := sel.(type) {
+	case *ast.Field:
+		return sel.Directives
+	case *ast.FragmentSpread:
+		return sel.Directives
+	case *ast.InlineFragment:
+		return sel.Directives
+	}
+	return nil
+}
+
+// The applies method returns true if a fragment with the type condition named typeCondition applies to values of
+// type o.
+func (c *collector) applies(o *schema.Object, typeCondition string) bool {
+	t := c.schema.Type(typeCondition)
+	return t != nil && c.schema.IsPossibleType(t, o)
+}
+
+// The ShouldInclude function returns false if the @skip or @include directives in directives exclude their node from
+// execution. The "if" arguments must be Boolean literals, or variables with Boolean values in variables.
+func ShouldInclude(directives []ast.Directive, variables map[string]interface{}) (bool, error) {
+	for i := range directives {
+		d := &directives[i]
+		var include bool
+		switch d.Name.Value {
+		case schema.SkipDirective.Name():
+			include = false
+		case schema.IncludeDirective.Name():
+			include = true
+		default:
+			continue
+		}
+		v, err := ifArgument(d, variables)
+		if err != nil {
+			return false, err
+		}
+		if v != include {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// The ifArgument function returns the value of the "if" argument of the @skip or @include directive d.
+func ifArgument(d *ast.Directive, variables map[string]interface{}) (bool, error) {
+	for _, a := range d.Arguments {
+		if a.Name.Value != "if" {
+			continue
+		}
+		switch v := a.Value.(type) {
+		case *ast.Boolean:
+			return v.Value, nil
+		case *ast.Variable:
+			if b, ok := variables[v.Name.Value].(bool); ok {
+				return b, nil
+			}
+			return false, errors.Newf(v.Loc, "variable $%s of argument \"if\" of @%s must be a Boolean", v.Name.Value, d.Name.Value)
+		}
+		return false, errors.Newf(a.Loc, "argument \"if\" of @%s must be a Boolean", d.Name.Value)
+	}
+	return false, errors.Newf(d.Loc, "argument \"if\" of @%s is required", d.Name.Value)
+}
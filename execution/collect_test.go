@@ -0,0 +1,118 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+	"github.com/kr/pretty"
+)
+
+const testSDL = `
+type Query {a: Int, b: Int, c: Int, pet: Pet}
+interface Pet {name: String}
+type Dog implements Pet {name: String, barks: Boolean}
+type Cat implements Pet {name: String, meows: Boolean}
+`
+
+func mustBuild(t *testing.T, sdl string) *schema.Schema {
+	d, err := parser.ParseString(sdl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Build(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// The fragmentsOf function returns the fragments of d by name, and its first operation.
+func fragmentsOf(d *ast.Document) (map[string]*ast.FragmentDef, *ast.OpDef) {
+	fragments := make(map[string]*ast.FragmentDef)
+	var op *ast.OpDef
+	for _, def := range d.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDef:
+			fragments[def.Name.Value] = def
+		case *ast.OpDef:
+			if op == nil {
+				op = def
+			}
+		}
+	}
+	return fragments, op
+}
+
+// The summarize function returns the response keys of g, each followed by the names of its fields.
+func summarize(g *GroupedFields) []string {
+	var s []string
+	for _, k := range g.Keys {
+		e := k + ":"
+		for _, f := range g.Fields[k] {
+			e += " " + f.Name.Value
+		}
+		s = append(s, e)
+	}
+	return s
+}
+
+func TestCollectFields(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	for _, test := range []struct {
+		typ       string
+		query     string
+		variables map[string]interface{}
+		expected  []string
+	}{
+		{"Query", "{a b a}", nil, []string{"a: a a", "b: b"}},
+		{"Query", "{x: a, a, x: b}", nil, []string{"x: a b", "a: a"}},
+		{"Query", "{a @skip(if: true), b @skip(if: false), c @include(if: false)}", nil, []string{"b: b"}},
+		{"Query", "query($yes: Boolean!) {a @include(if: $yes), b @skip(if: $yes)}", map[string]interface{}{"yes": true},
+			[]string{"a: a"}},
+		{"Query", "{a @include(if: true) @skip(if: true), b @include(if: true) @skip(if: false)}", nil, []string{"b: b"}},
+		{"Query", "{...F, a, ...F} fragment F on Query {b a}", nil, []string{"b: b", "a: a a"}},
+		{"Query", "{...F @skip(if: true), ... @include(if: false) {b}, ... on Query {c}} fragment F on Query {a}", nil,
+			[]string{"c: c"}},
+		{"Dog", "{name, ... on Dog {barks}, ... on Cat {meows}, ...P} fragment P on Pet {name}", nil,
+			[]string{"name: name name", "barks: barks"}},
+		{"Cat", "{... on Dog {barks}, ... on Pet {name}, ...Missing, ... on Missing {meows}}", nil, []string{"name: name"}},
+	} {
+		d, err := parser.ParseString(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fragments, op := fragmentsOf(d)
+		g, err := CollectFields(s, fragments, test.variables, s.Type(test.typ).(*schema.Object), op.SelectionSet)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.query, err)
+			continue
+		}
+		if actual := summarize(g); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %# v but got %# v", test.query, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+func TestShouldIncludeErrors(t *testing.T) {
+	for _, test := range []struct {
+		query    string
+		expected string
+	}{
+		{"{a @skip}", `argument "if" of @skip is required (at position 3)`},
+		{`{a @include(if: "yes")}`, `argument "if" of @include must be a Boolean (at position 12)`},
+		{"query($v: Boolean) {a @skip(if: $v)}", `variable $v of argument "if" of @skip must be a Boolean (at position 32)`},
+	} {
+		d, err := parser.ParseString(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, op := fragmentsOf(d)
+		_, err = ShouldInclude(op.SelectionSet.Selections[0].(*ast.Field).Directives, nil)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected error %q but got %v", test.query, test.expected, err)
+		}
+	}
+}
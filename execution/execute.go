@@ -0,0 +1,387 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// A Response is the result of executing a request.
+type Response struct {
+	// The result of the operation, or nil if execution failed before it began, or a non-null root field was null.
+	Data *Map
+	// The errors raised during execution, in the order they occurred. Their Source is the Source of the document.
+	Errors []*errors.GraphQLError
+
+	// Set if an error was raised before execution began, so there is no data.
+	requestError bool
+}
+
+// The MarshalJSON method encodes r in the spec's response format, as an object with the "errors", if there are any,
+// and the "data", unless an error was raised before execution began.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if r.requestError {
+		return json.Marshal(struct {
+			Errors []*errors.GraphQLError `json:"errors"`
+		}{r.Errors})
+	}
+	return json.Marshal(struct {
+		Errors []*errors.GraphQLError `json:"errors,omitempty"`
+		Data   *Map                   `json:"data"`
+	}{r.Errors, r.Data})
+}
+
+// A Map is an ordered map of response keys to values, the result of executing a selection set. Values are nil,
+// *Map, []interface{}, or serialized leaf values.
+type Map struct {
+	// Response keys, in selection order.
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// The set method sets the value of key to v.
+func (m *Map) set(key string, v interface{}) {
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = v
+}
+
+// The Get method returns the value of key, or nil if it is not set.
+func (m *Map) Get(key string) interface{} { return m.Values[key] }
+
+// The MarshalJSON method encodes m as a JSON object, with keys in order.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.Keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.Values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// The Execute function executes the operation named operationName in d, or its only operation if operationName is
+// empty, against s, as described by the spec's ExecuteRequest algorithm. The raw variables are coerced to the types
+// of the operation's variables, and rootValue is the source of the root fields. The document should already be
+// validated.
+//
+// Fields are resolved serially, in selection order. Fields without a resolver use DefaultResolve. Errors raised while
+// resolving or completing a field are added to the response, and its value is null, or its parent's if it is
+// non-null.
+//
+// Subscriptions are executed once, like queries, to produce a single result.
+func Execute(ctx context.Context, s *schema.Schema, d *ast.Document, operationName string, variables map[string]interface{}, rootValue interface{}) *Response {
+	r := execute(ctx, s, d, operationName, variables, rootValue)
+	r.Errors = errors.List(r.Errors).WithSource(d.Source)
+	return r
+}
+
+// The execute function implements Execute, without locating errors in the source of d.
+func execute(ctx context.Context, s *schema.Schema, d *ast.Document, operationName string, variables map[string]interface{}, rootValue interface{}) *Response {
+	op, fragments, err := operation(d, operationName)
+	if err != nil {
+		return &Response{Errors: []*errors.GraphQLError{err}, requestError: true}
+	}
+	coerced, errs := CoerceVariableValues(s, op, variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs, requestError: true}
+	}
+	e := &executor{
+		schema:    s,
+		fragments: fragments,
+		variables: coerced,
+		rootValue: rootValue,
+		operation: op,
+	}
+	var root *schema.Object
+	switch op.OpType {
+	case ast.Query:
+		root = s.QueryType()
+	case ast.Mutation:
+		root = s.MutationType()
+	case ast.Subscription:
+		root = s.SubscriptionType()
+	}
+	if root == nil {
+		return &Response{Errors: []*errors.GraphQLError{errors.Newf(op.Loc, "Schema is not configured to execute %s operation.", op.OpType.String())}, requestError: true}
+	}
+	data, err := e.executeSelectionSet(ctx, root, []ast.SelectionSet{op.SelectionSet}, rootValue, nil)
+	if err != nil {
+		e.errs = append(e.errs, err)
+	}
+	return &Response{Data: data, Errors: e.errs}
+}
+
+// The operation function returns the operation named operationName in d, or its only operation if operationName is
+// empty, as described by the spec's GetOperation algorithm, along with the fragments of d by name.
+func operation(d *ast.Document, operationName string) (*ast.OpDef, map[string]*ast.FragmentDef, *errors.GraphQLError) {
+	var op *ast.OpDef
+	fragments := make(map[string]*ast.FragmentDef)
+	for _, def := range d.Definitions {
+		switch def := def.(type) {
+		case *ast.OpDef:
+			if operationName == "" {
+				if op != nil {
+					return nil, nil, errors.New("Must provide operation name if query contains multiple operations.")
+				}
+				op = def
+			} else if def.Name.Value == operationName && op == nil {
+				op = def
+			}
+		case *ast.FragmentDef:
+			fragments[def.Name.Value] = def
+		}
+	}
+	if op == nil {
+		if operationName != "" {
+			return nil, nil, errors.New(fmt.Sprintf("Unknown operation named %q.", operationName))
+		}
+		return nil, nil, errors.New("Must provide an operation.")
+	}
+	return op, fragments, nil
+}
+
+// An executor holds the state of a single execution.
+type executor struct {
+	schema    *schema.Schema
+	fragments map[string]*ast.FragmentDef
+	variables map[string]interface{}
+	rootValue interface{}
+	operation *ast.OpDef
+	// Errors of fields whose values were replaced by null.
+	errs []*errors.GraphQLError
+}
+
+// The executeSelectionSet method executes the fields selected by sets on value, of type o, and returns the result. An
+// error is returned if a non-null field was null, so that null must propagate to the parent field.
+func (e *executor) executeSelectionSet(ctx context.Context, o *schema.Object, sets []ast.SelectionSet, value interface{}, path []interface{}) (*Map, *errors.GraphQLError) {
+	g := &GroupedFields{Fields: make(map[string][]*ast.Field)}
+	for _, ss := range sets {
+		sub, err := CollectFields(e.schema, e.fragments, e.variables, o, ss)
+		if err != nil {
+			return nil, locatedError(err, nil, path)
+		}
+		for _, key := range sub.Keys {
+			for _, f := range sub.Fields[key] {
+				g.add(key, f)
+			}
+		}
+	}
+	m := &Map{Values: make(map[string]interface{}, len(g.Keys))}
+	for _, key := range g.Keys {
+		fields := g.Fields[key]
+		def := e.schema.Field(o, fields[0].Name.Value)
+		if def == nil {
+			continue
+		}
+		v, err := e.executeField(ctx, o, def, fields, value, appendPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		m.set(key, v)
+	}
+	return m, nil
+}
+
+// The executeField method resolves and completes the field def of o selected by fields on source, as described by
+// the spec's ExecuteField algorithm.
+func (e *executor) executeField(ctx context.Context, o *schema.Object, def *schema.Field, fields []*ast.Field, source interface{}, path []interface{}) (interface{}, *errors.GraphQLError) {
+	if err := ctx.Err(); err != nil {
+		return e.fieldError(locatedError(err, fields, path), def.Type())
+	}
+	args, err := CoerceArgumentValues(def.Args(), fields[0], e.variables)
+	if err != nil {
+		return e.fieldError(locatedError(err, fields, path), def.Type())
+	}
+	info := &schema.ResolveInfo{
+		FieldName:      def.Name(),
+		FieldASTs:      fields,
+		ReturnType:     def.Type(),
+		ParentType:     o,
+		Path:           path,
+		Schema:         e.schema,
+		Fragments:      e.fragments,
+		RootValue:      e.rootValue,
+		Operation:      e.operation,
+		VariableValues: e.variables,
+	}
+	resolve := def.Resolve()
+	if resolve == nil {
+		resolve = DefaultResolve
+	}
+	result, err := resolve(ctx, source, args, info)
+	if err != nil {
+		return e.fieldError(locatedError(err, fields, path), def.Type())
+	}
+	v, gqlErr := e.completeValue(ctx, def.Type(), fields, info, result, path)
+	if gqlErr != nil {
+		return e.fieldError(gqlErr, def.Type())
+	}
+	return v, nil
+}
+
+// The fieldError method handles err, raised by a field or list item of type t. Errors of non-null types are
+// returned, to propagate to the parent. Otherwise the error is recorded and the value is null.
+func (e *executor) fieldError(err *errors.GraphQLError, t schema.Type) (interface{}, *errors.GraphQLError) {
+	if _, ok := t.(*schema.NonNullType); ok {
+		return nil, err
+	}
+	e.errs = append(e.errs, err)
+	return nil, nil
+}
+
+// The completeValue method returns the result value of result, as described by the spec's CompleteValue algorithm.
+func (e *executor) completeValue(ctx context.Context, t schema.Type, fields []*ast.Field, info *schema.ResolveInfo, result interface{}, path []interface{}) (interface{}, *errors.GraphQLError) {
+	if nn, ok := t.(*schema.NonNullType); ok {
+		v, err := e.completeValue(ctx, nn.OfType(), fields, info, result, path)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, newError(fields, path, "Cannot return null for non-nullable field %s.%s.", info.ParentType.Name(), info.FieldName)
+		}
+		return v, nil
+	}
+	if isNil(result) {
+		return nil, nil
+	}
+	switch t := t.(type) {
+	case *schema.ListType:
+		rv := reflect.ValueOf(result)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, newError(fields, path, "Expected a list, but did not find one for field %s.%s.", info.ParentType.Name(), info.FieldName)
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			itemPath := appendPath(path, i)
+			v, err := e.completeValue(ctx, t.OfType(), fields, info, rv.Index(i).Interface(), itemPath)
+			if err != nil {
+				if v, err = e.fieldError(err, t.OfType()); err != nil {
+					return nil, err
+				}
+			}
+			list[i] = v
+		}
+		return list, nil
+
+	case *schema.Scalar:
+		v, err := t.Serialize(result)
+		if err != nil {
+			return nil, newError(fields, path, "%s", err)
+		}
+		return v, nil
+
+	case *schema.Enum:
+		for _, ev := range t.Values() {
+			if reflect.DeepEqual(ev.Value(), result) {
+				return ev.Name(), nil
+			}
+		}
+		return nil, newError(fields, path, "Enum %q cannot represent value: %s", t.Name(), inspect(result))
+
+	case *schema.Object:
+		return e.completeObject(ctx, t, fields, result, path)
+
+	case *schema.Interface, *schema.Union:
+		o := e.resolveType(ctx, t.(schema.NamedType), result, info)
+		if o == nil {
+			return nil, newError(fields, path, "Abstract type %q must resolve to an Object type at runtime for field %s.%s. Either the %q type should have a ResolveType function or the value should have a \"__typename\".", t, info.ParentType.Name(), info.FieldName, t)
+		}
+		if !e.schema.IsPossibleType(t.(schema.NamedType), o) {
+			return nil, newError(fields, path, "Runtime Object type %q is not a possible type for %q.", o.Name(), t)
+		}
+		return e.completeObject(ctx, o, fields, result, path)
+	}
+	return nil, newError(fields, path, "Cannot complete value of unexpected output type %q.", t)
+}
+
+// The completeObject method executes the merged sub-selections of fields on result, of type o.
+func (e *executor) completeObject(ctx context.Context, o *schema.Object, fields []*ast.Field, result interface{}, path []interface{}) (interface{}, *errors.GraphQLError) {
+	sets := make([]ast.SelectionSet, len(fields))
+	for i, f := range fields {
+		sets[i] = f.SelectionSet
+	}
+	m, err := e.executeSelectionSet(ctx, o, sets, result, path)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// The resolveType method returns the object type of value, of the abstract type t, or nil if it can not be
+// determined. The ResolveType function of t is used if it has one. Otherwise the type is named by the "__typename" of
+// value.
+func (e *executor) resolveType(ctx context.Context, t schema.NamedType, value interface{}, info *schema.ResolveInfo) *schema.Object {
+	var resolveType schema.ResolveTypeFunc
+	switch t := t.(type) {
+	case *schema.Interface:
+		resolveType = t.ResolveType()
+	case *schema.Union:
+		resolveType = t.ResolveType()
+	}
+	if resolveType != nil {
+		return resolveType(ctx, value, info)
+	}
+	if name, ok := property(value, "__typename"); ok {
+		if name, ok := name.(string); ok {
+			o, _ := e.schema.Type(name).(*schema.Object)
+			return o
+		}
+	}
+	return nil
+}
+
+// The locatedError function returns err as a GraphQLError located at fields, and at path in the response. The
+// message and locations of a GraphQLError are kept.
+func locatedError(err error, fields []*ast.Field, path []interface{}) *errors.GraphQLError {
+	e, ok := err.(*errors.GraphQLError)
+	if !ok {
+		e = &errors.GraphQLError{Message: err.Error()}
+	} else {
+		copied := *e
+		e = &copied
+	}
+	if len(e.Locations) == 0 {
+		for _, f := range fields {
+			e.Locations = append(e.Locations, f.Loc)
+		}
+	}
+	if e.Path == nil {
+		e.Path = path
+	}
+	return e
+}
+
+// The newError function returns a GraphQLError located at fields, and at path in the response, with a message
+// formatted by fmt.Sprintf.
+func newError(fields []*ast.Field, path []interface{}, format string, args ...interface{}) *errors.GraphQLError {
+	return locatedError(fmt.Errorf(format, args...), fields, path)
+}
+
+// The appendPath function returns a copy of path with elem appended, so that paths are never shared.
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	p := make([]interface{}, len(path)+1)
+	copy(p, path)
+	p[len(path)] = elem
+	return p
+}
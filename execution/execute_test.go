@@ -0,0 +1,316 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+	"github.com/kr/pretty"
+)
+
+const executeSDL = `
+type Query {
+	hello: String
+	count: Int
+	user: User
+	users: [User!]
+	ints: [Int]
+	strict: [Int!]
+	pet: Pet
+	pets: [Pet]
+	search: [Result]
+	color: Color
+	colors: [Color]
+	required: String!
+	nested: Nested
+}
+type User {id: ID!, name: String, best: User}
+type Nested {required: String!, other: String}
+interface Pet {name: String}
+type Dog implements Pet {name: String, barks: Boolean}
+type Cat implements Pet {name: String, meows: Boolean}
+union Result = Dog | User
+enum Color {RED GREEN}
+`
+
+type testUser struct {
+	ID   string `json:"id"`
+	Name string
+	Best *testUser
+}
+
+type testResult struct {
+	Typename string `json:"__typename"`
+	testUser
+}
+
+type testRoot struct{}
+
+func (testRoot) Hello() string { return "method" }
+
+func (testRoot) Count(ctx context.Context) (int, error) { return 0, errors.New("count failed") }
+
+// The summarizeResponse function returns the data of r encoded as JSON, and its errors, each followed by its path.
+func summarizeResponse(t *testing.T, r *Response) (string, []string) {
+	data := "null"
+	if r.Data != nil {
+		b, err := json.Marshal(r.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = string(b)
+	}
+	var errs []string
+	for _, e := range r.Errors {
+		errs = append(errs, fmt.Sprintf("%s %v", e.Message, e.Path))
+	}
+	return data, errs
+}
+
+func TestExecute(t *testing.T) {
+	s := mustBuild(t, executeSDL)
+	root := map[string]interface{}{
+		"hello":  "world",
+		"user":   &testUser{ID: "1", Name: "a", Best: &testUser{ID: "2", Name: "b"}},
+		"users":  []testUser{{ID: "1"}, {ID: "2"}},
+		"ints":   []int{1, 2},
+		"strict": []interface{}{1, nil, 3},
+		"pet":    map[string]interface{}{"__typename": "Dog", "name": "rex", "barks": true},
+		"pets": []interface{}{
+			map[string]interface{}{"__typename": "Cat", "name": "tom", "meows": true},
+			map[string]interface{}{"__typename": "User"},
+			map[string]interface{}{"name": "unknown"},
+		},
+		"search":   []interface{}{map[string]interface{}{"__typename": "Dog", "name": "rex"}, testResult{"User", testUser{ID: "3"}}},
+		"color":    "GREEN",
+		"colors":   []string{"RED", "BLUE"},
+		"required": (*string)(nil),
+		"nested":   map[string]interface{}{"other": "x"},
+	}
+	for _, test := range []struct {
+		name           string
+		query          string
+		operationName  string
+		variables      map[string]interface{}
+		root           interface{}
+		expectedData   string
+		expectedErrors []string
+	}{
+		{name: "fields", query: "{hello, alias: hello, ...F, ... on Query {user {id name}}} fragment F on Query {hello}",
+			expectedData: `{"hello":"world","alias":"world","user":{"id":"1","name":"a"}}`},
+		{name: "merged", query: "{user {id} user {best {name}} user {best {id}}}",
+			expectedData: `{"user":{"id":"1","best":{"name":"b","id":"2"}}}`},
+		{name: "directives", query: "query($skip: Boolean!) {hello @skip(if: $skip), color @include(if: $skip)}",
+			variables: map[string]interface{}{"skip": true}, expectedData: `{"color":"GREEN"}`},
+		{name: "typename", query: "{__typename, user {__typename}}",
+			expectedData: `{"__typename":"Query","user":{"__typename":"User"}}`},
+		{name: "lists", query: "{users {id}, ints}", expectedData: `{"users":[{"id":"1"},{"id":"2"}],"ints":[1,2]}`},
+		{name: "non-null list item", query: "{strict, hello}", expectedData: `{"strict":null,"hello":"world"}`,
+			expectedErrors: []string{"Cannot return null for non-nullable field Query.strict. [strict 1]"}},
+		{name: "interface", query: "{pet {name ... on Dog {barks}}}",
+			expectedData: `{"pet":{"name":"rex","barks":true}}`},
+		{name: "abstract errors", query: "{pets {name ... on Cat {meows}}}",
+			expectedData: `{"pets":[{"name":"tom","meows":true},null,null]}`,
+			expectedErrors: []string{
+				`Runtime Object type "User" is not a possible type for "Pet". [pets 1]`,
+				`Abstract type "Pet" must resolve to an Object type at runtime for field Query.pets. Either the "Pet" type should have a ResolveType function or the value should have a "__typename". [pets 2]`,
+			}},
+		{name: "union", query: "{search {__typename ... on Dog {name} ... on User {id}}}",
+			expectedData: `{"search":[{"__typename":"Dog","name":"rex"},{"__typename":"User","id":"3"}]}`},
+		{name: "enums", query: "{color, colors}", expectedData: `{"color":"GREEN","colors":["RED",null]}`,
+			expectedErrors: []string{`Enum "Color" cannot represent value: "BLUE" [colors 1]`}},
+		{name: "non-null root", query: "{hello, required}", expectedData: "null",
+			expectedErrors: []string{"Cannot return null for non-nullable field Query.required. [required]"}},
+		{name: "non-null propagation", query: "{nested {other, required}}", expectedData: `{"nested":null}`,
+			expectedErrors: []string{"Cannot return null for non-nullable field Nested.required. [nested required]"}},
+		{name: "methods", query: "{hello, count}", root: testRoot{}, expectedData: `{"hello":"method","count":null}`,
+			expectedErrors: []string{"count failed [count]"}},
+		{name: "nil root", query: "{hello, user {id}}", root: (*testUser)(nil), expectedData: `{"hello":null,"user":null}`},
+		{name: "operation name", query: "query A {hello} query B {color}", operationName: "B",
+			expectedData: `{"color":"GREEN"}`},
+		{name: "multiple operations", query: "query A {hello} query B {color}", expectedData: "null",
+			expectedErrors: []string{"Must provide operation name if query contains multiple operations. []"}},
+		{name: "unknown operation", query: "{hello}", operationName: "A", expectedData: "null",
+			expectedErrors: []string{`Unknown operation named "A". []`}},
+		{name: "no operation", query: "fragment F on Query {hello}", expectedData: "null",
+			expectedErrors: []string{"Must provide an operation. []"}},
+		{name: "no mutation type", query: "mutation {hello}", expectedData: "null",
+			expectedErrors: []string{"Schema is not configured to execute mutation operation. []"}},
+		{name: "variables", query: "query($a: Boolean!, $b: Boolean, $c: Boolean! = true, $d: Int!) {hello}",
+			variables: map[string]interface{}{"b": "yes", "d": nil}, expectedData: "null",
+			expectedErrors: []string{
+				`Variable "$a" of required type "Boolean!" was not provided. []`,
+				`Variable "$b" got invalid value "yes"; Expected type "Boolean". Boolean cannot represent a non boolean value: "yes" []`,
+				`Variable "$d" of non-null type "Int!" must not be null. []`,
+			}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := parser.ParseString(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rootValue := test.root
+			if rootValue == nil {
+				rootValue = root
+			}
+			r := Execute(context.Background(), s, d, test.operationName, test.variables, rootValue)
+			data, errs := summarizeResponse(t, r)
+			if data != test.expectedData {
+				t.Errorf("expected data %s but got %s", test.expectedData, data)
+			}
+			if !reflect.DeepEqual(errs, test.expectedErrors) {
+				t.Errorf("expected errors %# v but got %# v", pretty.Formatter(test.expectedErrors), pretty.Formatter(errs))
+			}
+		})
+	}
+}
+
+func resolveArgs(ctx context.Context, source interface{}, args map[string]interface{}, info *schema.ResolveInfo) (interface{}, error) {
+	return fmt.Sprint(args), nil
+}
+
+func TestExecuteResolvers(t *testing.T) {
+	var calls []string
+	add := func(ctx context.Context, source interface{}, args map[string]interface{}, info *schema.ResolveInfo) (interface{}, error) {
+		calls = append(calls, fmt.Sprint(info.Path...))
+		return len(calls), nil
+	}
+	s, err := schema.NewSchema().
+		Query(schema.NewObject("Query").
+			Field("args", schema.String, resolveArgs,
+				schema.Arg("s", schema.NonNull(schema.String), nil),
+				schema.Arg("n", schema.Int, &ast.Int{Value: "3"}),
+				schema.Arg("role", schema.Ref("Role"), nil),
+				schema.Arg("input", schema.Ref("Input"), nil)).
+			Field("role", schema.Ref("Role"), func(context.Context, interface{}, map[string]interface{}, *schema.ResolveInfo) (interface{}, error) {
+				return 2, nil
+			}).
+			Field("node", schema.Ref("Node"), func(context.Context, interface{}, map[string]interface{}, *schema.ResolveInfo) (interface{}, error) {
+				return "anything", nil
+			}).
+			Field("named", schema.Ref("Named"), func(context.Context, interface{}, map[string]interface{}, *schema.ResolveInfo) (interface{}, error) {
+				return "anything", nil
+			}).
+			Field("fail", schema.NonNull(schema.String), func(context.Context, interface{}, map[string]interface{}, *schema.ResolveInfo) (interface{}, error) {
+				return nil, errors.New("failed")
+			})).
+		Mutation(schema.NewObject("Mutation").
+			Field("add", schema.Int, add)).
+		Types(
+			schema.NewEnum("Role").Value("ADMIN", 1).Value("USER", 2),
+			schema.NewInputObject("Input").
+				Field("a", schema.NonNull(schema.Int), nil).
+				Field("b", schema.String, &ast.String{Value: "default"}),
+			schema.NewInterface("Node").
+				Field("id", schema.ID).
+				ResolveType(func(context.Context, interface{}, *schema.ResolveInfo) *schema.Object { return nil }),
+			schema.NewObject("Thing").Implements("Node").
+				Field("id", schema.ID, func(context.Context, interface{}, map[string]interface{}, *schema.ResolveInfo) (interface{}, error) {
+					return 7, nil
+				}),
+			schema.NewInterface("Named").
+				Field("name", schema.String),
+			schema.NewObject("Person").Implements("Named").
+				Field("name", schema.String, nil),
+		).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name           string
+		query          string
+		variables      map[string]interface{}
+		expectedData   string
+		expectedErrors []string
+	}{
+		{name: "arguments", query: `{args(s: "x", role: ADMIN, input: {a: 1})}`,
+			expectedData: `{"args":"map[input:map[a:1 b:default] n:3 role:1 s:x]"}`},
+		{name: "variable arguments", query: `query($s: String!, $n: Int, $i: Input) {args(s: $s, n: $n, input: $i)}`,
+			variables:    map[string]interface{}{"s": "y", "i": map[string]interface{}{"a": float64(2)}},
+			expectedData: `{"args":"map[input:map[a:2 b:default] n:3 s:y]"}`},
+		{name: "invalid variable", query: `query($i: Input) {args(s: "x", input: $i)}`,
+			variables: map[string]interface{}{"i": map[string]interface{}{"a": 1, "c": 2}}, expectedData: "null",
+			expectedErrors: []string{`Variable "$i" got invalid value map[a:1 c:2]; Field "c" is not defined by type "Input". []`}},
+		{name: "enum values", query: `{role}`, expectedData: `{"role":"USER"}`},
+		{name: "resolve type", query: `{node {id}}`, expectedData: `{"node":null}`,
+			expectedErrors: []string{`Abstract type "Node" must resolve to an Object type at runtime for field Query.node. Either the "Node" type should have a ResolveType function or the value should have a "__typename". [node]`}},
+		// The only possible type is not assumed.
+		{name: "single possible type", query: `{named {name}}`, expectedData: `{"named":null}`,
+			expectedErrors: []string{`Abstract type "Named" must resolve to an Object type at runtime for field Query.named. Either the "Named" type should have a ResolveType function or the value should have a "__typename". [named]`}},
+		{name: "resolver error", query: `{role, fail}`, expectedData: "null",
+			expectedErrors: []string{"failed [fail]"}},
+		{name: "mutation", query: `mutation {a: add, b: add, c: add}`, expectedData: `{"a":1,"b":2,"c":3}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			calls = nil
+			d, err := parser.ParseString(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := Execute(context.Background(), s, d, "", test.variables, nil)
+			data, errs := summarizeResponse(t, r)
+			if data != test.expectedData {
+				t.Errorf("expected data %s but got %s", test.expectedData, data)
+			}
+			if !reflect.DeepEqual(errs, test.expectedErrors) {
+				t.Errorf("expected errors %# v but got %# v", pretty.Formatter(test.expectedErrors), pretty.Formatter(errs))
+			}
+		})
+	}
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected mutations %v but got %v", expected, calls)
+	}
+}
+
+func TestExecuteCanceled(t *testing.T) {
+	s := mustBuild(t, executeSDL)
+	d, err := parser.ParseString("{hello}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := Execute(ctx, s, d, "", nil, map[string]interface{}{"hello": "world"})
+	data, errs := summarizeResponse(t, r)
+	if expected := `{"hello":null}`; data != expected {
+		t.Errorf("expected data %s but got %s", expected, data)
+	}
+	if expected := []string{"context canceled [hello]"}; !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected errors %v but got %v", expected, errs)
+	}
+}
+
+func TestResponseJSON(t *testing.T) {
+	s := mustBuild(t, executeSDL)
+	for _, test := range []struct {
+		query    string
+		expected string
+	}{
+		{"{hello}", `{"data":{"hello":"method"}}`},
+		{"{\n  hello\r\n  count\n}", `{"errors":[{"message":"count failed","locations":[{"line":3,"column":3}],"path":["count"]}],"data":{"hello":"method","count":null}}`},
+		{"{hello, required}", `{"errors":[{"message":"Cannot return null for non-nullable field Query.required.","locations":[{"line":1,"column":9}],"path":["required"]}],"data":null}`},
+		// Errors raised before execution began have no data.
+		{"query Q {hello} query R {hello}", `{"errors":[{"message":"Must provide operation name if query contains multiple operations."}]}`},
+		{"query ($n: Int!) {hello}", `{"errors":[{"message":"Variable \"$n\" of required type \"Int!\" was not provided.","locations":[{"line":1,"column":8}]}]}`},
+	} {
+		d, err := parser.ParseString(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := Execute(context.Background(), s, d, "", nil, testRoot{})
+		b, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := string(b); actual != test.expected {
+			t.Errorf("%q: expected %s but got %s", test.query, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package execution
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jmank88/gql/schema"
+)
+
+// The DefaultResolve function resolves fields without a resolver. It returns the property of the source named by the
+// field, which is either:
+//   - the value of a map with string keys at the field name,
+//   - the exported struct field whose json tag name, or else whose name, case-insensitively, is the field name, or
+//   - the result of the exported method named by the capitalized field name, taking no arguments or a
+//     context.Context, and returning a value and optionally an error.
+//
+// Pointers are dereferenced, and a missing property resolves to nil.
+func DefaultResolve(ctx context.Context, source interface{}, args map[string]interface{}, info *schema.ResolveInfo) (interface{}, error) {
+	if m, ok := method(source, info.FieldName); ok {
+		in := []reflect.Value{}
+		if m.Type().NumIn() == 1 {
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			in = append(in, reflect.ValueOf(ctx))
+		}
+		out := m.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+		return out[0].Interface(), nil
+	}
+	v, _ := property(source, info.FieldName)
+	return v, nil
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// The method function returns the exported method of source named by the capitalized name, if it has one with a
+// signature supported by DefaultResolve.
+func method(source interface{}, name string) (reflect.Value, bool) {
+	r, size := utf8.DecodeRuneInString(name)
+	if source == nil || size == 0 || !unicode.IsLetter(r) {
+		return reflect.Value{}, false
+	}
+	m := reflect.ValueOf(source).MethodByName(string(unicode.ToUpper(r)) + name[size:])
+	if !m.IsValid() {
+		return reflect.Value{}, false
+	}
+	t := m.Type()
+	if t.NumIn() > 1 || (t.NumIn() == 1 && t.In(0) != contextType) {
+		return reflect.Value{}, false
+	}
+	switch t.NumOut() {
+	case 1:
+		return m, true
+	case 2:
+		return m, t.Out(1) == errorType
+	}
+	return reflect.Value{}, false
+}
+
+// The property function returns the value of source named name, and true if it has one. Source may be a map with
+// string keys, or a struct, or pointers to either.
+func property(source interface{}, name string) (interface{}, bool) {
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		e := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !e.IsValid() {
+			return nil, false
+		}
+		return e.Interface(), true
+	case reflect.Struct:
+		if f, ok := structField(v, name); ok {
+			return f.Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// The structField function returns the exported field of the struct v named name, by json tag or case-insensitively,
+// including the fields of embedded structs. Tags take precedence, and shallower fields over deeper ones.
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	var byName reflect.Value
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		if tag := strings.Split(sf.Tag.Get("json"), ",")[0]; tag != "" {
+			if tag == name {
+				return v.Field(i), true
+			}
+			continue
+		}
+		if sf.Anonymous {
+			f := v.Field(i)
+			for f.Kind() == reflect.Ptr && !f.IsNil() {
+				f = f.Elem()
+			}
+			if f.Kind() == reflect.Struct {
+				embedded = append(embedded, f)
+			}
+			continue
+		}
+		if !byName.IsValid() && strings.EqualFold(sf.Name, name) {
+			byName = v.Field(i)
+		}
+	}
+	if byName.IsValid() {
+		return byName, true
+	}
+	for _, f := range embedded {
+		if v, ok := structField(f, name); ok {
+			return v, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,70 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/schema"
+)
+
+type resolveBase struct {
+	Base  string
+	Label string
+}
+
+type resolveSource struct {
+	resolveBase
+	Label    string `json:"title"`
+	Renamed  int    `json:"other"`
+	hidden   string
+	Pointer  *int
+	Embedded *resolveBase
+}
+
+func (*resolveSource) Greeting() string { return "hello" }
+
+func (resolveSource) Fail(ctx context.Context) (string, error) { return "", errors.New("failed") }
+
+func (resolveSource) Args(a int) int { return a }
+
+func TestDefaultResolve(t *testing.T) {
+	source := &resolveSource{resolveBase: resolveBase{Base: "base", Label: "base label"}, Label: "label", Renamed: 1, hidden: "x"}
+	for _, test := range []struct {
+		source   interface{}
+		field    string
+		expected interface{}
+		err      string
+	}{
+		{map[string]interface{}{"a": 1}, "a", 1, ""},
+		{map[string]int{"a": 1}, "b", nil, ""},
+		{map[int]int{1: 1}, "1", nil, ""},
+		{source, "title", "label", ""},
+		{source, "other", 1, ""},
+		{source, "renamed", nil, ""},
+		{source, "base", "base", ""},
+		{source, "label", "base label", ""},
+		{source, "hidden", nil, ""},
+		{source, "pointer", (*int)(nil), ""},
+		{source, "greeting", "hello", ""},
+		{*source, "greeting", nil, ""},
+		{source, "fail", nil, "failed"},
+		{source, "args", nil, ""},
+		{(*resolveSource)(nil), "title", nil, ""},
+		{nil, "a", nil, ""},
+	} {
+		v, err := DefaultResolve(context.Background(), test.source, nil, &schema.ResolveInfo{FieldName: test.field})
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%T %s: expected error %q but got %v", test.source, test.field, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%T %s: unexpected error: %s", test.source, test.field, err)
+		} else if !reflect.DeepEqual(v, test.expected) {
+			t.Errorf("%T %s: expected %#v but got %#v", test.source, test.field, test.expected, v)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+	"github.com/jmank88/gql/schema"
+)
+
+// The CoerceVariableValues function returns the values of the variables defined by op, coerced from the raw input
+// values to their types, as described by the spec's CoerceVariableValues algorithm. Variables which are not given
+// and have no default value are absent from the result.
+func CoerceVariableValues(s *schema.Schema, op *ast.OpDef, values map[string]interface{}) (map[string]interface{}, []*errors.GraphQLError) {
+	coerced := make(map[string]interface{})
+	var errs []*errors.GraphQLError
+	for i := range op.VarDefs {
+		vd := &op.VarDefs[i]
+		name := vd.Variable.Name.Value
+		t := s.TypeFromAST(vd.RefType)
+		if t == nil || !schema.IsInputType(t) {
+			errs = append(errs, errors.Newf(vd.Loc, "Variable \"$%s\" expected value of type %q which cannot be used as an input type.", name, printNode(vd.RefType)))
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			if vd.DefaultValue != nil {
+				v, err := ValueFromAST(vd.DefaultValue, t, nil)
+				if err != nil {
+					errs = append(errs, errors.Newf(vd.Loc, "Variable \"$%s\" has invalid default value: %s", name, err))
+					continue
+				}
+				coerced[name] = v
+			} else if _, nonNull := t.(*schema.NonNullType); nonNull {
+				errs = append(errs, errors.Newf(vd.Loc, "Variable \"$%s\" of required type %q was not provided.", name, t.String()))
+			}
+			continue
+		}
+		if _, nonNull := t.(*schema.NonNullType); nonNull && isNil(value) {
+			errs = append(errs, errors.Newf(vd.Loc, "Variable \"$%s\" of non-null type %q must not be null.", name, t.String()))
+			continue
+		}
+		v, err := CoerceInputValue(value, t)
+		if err != nil {
+			errs = append(errs, errors.Newf(vd.Loc, "Variable \"$%s\" got invalid value %s; %s", name, inspect(value), err))
+			continue
+		}
+		coerced[name] = v
+	}
+	return coerced, errs
+}
+
+// The CoerceInputValue function coerces value, a raw input value such as one decoded from JSON, to the input type t.
+// Lists may be any slice or array, and input objects must be map[string]interface{}.
+func CoerceInputValue(value interface{}, t schema.Type) (interface{}, error) {
+	if nn, ok := t.(*schema.NonNullType); ok {
+		if isNil(value) {
+			return nil, fmt.Errorf("Expected non-nullable type %q not to be null.", t.String())
+		}
+		return CoerceInputValue(value, nn.OfType())
+	}
+	if isNil(value) {
+		return nil, nil
+	}
+	switch t := t.(type) {
+	case *schema.ListType:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			// A single value is coerced to a list of one item.
+			item, err := CoerceInputValue(value, t.OfType())
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			item, err := CoerceInputValue(rv.Index(i).Interface(), t.OfType())
+			if err != nil {
+				return nil, fmt.Errorf("At index %d: %s", i, err)
+			}
+			list[i] = item
+		}
+		return list, nil
+
+	case *schema.InputObject:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected type %q to be an object.", t.Name())
+		}
+		o := make(map[string]interface{}, len(m))
+		for _, f := range t.Fields() {
+			fv, ok := m[f.Name()]
+			if !ok {
+				if f.DefaultValue() != nil {
+					v, err := ValueFromAST(f.DefaultValue(), f.Type(), nil)
+					if err != nil {
+						return nil, err
+					}
+					o[f.Name()] = v
+				} else if _, nonNull := f.Type().(*schema.NonNullType); nonNull {
+					return nil, fmt.Errorf("Field %q of required type %q was not provided.", f.Name(), f.Type().String())
+				}
+				continue
+			}
+			v, err := CoerceInputValue(fv, f.Type())
+			if err != nil {
+				return nil, fmt.Errorf("At field %q: %s", f.Name(), err)
+			}
+			o[f.Name()] = v
+		}
+		// Report unknown fields in a deterministic order.
+		var unknown []string
+		for name := range m {
+			if t.Field(name) == nil {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("Field %q is not defined by type %q.", unknown[0], t.Name())
+		}
+		return o, nil
+
+	case *schema.Enum:
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("Enum %q cannot represent non-string value: %s.", t.Name(), inspect(value))
+		}
+		v := t.Value(name)
+		if v == nil {
+			return nil, fmt.Errorf("Value %q does not exist in %q enum.", name, t.Name())
+		}
+		return v.Value(), nil
+
+	case *schema.Scalar:
+		v, err := t.ParseValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("Expected type %q. %s", t.Name(), err)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("Expected an input type, but found %q.", t.String())
+}
+
+// The ValueFromAST function returns the value of the literal v, coerced to the input type t. Variables are replaced
+// by their values in variables, which must already be coerced. An error is returned if v is invalid for t, or uses a
+// variable which has no value where one is required.
+func ValueFromAST(v ast.Value, t schema.Type, variables map[string]interface{}) (interface{}, error) {
+	if variable, ok := v.(*ast.Variable); ok {
+		value, ok := variables[variable.Name.Value]
+		if !ok || isNil(value) {
+			if _, nonNull := t.(*schema.NonNullType); nonNull {
+				return nil, fmt.Errorf("Expected a value of non-null type %q for variable \"$%s\".", t.String(), variable.Name.Value)
+			}
+		}
+		return value, nil
+	}
+	switch t := t.(type) {
+	case *schema.NonNullType:
+		return ValueFromAST(v, t.OfType(), variables)
+
+	case *schema.ListType:
+		l, ok := v.(*ast.List)
+		if !ok {
+			item, err := ValueFromAST(v, t.OfType(), variables)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+		list := make([]interface{}, len(l.Values))
+		for i, item := range l.Values {
+			value, err := ValueFromAST(item, t.OfType(), variables)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+
+	case *schema.InputObject:
+		obj, ok := v.(*ast.Object)
+		if !ok {
+			return nil, fmt.Errorf("Expected type %q, found %s.", t.Name(), printNode(v))
+		}
+		fields := make(map[string]ast.Value, len(obj.Fields))
+		for _, f := range obj.Fields {
+			if t.Field(f.Name.Value) == nil {
+				return nil, fmt.Errorf("Field %q is not defined by type %q.", f.Name.Value, t.Name())
+			}
+			fields[f.Name.Value] = f.Value
+		}
+		o := make(map[string]interface{}, len(fields))
+		for _, f := range t.Fields() {
+			fv, ok := fields[f.Name()]
+			if ok {
+				// Variables without values are treated as absent fields.
+				if variable, isVar := fv.(*ast.Variable); isVar {
+					_, ok = variables[variable.Name.Value]
+				}
+			}
+			if !ok {
+				fv = f.DefaultValue()
+				if fv == nil {
+					if _, nonNull := f.Type().(*schema.NonNullType); nonNull {
+						return nil, fmt.Errorf("Field %q of required type %q was not provided.", f.Name(), f.Type().String())
+					}
+					continue
+				}
+			}
+			value, err := ValueFromAST(fv, f.Type(), variables)
+			if err != nil {
+				return nil, err
+			}
+			o[f.Name()] = value
+		}
+		return o, nil
+
+	case *schema.Enum:
+		e, ok := v.(*ast.Enum)
+		if !ok {
+			return nil, fmt.Errorf("Enum %q cannot represent non-enum value: %s.", t.Name(), printNode(v))
+		}
+		value := t.Value(e.Value)
+		if value == nil {
+			return nil, fmt.Errorf("Value %q does not exist in %q enum.", e.Value, t.Name())
+		}
+		return value.Value(), nil
+
+	case *schema.Scalar:
+		return t.ParseLiteral(v)
+	}
+	return nil, fmt.Errorf("Expected an input type, but found %q.", t.String())
+}
+
+// The CoerceArgumentValues function returns the values of the arguments of f, defined by argDefs, coerced to their
+// types, as described by the spec's CoerceArgumentValues algorithm. Arguments which are not given and have no default
+// value are absent from the result.
+func CoerceArgumentValues(argDefs []*schema.InputValue, f *ast.Field, variables map[string]interface{}) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{}, len(argDefs))
+	for _, def := range argDefs {
+		name := def.Name()
+		t := def.Type()
+		_, nonNull := t.(*schema.NonNullType)
+		var arg *ast.Argument
+		for i := range f.Arguments {
+			if f.Arguments[i].Name.Value == name {
+				arg = &f.Arguments[i]
+				break
+			}
+		}
+		hasValue := arg != nil
+		if arg != nil {
+			if v, ok := arg.Value.(*ast.Variable); ok {
+				_, hasValue = variables[v.Name.Value]
+				if !hasValue && def.DefaultValue() == nil && nonNull {
+					return nil, errors.Newf(arg.Loc, "Argument %q of required type %q was provided the variable \"$%s\" which was not provided a runtime value.", name, t.String(), v.Name.Value)
+				}
+			}
+		}
+		if !hasValue {
+			if def.DefaultValue() != nil {
+				v, err := ValueFromAST(def.DefaultValue(), t, nil)
+				if err != nil {
+					return nil, errors.Newf(f.Loc, "Argument %q has invalid default value: %s", name, err)
+				}
+				coerced[name] = v
+			} else if nonNull {
+				return nil, errors.Newf(f.Loc, "Argument %q of required type %q was not provided.", name, t.String())
+			}
+			continue
+		}
+		v, err := ValueFromAST(arg.Value, t, variables)
+		if err != nil {
+			return nil, errors.Newf(arg.Loc, "Argument %q has invalid value %s: %s", name, printNode(arg.Value), err)
+		}
+		if nonNull && isNil(v) {
+			return nil, errors.Newf(arg.Loc, "Argument %q of non-null type %q must not be null.", name, t.String())
+		}
+		coerced[name] = v
+	}
+	return coerced, nil
+}
+
+// The isNil function returns true if v is nil, or a nil pointer, map, slice, interface, function, or channel.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// The printNode function returns n in compact GraphQL syntax.
+func printNode(n ast.Node) string {
+	var b bytes.Buffer
+	if err := printer.Compact.Fprint(&b, n); err != nil {
+		return n.Kind()
+	}
+	return b.String()
+}
+
+// The inspect function returns a representation of the raw value v for error messages.
+func inspect(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,100 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+	"github.com/kr/pretty"
+)
+
+const valuesSDL = `
+type Query {a: Int}
+enum Color {RED GREEN}
+input Point {x: Int!, y: Int = 0, color: Color}
+`
+
+func TestCoerceInputValue(t *testing.T) {
+	s := mustBuild(t, valuesSDL)
+	for _, test := range []struct {
+		typ      schema.Type
+		value    interface{}
+		expected interface{}
+		err      string
+	}{
+		{schema.Int, float64(3), 3, ""},
+		{schema.NonNull(schema.Int), nil, nil, `Expected non-nullable type "Int!" not to be null.`},
+		{schema.List(schema.Int), []float64{1, 2}, []interface{}{1, 2}, ""},
+		{schema.List(schema.Int), 1, []interface{}{1}, ""},
+		{schema.List(schema.Int), []interface{}{1, "a"}, nil,
+			`At index 1: Expected type "Int". Int cannot represent non-integer value: "a"`},
+		{s.Type("Color"), "RED", "RED", ""},
+		{s.Type("Color"), "BLUE", nil, `Value "BLUE" does not exist in "Color" enum.`},
+		{s.Type("Point"), map[string]interface{}{"x": 1, "color": "GREEN"},
+			map[string]interface{}{"x": 1, "y": 0, "color": "GREEN"}, ""},
+		{s.Type("Point"), map[string]interface{}{"y": 1}, nil, `Field "x" of required type "Int!" was not provided.`},
+		{s.Type("Point"), map[string]interface{}{"x": 1, "z": 1}, nil, `Field "z" is not defined by type "Point".`},
+		{s.Type("Point"), "x", nil, `Expected type "Point" to be an object.`},
+	} {
+		v, err := CoerceInputValue(test.value, test.typ)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s %v: expected error %q but got %v", test.typ, test.value, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %v: unexpected error: %s", test.typ, test.value, err)
+		} else if !reflect.DeepEqual(v, test.expected) {
+			t.Errorf("%s %v: expected %# v but got %# v", test.typ, test.value, pretty.Formatter(test.expected), pretty.Formatter(v))
+		}
+	}
+}
+
+// The parseLiteral function returns the value literal, which may contain variables.
+func parseLiteral(t *testing.T, literal string) ast.Value {
+	d, err := parser.ParseString("{f(a: " + literal + ")}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d.Definitions[0].(*ast.OpDef).Selections[0].(*ast.Field).Arguments[0].Value
+}
+
+func TestValueFromAST(t *testing.T) {
+	s := mustBuild(t, valuesSDL)
+	variables := map[string]interface{}{"one": 1}
+	for _, test := range []struct {
+		typ      schema.Type
+		literal  string
+		expected interface{}
+		err      string
+	}{
+		{schema.Int, "3", 3, ""},
+		{schema.Int, "$one", 1, ""},
+		{schema.Int, "$none", nil, ""},
+		{schema.NonNull(schema.Int), "$none", nil, `Expected a value of non-null type "Int!" for variable "$none".`},
+		{schema.List(schema.Int), "[1, $one]", []interface{}{1, 1}, ""},
+		{schema.List(schema.Int), "2", []interface{}{2}, ""},
+		{s.Type("Color"), "GREEN", "GREEN", ""},
+		{s.Type("Color"), `"GREEN"`, nil, `Enum "Color" cannot represent non-enum value: "GREEN".`},
+		{s.Type("Point"), "{x: $one, y: $none}", map[string]interface{}{"x": 1, "y": 0}, ""},
+		{s.Type("Point"), "{y: 1}", nil, `Field "x" of required type "Int!" was not provided.`},
+		{s.Type("Point"), "{x: 1, z: 2}", nil, `Field "z" is not defined by type "Point".`},
+		{s.Type("Point"), "[]", nil, `Expected type "Point", found [].`},
+	} {
+		v, err := ValueFromAST(parseLiteral(t, test.literal), test.typ, variables)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s %s: expected error %q but got %v", test.typ, test.literal, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %s", test.typ, test.literal, err)
+		} else if !reflect.DeepEqual(v, test.expected) {
+			t.Errorf("%s %s: expected %# v but got %# v", test.typ, test.literal, pretty.Formatter(test.expected), pretty.Formatter(v))
+		}
+	}
+}
@@ -20,6 +20,8 @@ type Loc struct {
 type Document struct {
 	Loc
 	Definitions []Definition
+	// The source text of a parsed Document, for locating errors by line and column. It is not encoded as JSON.
+	Source string
 }
 
 func (*Document) Kind() string {
@@ -39,7 +41,9 @@ func (*Name) Kind() string {
 // Definition :
 //	- OperationDefinition
 //	- FragmentDefinition
+//	- SchemaDefinition
 //	- TypeDefinition
+//	- DirectiveDefinition
 type Definition interface {
 	Node
 	definition()
@@ -49,6 +53,8 @@ func (*OpDef) definition() {}
 
 func (*FragmentDef) definition() {}
 
+func (*SchemaDef) definition() {}
+
 func (*ObjTypeDef) definition()       {}
 func (*InterfaceTypeDef) definition() {}
 func (*UnionTypeDef) definition()     {}
@@ -57,6 +63,8 @@ func (*EnumTypeDef) definition()      {}
 func (*InputObjTypeDef) definition()  {}
 func (*TypeExtDef) definition()       {}
 
+func (*DirectiveDef) definition() {}
+
 // OperationType
 type OpType int
 
@@ -98,13 +106,14 @@ func (*OpDef) Kind() string {
 	return "OperationDefinition"
 }
 
-// VariableDefinition : Variable : Type DefaultValue?
+// VariableDefinition : Variable : Type DefaultValue? Directives?
 // DefaultValue : =Value
 type VarDef struct {
 	Loc
 	Variable
 	RefType
 	DefaultValue Value
+	Directives   []Directive
 }
 
 func (*VarDef) Kind() string {
@@ -368,6 +377,28 @@ func (*NonNullType) Kind() string {
 	return "NonNullType"
 }
 
+// SchemaDefinition : schema Directives? { OperationTypeDefinition+ }
+type SchemaDef struct {
+	Loc
+	Directives []Directive
+	OpTypeDefs []OpTypeDef
+}
+
+func (*SchemaDef) Kind() string {
+	return "SchemaDefinition"
+}
+
+// OperationTypeDefinition : OperationType : NamedType
+type OpTypeDef struct {
+	Loc
+	OpType
+	NamedType
+}
+
+func (*OpTypeDef) Kind() string {
+	return "OperationTypeDefinition"
+}
+
 // Type Definition.
 type TypeDef interface {
 	Definition
@@ -385,11 +416,15 @@ func (*EnumValueDef) typeDefinition()     {}
 func (*InputObjTypeDef) typeDefinition()  {}
 func (*TypeExtDef) typeDefinition()       {}
 
-// ObjectTypeDefinition : type Name ImplementsInterfaces? { FieldDef+ }
+// ObjectTypeDefinition : Description? type Name ImplementsInterfaces? Directives? { FieldDef+ }
+//
+// Description : StringValue
 type ObjTypeDef struct {
 	Loc
+	Description *String
 	Name
 	Interfaces []NamedType
+	Directives []Directive
 	FieldDefs  []FieldDef
 }
 
@@ -397,45 +432,53 @@ func (*ObjTypeDef) Kind() string {
 	return "ObjectTypeDefinition"
 }
 
-// FieldDefinition : Name ArgumentsDef? : Type
+// FieldDefinition : Description? Name ArgumentsDef? : Type Directives?
 type FieldDef struct {
 	Loc
+	Description *String
 	Name
 	Arguments []InputValueDef
 	RefType
+	Directives []Directive
 }
 
 func (*FieldDef) Kind() string {
 	return "FieldDefinition"
 }
 
-// InputValueDefinition : Name : Type DefaultValue?
+// InputValueDefinition : Description? Name : Type DefaultValue? Directives?
 type InputValueDef struct {
 	Loc
+	Description *String
 	Name
 	RefType
 	DefaultValue Value
+	Directives   []Directive
 }
 
 func (*InputValueDef) Kind() string {
 	return "InputValueDefinition"
 }
 
-// InterfaceTypeDefinition : interface Name { FieldDef+ }
+// InterfaceTypeDefinition : Description? interface Name Directives? { FieldDef+ }
 type InterfaceTypeDef struct {
 	Loc
+	Description *String
 	Name
-	FieldDefs []FieldDef
+	Directives []Directive
+	FieldDefs  []FieldDef
 }
 
 func (*InterfaceTypeDef) Kind() string {
 	return "InterfaceTypeDefinition"
 }
 
-// UnionTypeDefinition : union Name = UnionMembers
+// UnionTypeDefinition : Description? union Name Directives? = UnionMembers
 type UnionTypeDef struct {
 	Loc
+	Description *String
 	Name
+	Directives []Directive
 	NamedTypes []NamedType
 }
 
@@ -443,20 +486,24 @@ func (*UnionTypeDef) Kind() string {
 	return "UnionTypeDefinition"
 }
 
-// ScalarTypeDefinition : scalar Name
+// ScalarTypeDefinition : Description? scalar Name Directives?
 type ScalarTypeDef struct {
 	Loc
+	Description *String
 	Name
+	Directives []Directive
 }
 
 func (*ScalarTypeDef) Kind() string {
 	return "ScalarTypeDefinition"
 }
 
-// EnumTypeDefinition : enum Name { EnumValueDef+ }
+// EnumTypeDefinition : Description? enum Name Directives? { EnumValueDef+ }
 type EnumTypeDef struct {
 	Loc
+	Description *String
 	Name
+	Directives    []Directive
 	EnumValueDefs []EnumValueDef
 }
 
@@ -464,20 +511,27 @@ func (*EnumTypeDef) Kind() string {
 	return "EnumTypeDefinition"
 }
 
-// EnumValueDefinition : EnumValue
+// EnumValueDefinition : Description? EnumValue Directives?
 //
 // EnumValue : Name
-type EnumValueDef Name
+type EnumValueDef struct {
+	Loc
+	Description *String
+	Name
+	Directives []Directive
+}
 
 func (*EnumValueDef) Kind() string {
 	return "EnumValueDefinition"
 }
 
-// InputObjectTypeDefinition : input Name { InputValueDefinition+ }
+// InputObjectTypeDefinition : Description? input Name Directives? { InputValueDefinition+ }
 type InputObjTypeDef struct {
 	Loc
+	Description *String
 	Name
-	Fields []InputValueDef
+	Directives []Directive
+	Fields     []InputValueDef
 }
 
 func (*InputObjTypeDef) Kind() string {
@@ -490,3 +544,19 @@ type TypeExtDef ObjTypeDef
 func (*TypeExtDef) Kind() string {
 	return "TypeExtensionDefinition"
 }
+
+// DirectiveDefinition : Description? directive @ Name ArgumentsDef? repeatable? on DirectiveLocations
+//
+// DirectiveLocations : |? Name ( | Name )*
+type DirectiveDef struct {
+	Loc
+	Description *String
+	Name
+	Arguments  []InputValueDef
+	Repeatable bool
+	Locations  []Name
+}
+
+func (*DirectiveDef) Kind() string {
+	return "DirectiveDefinition"
+}
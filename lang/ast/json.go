@@ -0,0 +1,1732 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"unicode/utf16"
+)
+
+// JSON encoding of Nodes matches the AST produced by the javascript reference implementation:
+// https://github.com/graphql/graphql-js/blob/master/src/language/ast.js
+//
+// Every node is encoded as an object with a "kind" and a "loc". Optional nodes are encoded as null, and absent lists
+// as empty arrays.
+//
+// A Loc counts runes, and nodes ending with a name or literal value, e.g. Names and Arguments, end at the offset of
+// its last rune. Within a Document, a "loc" is converted to count UTF-16 code units of the Source, and to end just past
+// the node, as in graphql-js. Decoding a Document converts them back, so its Source must be set beforehand for offsets
+// following characters outside the Basic Multilingual Plane, e.g. emoji, to be correct. A Node encoded or decoded on
+// its own holds its Loc as is. The loc of a Document spans from its first token to the end of its source, rather than
+// from the start of its source to the end of its last token.
+
+// JSON representation of a Loc.
+type jsonLoc struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func newJSONLoc(l Loc) jsonLoc {
+	return jsonLoc{Start: l.Start, End: l.End}
+}
+
+// A utf16Offsets holds the offset in UTF-16 code units of each rune of a source, and of its end.
+type utf16Offsets []int
+
+func newUTF16Offsets(source string) utf16Offsets {
+	offsets := utf16Offsets{0}
+	n := 0
+	for _, r := range source {
+		n += len(utf16.Encode([]rune{r}))
+		offsets = append(offsets, n)
+	}
+	return offsets
+}
+
+// The utf16 method returns the UTF-16 offset of the rune offset i. Offsets past the end are not converted.
+func (o utf16Offsets) utf16(i int) int {
+	if i < 0 || i >= len(o) {
+		return i
+	}
+	return o[i]
+}
+
+// The rune method returns the rune offset of the UTF-16 offset i. Offsets past the end are not converted.
+func (o utf16Offsets) rune(i int) int {
+	if i < 0 || i > o[len(o)-1] {
+		return i
+	}
+	return sort.SearchInts(o, i)
+}
+
+var (
+	locType      = reflect.TypeOf(Loc{})
+	documentType = reflect.TypeOf(Document{})
+)
+
+// The tokenTypes are the nodes consisting of a single name or literal token, whose Locs end at their last rune.
+var tokenTypes = map[reflect.Type]bool{
+	reflect.TypeOf(Name{}):      true,
+	reflect.TypeOf(NamedType{}): true,
+	reflect.TypeOf(Int{}):       true,
+	reflect.TypeOf(Float{}):     true,
+	reflect.TypeOf(String{}):    true,
+	reflect.TypeOf(Boolean{}):   true,
+	reflect.TypeOf(Enum{}):      true,
+}
+
+// The convertLocs function calls convert with the Loc of each node within v, an addressable value, after those of
+// its children, and whether the node ends with a name or literal token. Ends are compared before conversion, and
+// absent names are skipped. It returns the end of the last token within v, or -1 if there is none.
+func convertLocs(v reflect.Value, convert func(l *Loc, endsWithToken bool)) int {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return -1
+		}
+		return convertLocs(v.Elem(), convert)
+	case reflect.Slice:
+		last := -1
+		for i := 0; i < v.Len(); i++ {
+			if end := convertLocs(v.Index(i), convert); end > last {
+				last = end
+			}
+		}
+		return last
+	case reflect.Struct:
+		if v.Type() == locType {
+			return -1
+		}
+		token := tokenTypes[v.Type()]
+		if token && v.FieldByName("Value").Kind() == reflect.String && v.FieldByName("Value").Len() == 0 &&
+			v.Type() != reflect.TypeOf(String{}) {
+			// An absent name.
+			return -1
+		}
+		last := -1
+		for i := 0; i < v.NumField(); i++ {
+			if end := convertLocs(v.Field(i), convert); end > last {
+				last = end
+			}
+		}
+		loc := v.FieldByName("Loc")
+		if !loc.IsValid() || loc.Type() != locType {
+			return last
+		}
+		l := loc.Addr().Interface().(*Loc)
+		// A Document ends at the end of its source.
+		endsWithToken := token || last >= 0 && l.End == last && v.Type() != documentType
+		if token {
+			last = l.End
+		}
+		convert(l, endsWithToken)
+		return last
+	}
+	return -1
+}
+
+// The copyNodes function sets dst to a deep copy of src.
+func copyNodes(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if !src.IsNil() {
+			p := reflect.New(src.Type().Elem())
+			copyNodes(p.Elem(), src.Elem())
+			dst.Set(p)
+		}
+	case reflect.Interface:
+		if !src.IsNil() {
+			e := reflect.New(src.Elem().Type()).Elem()
+			copyNodes(e, src.Elem())
+			dst.Set(e)
+		}
+	case reflect.Slice:
+		if !src.IsNil() {
+			s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				copyNodes(s.Index(i), src.Index(i))
+			}
+			dst.Set(s)
+		}
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			copyNodes(dst.Field(i), src.Field(i))
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// The jsonList function returns an empty slice if the slice s is nil, so that it encodes as an empty array rather
+// than null.
+func jsonList(s interface{}) interface{} {
+	if reflect.ValueOf(s).IsNil() {
+		return []struct{}{}
+	}
+	return s
+}
+
+// The jsonName function returns n, or nil if n has no value, so that it encodes as null.
+func jsonName(n *Name) *Name {
+	if n.Value == "" {
+		return nil
+	}
+	return n
+}
+
+func (d Document) MarshalJSON() ([]byte, error) {
+	var c Document
+	copyNodes(reflect.ValueOf(&c).Elem(), reflect.ValueOf(d))
+	offsets := newUTF16Offsets(d.Source)
+	convertLocs(reflect.ValueOf(&c).Elem(), func(l *Loc, endsWithToken bool) {
+		if endsWithToken {
+			l.End++
+		}
+		l.Start, l.End = offsets.utf16(l.Start), offsets.utf16(l.End)
+	})
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Definitions interface{} `json:"definitions"`
+	}{c.Kind(), newJSONLoc(c.Loc), jsonList(c.Definitions)})
+}
+
+func (n Name) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string  `json:"kind"`
+		Loc   jsonLoc `json:"loc"`
+		Value string  `json:"value"`
+	}{n.Kind(), newJSONLoc(n.Loc), n.Value})
+}
+
+func (o OpType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o OpDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind                string        `json:"kind"`
+		Loc                 jsonLoc       `json:"loc"`
+		Operation           OpType        `json:"operation"`
+		Name                *Name         `json:"name"`
+		VariableDefinitions interface{}   `json:"variableDefinitions"`
+		Directives          interface{}   `json:"directives"`
+		SelectionSet        *SelectionSet `json:"selectionSet"`
+	}{o.Kind(), newJSONLoc(o.Loc), o.OpType, jsonName(&o.Name), jsonList(o.VarDefs), jsonList(o.Directives), &o.SelectionSet})
+}
+
+func (v VarDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind         string      `json:"kind"`
+		Loc          jsonLoc     `json:"loc"`
+		Variable     *Variable   `json:"variable"`
+		Type         RefType     `json:"type"`
+		DefaultValue Value       `json:"defaultValue"`
+		Directives   interface{} `json:"directives"`
+	}{v.Kind(), newJSONLoc(v.Loc), &v.Variable, v.RefType, v.DefaultValue, jsonList(v.Directives)})
+}
+
+func (v Variable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string  `json:"kind"`
+		Loc  jsonLoc `json:"loc"`
+		Name *Name   `json:"name"`
+	}{v.Kind(), newJSONLoc(v.Loc), &v.Name})
+}
+
+func (s SelectionSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Loc        jsonLoc     `json:"loc"`
+		Selections interface{} `json:"selections"`
+	}{s.Kind(), newJSONLoc(s.Loc), jsonList(s.Selections)})
+}
+
+func (f Field) MarshalJSON() ([]byte, error) {
+	var ss *SelectionSet
+	if len(f.Selections) > 0 {
+		ss = &f.SelectionSet
+	}
+	return json.Marshal(struct {
+		Kind         string        `json:"kind"`
+		Loc          jsonLoc       `json:"loc"`
+		Alias        *Name         `json:"alias"`
+		Name         *Name         `json:"name"`
+		Arguments    interface{}   `json:"arguments"`
+		Directives   interface{}   `json:"directives"`
+		SelectionSet *SelectionSet `json:"selectionSet"`
+	}{f.Kind(), newJSONLoc(f.Loc), jsonName(&f.Alias), &f.Name, jsonList(f.Arguments), jsonList(f.Directives), ss})
+}
+
+func (a Argument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string  `json:"kind"`
+		Loc   jsonLoc `json:"loc"`
+		Name  *Name   `json:"name"`
+		Value Value   `json:"value"`
+	}{a.Kind(), newJSONLoc(a.Loc), &a.Name, a.Value})
+}
+
+func (f FragmentSpread) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Loc        jsonLoc     `json:"loc"`
+		Name       *Name       `json:"name"`
+		Directives interface{} `json:"directives"`
+	}{f.Kind(), newJSONLoc(f.Loc), &f.Name, jsonList(f.Directives)})
+}
+
+func (i InlineFragment) MarshalJSON() ([]byte, error) {
+	var tc *NamedType
+	if i.NamedType.Value != "" {
+		tc = &i.NamedType
+	}
+	return json.Marshal(struct {
+		Kind          string        `json:"kind"`
+		Loc           jsonLoc       `json:"loc"`
+		TypeCondition *NamedType    `json:"typeCondition"`
+		Directives    interface{}   `json:"directives"`
+		SelectionSet  *SelectionSet `json:"selectionSet"`
+	}{i.Kind(), newJSONLoc(i.Loc), tc, jsonList(i.Directives), &i.SelectionSet})
+}
+
+func (f FragmentDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind          string        `json:"kind"`
+		Loc           jsonLoc       `json:"loc"`
+		Name          *Name         `json:"name"`
+		TypeCondition *NamedType    `json:"typeCondition"`
+		Directives    interface{}   `json:"directives"`
+		SelectionSet  *SelectionSet `json:"selectionSet"`
+	}{f.Kind(), newJSONLoc(f.Loc), &f.Name, &f.TypeCondition, jsonList(f.Directives), &f.SelectionSet})
+}
+
+func (i Int) MarshalJSON() ([]byte, error) {
+	return marshalScalarValue(i.Kind(), i.Loc, i.Value)
+}
+
+func (f Float) MarshalJSON() ([]byte, error) {
+	return marshalScalarValue(f.Kind(), f.Loc, f.Value)
+}
+
+func (s String) MarshalJSON() ([]byte, error) {
+	return marshalScalarValue(s.Kind(), s.Loc, s.Value)
+}
+
+func (b Boolean) MarshalJSON() ([]byte, error) {
+	return marshalScalarValue(b.Kind(), b.Loc, b.Value)
+}
+
+func (e Enum) MarshalJSON() ([]byte, error) {
+	return marshalScalarValue(e.Kind(), e.Loc, e.Value)
+}
+
+func marshalScalarValue(kind string, loc Loc, value interface{}) ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string      `json:"kind"`
+		Loc   jsonLoc     `json:"loc"`
+		Value interface{} `json:"value"`
+	}{kind, newJSONLoc(loc), value})
+}
+
+func (l List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind   string      `json:"kind"`
+		Loc    jsonLoc     `json:"loc"`
+		Values interface{} `json:"values"`
+	}{l.Kind(), newJSONLoc(l.Loc), jsonList(l.Values)})
+}
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind   string      `json:"kind"`
+		Loc    jsonLoc     `json:"loc"`
+		Fields interface{} `json:"fields"`
+	}{o.Kind(), newJSONLoc(o.Loc), jsonList(o.Fields)})
+}
+
+func (o ObjectField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string  `json:"kind"`
+		Loc   jsonLoc `json:"loc"`
+		Name  *Name   `json:"name"`
+		Value Value   `json:"value"`
+	}{o.Kind(), newJSONLoc(o.Loc), &o.Name, o.Value})
+}
+
+func (d Directive) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind      string      `json:"kind"`
+		Loc       jsonLoc     `json:"loc"`
+		Name      *Name       `json:"name"`
+		Arguments interface{} `json:"arguments"`
+	}{d.Kind(), newJSONLoc(d.Loc), &d.Name, jsonList(d.Arguments)})
+}
+
+func (n NamedType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string  `json:"kind"`
+		Loc  jsonLoc `json:"loc"`
+		Name Name    `json:"name"`
+	}{n.Kind(), newJSONLoc(n.Loc), Name(n)})
+}
+
+func (l ListType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string  `json:"kind"`
+		Loc  jsonLoc `json:"loc"`
+		Type RefType `json:"type"`
+	}{l.Kind(), newJSONLoc(l.Loc), l.RefType})
+}
+
+func (n NonNullType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string  `json:"kind"`
+		Loc  jsonLoc `json:"loc"`
+		Type RefType `json:"type"`
+	}{n.Kind(), newJSONLoc(n.Loc), n.RefType})
+}
+
+func (s SchemaDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind           string      `json:"kind"`
+		Loc            jsonLoc     `json:"loc"`
+		Directives     interface{} `json:"directives"`
+		OperationTypes interface{} `json:"operationTypes"`
+	}{s.Kind(), newJSONLoc(s.Loc), jsonList(s.Directives), jsonList(s.OpTypeDefs)})
+}
+
+func (o OpTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind      string     `json:"kind"`
+		Loc       jsonLoc    `json:"loc"`
+		Operation OpType     `json:"operation"`
+		Type      *NamedType `json:"type"`
+	}{o.Kind(), newJSONLoc(o.Loc), o.OpType, &o.NamedType})
+}
+
+func (o ObjTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Interfaces  interface{} `json:"interfaces"`
+		Directives  interface{} `json:"directives"`
+		Fields      interface{} `json:"fields"`
+	}{o.Kind(), newJSONLoc(o.Loc), o.Description, &o.Name, jsonList(o.Interfaces), jsonList(o.Directives),
+		jsonList(o.FieldDefs)})
+}
+
+func (f FieldDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Arguments   interface{} `json:"arguments"`
+		Type        RefType     `json:"type"`
+		Directives  interface{} `json:"directives"`
+	}{f.Kind(), newJSONLoc(f.Loc), f.Description, &f.Name, jsonList(f.Arguments), f.RefType, jsonList(f.Directives)})
+}
+
+func (i InputValueDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind         string      `json:"kind"`
+		Loc          jsonLoc     `json:"loc"`
+		Description  *String     `json:"description"`
+		Name         *Name       `json:"name"`
+		Type         RefType     `json:"type"`
+		DefaultValue Value       `json:"defaultValue"`
+		Directives   interface{} `json:"directives"`
+	}{i.Kind(), newJSONLoc(i.Loc), i.Description, &i.Name, i.RefType, i.DefaultValue, jsonList(i.Directives)})
+}
+
+func (i InterfaceTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+		Fields      interface{} `json:"fields"`
+	}{i.Kind(), newJSONLoc(i.Loc), i.Description, &i.Name, jsonList(i.Directives), jsonList(i.FieldDefs)})
+}
+
+func (u UnionTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+		Types       interface{} `json:"types"`
+	}{u.Kind(), newJSONLoc(u.Loc), u.Description, &u.Name, jsonList(u.Directives), jsonList(u.NamedTypes)})
+}
+
+func (s ScalarTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+	}{s.Kind(), newJSONLoc(s.Loc), s.Description, &s.Name, jsonList(s.Directives)})
+}
+
+func (e EnumTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+		Values      interface{} `json:"values"`
+	}{e.Kind(), newJSONLoc(e.Loc), e.Description, &e.Name, jsonList(e.Directives), jsonList(e.EnumValueDefs)})
+}
+
+func (e EnumValueDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+	}{e.Kind(), newJSONLoc(e.Loc), e.Description, &e.Name, jsonList(e.Directives)})
+}
+
+func (i InputObjTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Directives  interface{} `json:"directives"`
+		Fields      interface{} `json:"fields"`
+	}{i.Kind(), newJSONLoc(i.Loc), i.Description, &i.Name, jsonList(i.Directives), jsonList(i.Fields)})
+}
+
+func (t TypeExtDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Loc        jsonLoc     `json:"loc"`
+		Definition *ObjTypeDef `json:"definition"`
+	}{t.Kind(), newJSONLoc(t.Loc), (*ObjTypeDef)(&t)})
+}
+
+func (d DirectiveDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Loc         jsonLoc     `json:"loc"`
+		Description *String     `json:"description"`
+		Name        *Name       `json:"name"`
+		Arguments   interface{} `json:"arguments"`
+		Repeatable  bool        `json:"repeatable"`
+		Locations   interface{} `json:"locations"`
+	}{d.Kind(), newJSONLoc(d.Loc), d.Description, &d.Name, jsonList(d.Arguments), d.Repeatable, jsonList(d.Locations)})
+}
+
+// A jsonNode holds any decoded JSON node, prior to conversion to the Node of its kind.
+type jsonNode struct {
+	Kind string   `json:"kind"`
+	Loc  *jsonLoc `json:"loc"`
+
+	// A string or boolean for scalar values, otherwise a Value node.
+	Value json.RawMessage `json:"value"`
+
+	Alias               *jsonNode   `json:"alias"`
+	Arguments           []*jsonNode `json:"arguments"`
+	DefaultValue        *jsonNode   `json:"defaultValue"`
+	Description         *jsonNode   `json:"description"`
+	Definition          *jsonNode   `json:"definition"`
+	Definitions         []*jsonNode `json:"definitions"`
+	Directives          []*jsonNode `json:"directives"`
+	Fields              []*jsonNode `json:"fields"`
+	Interfaces          []*jsonNode `json:"interfaces"`
+	Locations           []*jsonNode `json:"locations"`
+	Name                *jsonNode   `json:"name"`
+	Operation           string      `json:"operation"`
+	OperationTypes      []*jsonNode `json:"operationTypes"`
+	Repeatable          bool        `json:"repeatable"`
+	SelectionSet        *jsonNode   `json:"selectionSet"`
+	Selections          []*jsonNode `json:"selections"`
+	Type                *jsonNode   `json:"type"`
+	TypeCondition       *jsonNode   `json:"typeCondition"`
+	Types               []*jsonNode `json:"types"`
+	Values              []*jsonNode `json:"values"`
+	Variable            *jsonNode   `json:"variable"`
+	VariableDefinitions []*jsonNode `json:"variableDefinitions"`
+}
+
+// The unmarshalJSONNode function decodes b and asserts that it is a node of kind.
+func unmarshalJSONNode(b []byte, kind string) (*jsonNode, error) {
+	var n jsonNode
+	if err := json.Unmarshal(b, &n); err != nil {
+		return nil, err
+	}
+	if err := n.expect(kind); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// The expect method returns an error if n is nil or not of kind.
+func (n *jsonNode) expect(kind string) error {
+	if n == nil {
+		return fmt.Errorf("expected %s node but got null", kind)
+	}
+	if n.Kind != kind {
+		return fmt.Errorf("expected %s node but got %q", kind, n.Kind)
+	}
+	return nil
+}
+
+func (n *jsonNode) loc() Loc {
+	if n.Loc == nil {
+		return Loc{}
+	}
+	return Loc{n.Loc.Start, n.Loc.End}
+}
+
+// The name method returns the Name n, or an empty Name if n is nil.
+func (n *jsonNode) name() (Name, error) {
+	if n == nil {
+		return Name{}, nil
+	}
+	if err := n.expect("Name"); err != nil {
+		return Name{}, err
+	}
+	var v string
+	if err := json.Unmarshal(n.Value, &v); err != nil {
+		return Name{}, err
+	}
+	return Name{n.loc(), v}, nil
+}
+
+func (n *jsonNode) document() (*Document, error) {
+	d := &Document{Loc: n.loc()}
+	for _, def := range n.Definitions {
+		dd, err := def.definition()
+		if err != nil {
+			return nil, err
+		}
+		d.Definitions = append(d.Definitions, dd)
+	}
+	return d, nil
+}
+
+func (n *jsonNode) definition() (Definition, error) {
+	if n == nil {
+		return nil, fmt.Errorf("expected definition node but got null")
+	}
+	switch n.Kind {
+	case "OperationDefinition":
+		return n.opDef()
+	case "FragmentDefinition":
+		return n.fragmentDef()
+	case "SchemaDefinition":
+		return n.schemaDef()
+	case "ObjectTypeDefinition":
+		return n.objTypeDef()
+	case "InterfaceTypeDefinition":
+		return n.interfaceTypeDef()
+	case "UnionTypeDefinition":
+		return n.unionTypeDef()
+	case "ScalarTypeDefinition":
+		return n.scalarTypeDef()
+	case "EnumTypeDefinition":
+		return n.enumTypeDef()
+	case "InputObjectTypeDefinition":
+		return n.inputObjTypeDef()
+	case "TypeExtensionDefinition":
+		return n.typeExtDef()
+	case "DirectiveDefinition":
+		return n.directiveDef()
+	default:
+		return nil, fmt.Errorf("unrecognized definition kind %q", n.Kind)
+	}
+}
+
+// The opType method returns the OpType of n's operation.
+func (n *jsonNode) opType() (OpType, error) {
+	switch n.Operation {
+	case "query":
+		return Query, nil
+	case "mutation":
+		return Mutation, nil
+	case "subscription":
+		return Subscription, nil
+	default:
+		return -1, fmt.Errorf("unrecognized operation %q", n.Operation)
+	}
+}
+
+func (n *jsonNode) opDef() (*OpDef, error) {
+	o := &OpDef{Loc: n.loc()}
+	var err error
+	if o.OpType, err = n.opType(); err != nil {
+		return nil, err
+	}
+	if o.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	for _, vd := range n.VariableDefinitions {
+		v, err := vd.varDef()
+		if err != nil {
+			return nil, err
+		}
+		o.VarDefs = append(o.VarDefs, *v)
+	}
+	if o.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if err := n.SelectionSet.expect("SelectionSet"); err != nil {
+		return nil, err
+	}
+	if o.SelectionSet, err = n.SelectionSet.selectionSet(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (n *jsonNode) varDef() (*VarDef, error) {
+	if err := n.expect("VariableDefinition"); err != nil {
+		return nil, err
+	}
+	v := &VarDef{Loc: n.loc()}
+	variable, err := n.Variable.variable()
+	if err != nil {
+		return nil, err
+	}
+	v.Variable = *variable
+	if v.RefType, err = n.Type.refType(); err != nil {
+		return nil, err
+	}
+	if n.DefaultValue != nil {
+		if v.DefaultValue, err = n.DefaultValue.value(); err != nil {
+			return nil, err
+		}
+	}
+	if v.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (n *jsonNode) variable() (*Variable, error) {
+	if err := n.expect("Variable"); err != nil {
+		return nil, err
+	}
+	name, err := n.Name.name()
+	if err != nil {
+		return nil, err
+	}
+	return &Variable{n.loc(), name}, nil
+}
+
+// The selectionSet method returns the SelectionSet n, or an empty SelectionSet if n is nil.
+func (n *jsonNode) selectionSet() (SelectionSet, error) {
+	if n == nil {
+		return SelectionSet{}, nil
+	}
+	if err := n.expect("SelectionSet"); err != nil {
+		return SelectionSet{}, err
+	}
+	ss := SelectionSet{Loc: n.loc()}
+	for _, s := range n.Selections {
+		sel, err := s.selection()
+		if err != nil {
+			return SelectionSet{}, err
+		}
+		ss.Selections = append(ss.Selections, sel)
+	}
+	return ss, nil
+}
+
+func (n *jsonNode) selection() (Selection, error) {
+	if n == nil {
+		return nil, fmt.Errorf("expected selection node but got null")
+	}
+	switch n.Kind {
+	case "Field":
+		return n.field()
+	case "FragmentSpread":
+		return n.fragmentSpread()
+	case "InlineFragment":
+		return n.inlineFragment()
+	default:
+		return nil, fmt.Errorf("unrecognized selection kind %q", n.Kind)
+	}
+}
+
+func (n *jsonNode) field() (*Field, error) {
+	f := &Field{Loc: n.loc()}
+	var err error
+	if f.Alias, err = n.Alias.name(); err != nil {
+		return nil, err
+	}
+	if f.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if f.Arguments, err = n.arguments(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if f.SelectionSet, err = n.SelectionSet.selectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (n *jsonNode) arguments() ([]Argument, error) {
+	var as []Argument
+	for _, a := range n.Arguments {
+		arg, err := a.argument()
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, *arg)
+	}
+	return as, nil
+}
+
+func (n *jsonNode) argument() (*Argument, error) {
+	if err := n.expect("Argument"); err != nil {
+		return nil, err
+	}
+	name, err := n.Name.name()
+	if err != nil {
+		return nil, err
+	}
+	v, err := n.nodeValue()
+	if err != nil {
+		return nil, err
+	}
+	return &Argument{n.loc(), name, v}, nil
+}
+
+func (n *jsonNode) fragmentSpread() (*FragmentSpread, error) {
+	f := &FragmentSpread{Loc: n.loc()}
+	var err error
+	if f.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (n *jsonNode) inlineFragment() (*InlineFragment, error) {
+	i := &InlineFragment{Loc: n.loc()}
+	var err error
+	if n.TypeCondition != nil {
+		nt, err := n.TypeCondition.namedType()
+		if err != nil {
+			return nil, err
+		}
+		i.NamedType = *nt
+	}
+	if i.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if err := n.SelectionSet.expect("SelectionSet"); err != nil {
+		return nil, err
+	}
+	if i.SelectionSet, err = n.SelectionSet.selectionSet(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (n *jsonNode) fragmentDef() (*FragmentDef, error) {
+	f := &FragmentDef{Loc: n.loc()}
+	var err error
+	if f.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	nt, err := n.TypeCondition.namedType()
+	if err != nil {
+		return nil, err
+	}
+	f.TypeCondition = *nt
+	if f.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if err := n.SelectionSet.expect("SelectionSet"); err != nil {
+		return nil, err
+	}
+	if f.SelectionSet, err = n.SelectionSet.selectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// The nodeValue method returns the Value node held by the "value" member of n.
+func (n *jsonNode) nodeValue() (Value, error) {
+	var v *jsonNode
+	if err := json.Unmarshal(n.Value, &v); err != nil {
+		return nil, err
+	}
+	return v.value()
+}
+
+func (n *jsonNode) value() (Value, error) {
+	if n == nil {
+		return nil, fmt.Errorf("expected value node but got null")
+	}
+	switch n.Kind {
+	case "Variable":
+		return n.variable()
+	case "IntValue":
+		i := &Int{Loc: n.loc()}
+		return i, json.Unmarshal(n.Value, &i.Value)
+	case "FloatValue":
+		f := &Float{Loc: n.loc()}
+		return f, json.Unmarshal(n.Value, &f.Value)
+	case "StringValue":
+		s := &String{Loc: n.loc()}
+		return s, json.Unmarshal(n.Value, &s.Value)
+	case "BooleanValue":
+		b := &Boolean{Loc: n.loc()}
+		return b, json.Unmarshal(n.Value, &b.Value)
+	case "EnumValue":
+		e := &Enum{Loc: n.loc()}
+		return e, json.Unmarshal(n.Value, &e.Value)
+	case "ListValue":
+		l := &List{Loc: n.loc()}
+		for _, v := range n.Values {
+			lv, err := v.value()
+			if err != nil {
+				return nil, err
+			}
+			l.Values = append(l.Values, lv)
+		}
+		return l, nil
+	case "ObjectValue":
+		o := &Object{Loc: n.loc()}
+		for _, f := range n.Fields {
+			of, err := f.objectField()
+			if err != nil {
+				return nil, err
+			}
+			o.Fields = append(o.Fields, *of)
+		}
+		return o, nil
+	default:
+		return nil, fmt.Errorf("unrecognized value kind %q", n.Kind)
+	}
+}
+
+func (n *jsonNode) objectField() (*ObjectField, error) {
+	if err := n.expect("ObjectField"); err != nil {
+		return nil, err
+	}
+	name, err := n.Name.name()
+	if err != nil {
+		return nil, err
+	}
+	v, err := n.nodeValue()
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectField{n.loc(), name, v}, nil
+}
+
+func (n *jsonNode) directives() ([]Directive, error) {
+	var ds []Directive
+	for _, d := range n.Directives {
+		dir, err := d.directive()
+		if err != nil {
+			return nil, err
+		}
+		ds = append(ds, *dir)
+	}
+	return ds, nil
+}
+
+func (n *jsonNode) directive() (*Directive, error) {
+	if err := n.expect("Directive"); err != nil {
+		return nil, err
+	}
+	name, err := n.Name.name()
+	if err != nil {
+		return nil, err
+	}
+	args, err := n.arguments()
+	if err != nil {
+		return nil, err
+	}
+	return &Directive{n.loc(), name, args}, nil
+}
+
+func (n *jsonNode) refType() (RefType, error) {
+	if n == nil {
+		return nil, fmt.Errorf("expected type node but got null")
+	}
+	switch n.Kind {
+	case "NamedType":
+		return n.namedType()
+	case "ListType":
+		t, err := n.Type.refType()
+		if err != nil {
+			return nil, err
+		}
+		return &ListType{n.loc(), t}, nil
+	case "NonNullType":
+		t, err := n.Type.refType()
+		if err != nil {
+			return nil, err
+		}
+		return &NonNullType{n.loc(), t}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized type kind %q", n.Kind)
+	}
+}
+
+func (n *jsonNode) namedType() (*NamedType, error) {
+	if err := n.expect("NamedType"); err != nil {
+		return nil, err
+	}
+	name, err := n.Name.name()
+	if err != nil {
+		return nil, err
+	}
+	return &NamedType{n.loc(), name.Value}, nil
+}
+
+func (n *jsonNode) namedTypes(nts []*jsonNode) ([]NamedType, error) {
+	var types []NamedType
+	for _, nt := range nts {
+		t, err := nt.namedType()
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, *t)
+	}
+	return types, nil
+}
+
+// The description method returns the StringValue n, or nil if n is nil.
+func (n *jsonNode) description() (*String, error) {
+	if n == nil {
+		return nil, nil
+	}
+	if err := n.expect("StringValue"); err != nil {
+		return nil, err
+	}
+	s := &String{Loc: n.loc()}
+	return s, json.Unmarshal(n.Value, &s.Value)
+}
+
+func (n *jsonNode) schemaDef() (*SchemaDef, error) {
+	s := &SchemaDef{Loc: n.loc()}
+	var err error
+	if s.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	for _, o := range n.OperationTypes {
+		def, err := o.opTypeDef()
+		if err != nil {
+			return nil, err
+		}
+		s.OpTypeDefs = append(s.OpTypeDefs, *def)
+	}
+	return s, nil
+}
+
+func (n *jsonNode) opTypeDef() (*OpTypeDef, error) {
+	if err := n.expect("OperationTypeDefinition"); err != nil {
+		return nil, err
+	}
+	o := &OpTypeDef{Loc: n.loc()}
+	var err error
+	if o.OpType, err = n.opType(); err != nil {
+		return nil, err
+	}
+	nt, err := n.Type.namedType()
+	if err != nil {
+		return nil, err
+	}
+	o.NamedType = *nt
+	return o, nil
+}
+
+func (n *jsonNode) objTypeDef() (*ObjTypeDef, error) {
+	if err := n.expect("ObjectTypeDefinition"); err != nil {
+		return nil, err
+	}
+	o := &ObjTypeDef{Loc: n.loc()}
+	var err error
+	if o.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if o.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if o.Interfaces, err = n.namedTypes(n.Interfaces); err != nil {
+		return nil, err
+	}
+	if o.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if o.FieldDefs, err = n.fieldDefs(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (n *jsonNode) fieldDefs() ([]FieldDef, error) {
+	var fds []FieldDef
+	for _, f := range n.Fields {
+		fd, err := f.fieldDef()
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, *fd)
+	}
+	return fds, nil
+}
+
+func (n *jsonNode) fieldDef() (*FieldDef, error) {
+	if err := n.expect("FieldDefinition"); err != nil {
+		return nil, err
+	}
+	f := &FieldDef{Loc: n.loc()}
+	var err error
+	if f.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if f.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if f.Arguments, err = n.inputValueDefs(n.Arguments); err != nil {
+		return nil, err
+	}
+	if f.RefType, err = n.Type.refType(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (n *jsonNode) inputValueDefs(ivds []*jsonNode) ([]InputValueDef, error) {
+	var defs []InputValueDef
+	for _, i := range ivds {
+		def, err := i.inputValueDef()
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, *def)
+	}
+	return defs, nil
+}
+
+func (n *jsonNode) inputValueDef() (*InputValueDef, error) {
+	if err := n.expect("InputValueDefinition"); err != nil {
+		return nil, err
+	}
+	i := &InputValueDef{Loc: n.loc()}
+	var err error
+	if i.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if i.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if i.RefType, err = n.Type.refType(); err != nil {
+		return nil, err
+	}
+	if n.DefaultValue != nil {
+		if i.DefaultValue, err = n.DefaultValue.value(); err != nil {
+			return nil, err
+		}
+	}
+	if i.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (n *jsonNode) interfaceTypeDef() (*InterfaceTypeDef, error) {
+	i := &InterfaceTypeDef{Loc: n.loc()}
+	var err error
+	if i.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if i.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if i.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if i.FieldDefs, err = n.fieldDefs(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (n *jsonNode) unionTypeDef() (*UnionTypeDef, error) {
+	u := &UnionTypeDef{Loc: n.loc()}
+	var err error
+	if u.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if u.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if u.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if u.NamedTypes, err = n.namedTypes(n.Types); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (n *jsonNode) scalarTypeDef() (*ScalarTypeDef, error) {
+	s := &ScalarTypeDef{Loc: n.loc()}
+	var err error
+	if s.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if s.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if s.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (n *jsonNode) enumTypeDef() (*EnumTypeDef, error) {
+	e := &EnumTypeDef{Loc: n.loc()}
+	var err error
+	if e.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if e.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if e.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	for _, v := range n.Values {
+		ev, err := v.enumValueDef()
+		if err != nil {
+			return nil, err
+		}
+		e.EnumValueDefs = append(e.EnumValueDefs, *ev)
+	}
+	return e, nil
+}
+
+func (n *jsonNode) enumValueDef() (*EnumValueDef, error) {
+	if err := n.expect("EnumValueDefinition"); err != nil {
+		return nil, err
+	}
+	e := &EnumValueDef{Loc: n.loc()}
+	var err error
+	if e.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if e.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if e.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (n *jsonNode) inputObjTypeDef() (*InputObjTypeDef, error) {
+	i := &InputObjTypeDef{Loc: n.loc()}
+	var err error
+	if i.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if i.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if i.Directives, err = n.directives(); err != nil {
+		return nil, err
+	}
+	if i.Fields, err = n.inputValueDefs(n.Fields); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (n *jsonNode) typeExtDef() (*TypeExtDef, error) {
+	o, err := n.Definition.objTypeDef()
+	if err != nil {
+		return nil, err
+	}
+	t := TypeExtDef(*o)
+	t.Loc = n.loc()
+	return &t, nil
+}
+
+func (n *jsonNode) directiveDef() (*DirectiveDef, error) {
+	d := &DirectiveDef{Loc: n.loc(), Repeatable: n.Repeatable}
+	var err error
+	if d.Description, err = n.Description.description(); err != nil {
+		return nil, err
+	}
+	if d.Name, err = n.Name.name(); err != nil {
+		return nil, err
+	}
+	if d.Arguments, err = n.inputValueDefs(n.Arguments); err != nil {
+		return nil, err
+	}
+	for _, l := range n.Locations {
+		if err := l.expect("Name"); err != nil {
+			return nil, err
+		}
+		name, err := l.name()
+		if err != nil {
+			return nil, err
+		}
+		d.Locations = append(d.Locations, name)
+	}
+	return d, nil
+}
+
+func (d *Document) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, d.Kind())
+	if err != nil {
+		return err
+	}
+	doc, err := n.document()
+	if err != nil {
+		return err
+	}
+	doc.Source = d.Source
+	offsets := newUTF16Offsets(d.Source)
+	convertLocs(reflect.ValueOf(doc).Elem(), func(l *Loc, endsWithToken bool) {
+		l.Start, l.End = offsets.rune(l.Start), offsets.rune(l.End)
+		if endsWithToken {
+			l.End--
+		}
+	})
+	*d = *doc
+	return nil
+}
+
+func (nm *Name) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, nm.Kind())
+	if err != nil {
+		return err
+	}
+	*nm, err = n.name()
+	return err
+}
+
+func (o *OpType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	for op, str := range opStrings {
+		if str == s {
+			*o = op
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized operation %q", s)
+}
+
+func (o *OpDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, o.Kind())
+	if err != nil {
+		return err
+	}
+	op, err := n.opDef()
+	if err != nil {
+		return err
+	}
+	*o = *op
+	return nil
+}
+
+func (v *VarDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, v.Kind())
+	if err != nil {
+		return err
+	}
+	vd, err := n.varDef()
+	if err != nil {
+		return err
+	}
+	*v = *vd
+	return nil
+}
+
+func (v *Variable) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, v.Kind())
+	if err != nil {
+		return err
+	}
+	variable, err := n.variable()
+	if err != nil {
+		return err
+	}
+	*v = *variable
+	return nil
+}
+
+func (s *SelectionSet) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, s.Kind())
+	if err != nil {
+		return err
+	}
+	*s, err = n.selectionSet()
+	return err
+}
+
+func (f *Field) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, f.Kind())
+	if err != nil {
+		return err
+	}
+	field, err := n.field()
+	if err != nil {
+		return err
+	}
+	*f = *field
+	return nil
+}
+
+func (a *Argument) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, a.Kind())
+	if err != nil {
+		return err
+	}
+	arg, err := n.argument()
+	if err != nil {
+		return err
+	}
+	*a = *arg
+	return nil
+}
+
+func (f *FragmentSpread) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, f.Kind())
+	if err != nil {
+		return err
+	}
+	fs, err := n.fragmentSpread()
+	if err != nil {
+		return err
+	}
+	*f = *fs
+	return nil
+}
+
+func (i *InlineFragment) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, i.Kind())
+	if err != nil {
+		return err
+	}
+	inline, err := n.inlineFragment()
+	if err != nil {
+		return err
+	}
+	*i = *inline
+	return nil
+}
+
+func (f *FragmentDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, f.Kind())
+	if err != nil {
+		return err
+	}
+	fd, err := n.fragmentDef()
+	if err != nil {
+		return err
+	}
+	*f = *fd
+	return nil
+}
+
+func (i *Int) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, i)
+}
+
+func (f *Float) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, f)
+}
+
+func (s *String) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, s)
+}
+
+func (bl *Boolean) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, bl)
+}
+
+func (e *Enum) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, e)
+}
+
+func (l *List) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, l)
+}
+
+func (o *Object) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONValueInto(b, o)
+}
+
+// The unmarshalJSONValueInto function decodes a Value node from b into the Value pointed to by v, which must be of the
+// same kind.
+func unmarshalJSONValueInto(b []byte, v Value) error {
+	n, err := unmarshalJSONNode(b, v.Kind())
+	if err != nil {
+		return err
+	}
+	value, err := n.value()
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(v).Elem().Set(reflect.ValueOf(value).Elem())
+	return nil
+}
+
+func (o *ObjectField) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, o.Kind())
+	if err != nil {
+		return err
+	}
+	of, err := n.objectField()
+	if err != nil {
+		return err
+	}
+	*o = *of
+	return nil
+}
+
+func (d *Directive) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, d.Kind())
+	if err != nil {
+		return err
+	}
+	dir, err := n.directive()
+	if err != nil {
+		return err
+	}
+	*d = *dir
+	return nil
+}
+
+func (nt *NamedType) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, nt.Kind())
+	if err != nil {
+		return err
+	}
+	t, err := n.namedType()
+	if err != nil {
+		return err
+	}
+	*nt = *t
+	return nil
+}
+
+func (l *ListType) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, l.Kind())
+	if err != nil {
+		return err
+	}
+	t, err := n.refType()
+	if err != nil {
+		return err
+	}
+	*l = *t.(*ListType)
+	return nil
+}
+
+func (nn *NonNullType) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, nn.Kind())
+	if err != nil {
+		return err
+	}
+	t, err := n.refType()
+	if err != nil {
+		return err
+	}
+	*nn = *t.(*NonNullType)
+	return nil
+}
+
+func (s *SchemaDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, s.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.schemaDef()
+	if err != nil {
+		return err
+	}
+	*s = *def
+	return nil
+}
+
+func (o *OpTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, o.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.opTypeDef()
+	if err != nil {
+		return err
+	}
+	*o = *def
+	return nil
+}
+
+func (o *ObjTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, o.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.objTypeDef()
+	if err != nil {
+		return err
+	}
+	*o = *def
+	return nil
+}
+
+func (f *FieldDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, f.Kind())
+	if err != nil {
+		return err
+	}
+	fd, err := n.fieldDef()
+	if err != nil {
+		return err
+	}
+	*f = *fd
+	return nil
+}
+
+func (i *InputValueDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, i.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.inputValueDef()
+	if err != nil {
+		return err
+	}
+	*i = *def
+	return nil
+}
+
+func (i *InterfaceTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, i.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.interfaceTypeDef()
+	if err != nil {
+		return err
+	}
+	*i = *def
+	return nil
+}
+
+func (u *UnionTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, u.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.unionTypeDef()
+	if err != nil {
+		return err
+	}
+	*u = *def
+	return nil
+}
+
+func (s *ScalarTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, s.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.scalarTypeDef()
+	if err != nil {
+		return err
+	}
+	*s = *def
+	return nil
+}
+
+func (e *EnumTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, e.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.enumTypeDef()
+	if err != nil {
+		return err
+	}
+	*e = *def
+	return nil
+}
+
+func (e *EnumValueDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, e.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.enumValueDef()
+	if err != nil {
+		return err
+	}
+	*e = *def
+	return nil
+}
+
+func (i *InputObjTypeDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, i.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.inputObjTypeDef()
+	if err != nil {
+		return err
+	}
+	*i = *def
+	return nil
+}
+
+func (t *TypeExtDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, t.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.typeExtDef()
+	if err != nil {
+		return err
+	}
+	*t = *def
+	return nil
+}
+
+func (d *DirectiveDef) UnmarshalJSON(b []byte) error {
+	n, err := unmarshalJSONNode(b, d.Kind())
+	if err != nil {
+		return err
+	}
+	def, err := n.directiveDef()
+	if err != nil {
+		return err
+	}
+	*d = *def
+	return nil
+}
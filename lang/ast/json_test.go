@@ -0,0 +1,223 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+
+	. "github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, input := range []string{
+		`{ user(id: 4) { name } }`,
+		`query q ($a:Int=1, $b:[String!]!) @dir(x:$a) {alias:f(l:[1,2.5,"s",true,ENUM], o:{a:{b:$b}}) ...frag ...on T @skip(if:true) {a} ... {b}}`,
+		`mutation m {a} subscription s {b} fragment frag on T @d {a}`,
+		`type T implements I J {a(x:Int=7):[String]!} interface I {a:Int} union U=A|B scalar S enum E {A,B} input In {a:Int, b:String="s"} extend type T {b:Int}`,
+		`schema @d {query: Q, mutation: M, subscription: S}`,
+		`"""T""" type T @a {"f" f("x" x:Int @b):Int @deprecated(reason:"r")} "e" enum E @c {"A" A @d, B} "s" scalar S @e "i" interface I @f {a:Int} "u" union U @g = T "in" input In @h {"a" a:Int=1 @i}`,
+		`query ($a:Int=1 @x, $b:Int @y(z:1) @w) {a}`,
+		`"d" directive @d("a" a:Int=1 @x) repeatable on FIELD | OBJECT directive @e on | QUERY`,
+	} {
+		d, err := parser.ParseString(input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		var actual Document
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatalf("input %q; unexpected error: %s\n%s", input, err, b)
+		}
+		// The source is not encoded.
+		actual.Source = input
+		if !reflect.DeepEqual(d, &actual) {
+			t.Errorf("input %q; diff:\n %v", input, pretty.Diff(d, &actual))
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	d, err := parser.ParseString(`{a:b(c:1)}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual, expected interface{}
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{
+		"kind": "Document",
+		"loc": {"start": 0, "end": 10},
+		"definitions": [{
+			"kind": "OperationDefinition",
+			"loc": {"start": 0, "end": 10},
+			"operation": "query",
+			"name": null,
+			"variableDefinitions": [],
+			"directives": [],
+			"selectionSet": {
+				"kind": "SelectionSet",
+				"loc": {"start": 0, "end": 10},
+				"selections": [{
+					"kind": "Field",
+					"loc": {"start": 1, "end": 9},
+					"alias": {"kind": "Name", "loc": {"start": 1, "end": 2}, "value": "a"},
+					"name": {"kind": "Name", "loc": {"start": 3, "end": 4}, "value": "b"},
+					"arguments": [{
+						"kind": "Argument",
+						"loc": {"start": 5, "end": 8},
+						"name": {"kind": "Name", "loc": {"start": 5, "end": 6}, "value": "c"},
+						"value": {"kind": "IntValue", "loc": {"start": 7, "end": 8}, "value": "1"}
+					}],
+					"directives": [],
+					"selectionSet": null
+				}]
+			}
+		}]
+	}`), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected:\n%s\nbut got:\n%s", pretty.Sprint(expected), b)
+	}
+}
+
+func TestMarshalJSONLoc(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		// The locs of the string value, and the name following it.
+		str, name Loc
+	}{
+		{`{a(s:"e")b}`, Loc{Start: 5, End: 8}, Loc{Start: 9, End: 10}},
+		// Runes of the Basic Multilingual Plane are single UTF-16 code units.
+		{`{a(s:"é")b}`, Loc{Start: 5, End: 8}, Loc{Start: 9, End: 10}},
+		// Other runes are two UTF-16 code units.
+		{`{a(s:"😀")b}`, Loc{Start: 5, End: 9}, Loc{Start: 10, End: 11}},
+	} {
+		d, err := parser.ParseString(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual struct {
+			Definitions []struct {
+				SelectionSet struct {
+					Selections []struct {
+						Loc       Loc
+						Name      struct{ Loc Loc }
+						Arguments []struct {
+							Value struct{ Loc Loc }
+						}
+					}
+				}
+			}
+		}
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatal(err)
+		}
+		fields := actual.Definitions[0].SelectionSet.Selections
+		if l := fields[0].Arguments[0].Value.Loc; l != test.str {
+			t.Errorf("input %q; expected string loc %v but got %v", test.input, test.str, l)
+		}
+		if l := fields[1].Name.Loc; l != test.name {
+			t.Errorf("input %q; expected name loc %v but got %v", test.input, test.name, l)
+		}
+	}
+}
+
+// The locs of graphql-js: exclusive ends, in UTF-16 code units.
+const graphqlJSFixture = `{
+	"kind": "Document",
+	"loc": {"start": 0, "end": 21},
+	"definitions": [{
+		"kind": "OperationDefinition",
+		"loc": {"start": 0, "end": 21},
+		"operation": "query",
+		"name": {"kind": "Name", "loc": {"start": 6, "end": 7}, "value": "Q"},
+		"variableDefinitions": [],
+		"directives": [],
+		"selectionSet": {
+			"kind": "SelectionSet",
+			"loc": {"start": 8, "end": 21},
+			"selections": [{
+				"kind": "Field",
+				"loc": {"start": 9, "end": 18},
+				"alias": null,
+				"name": {"kind": "Name", "loc": {"start": 9, "end": 10}, "value": "a"},
+				"arguments": [{
+					"kind": "Argument",
+					"loc": {"start": 11, "end": 17},
+					"name": {"kind": "Name", "loc": {"start": 11, "end": 12}, "value": "s"},
+					"value": {"kind": "StringValue", "loc": {"start": 13, "end": 17}, "value": "😀"}
+				}],
+				"directives": [],
+				"selectionSet": null
+			}, {
+				"kind": "Field",
+				"loc": {"start": 19, "end": 20},
+				"alias": null,
+				"name": {"kind": "Name", "loc": {"start": 19, "end": 20}, "value": "b"},
+				"arguments": [],
+				"directives": [],
+				"selectionSet": null
+			}]
+		}
+	}]
+}`
+
+func TestJSONFixture(t *testing.T) {
+	const input = `query Q {a(s:"😀") b}`
+	expected, err := parser.ParseString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actualJSON, expectedJSON interface{}
+	if err := json.Unmarshal(b, &actualJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(graphqlJSFixture), &expectedJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actualJSON, expectedJSON) {
+		t.Errorf("expected:\n%s\nbut got:\n%s", graphqlJSFixture, b)
+	}
+	// Offsets past non-BMP runes are converted back via the source.
+	actual := Document{Source: input}
+	if err := json.Unmarshal([]byte(graphqlJSFixture), &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, &actual) {
+		t.Errorf("diff:\n %v", pretty.Diff(expected, &actual))
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	for _, input := range []string{
+		`{"kind": "Name", "value": "a"}`,
+		`{"kind": "Document", "definitions": [{"kind": "Unknown"}]}`,
+		`{"kind": "Document", "definitions": [{"kind": "OperationDefinition", "operation": "query"}]}`,
+		`{"kind": "Document", "definitions": [{"kind": "OperationDefinition", "operation": "bad", "selectionSet": {"kind": "SelectionSet"}}]}`,
+	} {
+		var d Document
+		if err := json.Unmarshal([]byte(input), &d); err == nil {
+			t.Errorf("input %q; expected error", input)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/jmank88/gql/lang/parser/lexer/scanner"
 	"github.com/jmank88/gql/lang/parser/lexer/token"
@@ -143,8 +144,8 @@ func (l *lexer) Lex(t *token.Token) error {
 	}
 }
 
-// The advanceToNextToken method advances l to the first character of the next token, skipping past whitespace and comments.
-// Returns true if successful, and false if an error was encountered.
+// The advanceToNextToken method advances l to the first character of the next token, skipping past whitespace and
+// comments. Returns true if successful, and false if an error was encountered.
 func (l *lexer) advanceToNextToken() bool {
 loop:
 	for {
@@ -275,13 +276,30 @@ func (l *lexer) advanceDigits() bool {
 
 // The readString methods lexs a string surrounding by double-quotes (") into the token t.
 // Any escaped or unicode characters will be replaced in t.Value.
+// Block strings, surrounded by triple-quotes ("""), are lexed by readBlockString.
 // It is the caller's responsibility to set t.Start and to assert that l.last == '"'.
 func (l *lexer) readString(t *token.Token) error {
 	t.Kind = token.String
 
+	if !l.advance() {
+		return l.err
+	}
+	if !l.eof && l.scanner.Rune() == '"' {
+		// Either an empty string or a block string.
+		if !l.advance() {
+			return l.err
+		}
+		if l.eof || l.scanner.Rune() != '"' {
+			t.End = l.lastIndex - 1
+			t.Value = ""
+			return nil
+		}
+		return l.readBlockString(t)
+	}
+
 	var value bytes.Buffer
 
-	for l.advance() {
+	for {
 		r := l.scanner.Rune()
 		switch {
 		case l.eof, r == token.LF, r == token.CR:
@@ -342,8 +360,115 @@ func (l *lexer) readString(t *token.Token) error {
 				return &SyntaxError{l.lastIndex, fmt.Errorf("Invalid character escape sequence: \\%s", string(l.scanner.Rune()))}
 			}
 		}
+		if !l.advance() {
+			return l.err
+		}
+	}
+}
+
+// The readBlockString method lexs a block string surrounded by triple-quotes (""") into the token t.
+// Only the escape sequence \""" is replaced, and t.Value holds the BlockStringValue of the raw contents.
+// It is the caller's responsibility to set t.Start and to assert that the last three runes were '"'.
+func (l *lexer) readBlockString(t *token.Token) error {
+	var raw bytes.Buffer
+
+	// The quotes function advances past up to three consecutive double-quotes, and returns the number found.
+	quotes := func() (int, bool) {
+		n := 0
+		for n < 3 && !l.eof && l.scanner.Rune() == '"' {
+			n++
+			if !l.advance() {
+				return n, false
+			}
+		}
+		return n, true
 	}
-	return l.err
+
+	if !l.advance() {
+		return l.err
+	}
+	for {
+		if l.eof {
+			return &SyntaxError{l.lastIndex, fmt.Errorf("unterminated block string")}
+		}
+		r := l.scanner.Rune()
+		switch {
+		case r == '"':
+			n, ok := quotes()
+			if !ok {
+				return l.err
+			}
+			if n == 3 {
+				t.End = l.lastIndex - 1
+				t.Value = BlockStringValue(raw.String())
+				return nil
+			}
+			raw.WriteString(strings.Repeat(`"`, n))
+			continue
+		case r == '\\':
+			if !l.advance() {
+				return l.err
+			}
+			n, ok := quotes()
+			if !ok {
+				return l.err
+			}
+			if n == 3 {
+				raw.WriteString(`"""`)
+			} else {
+				raw.WriteRune('\\')
+				raw.WriteString(strings.Repeat(`"`, n))
+			}
+			continue
+		case r < token.SPACE && r != token.TAB && r != token.LF && r != token.CR:
+			return &SyntaxError{l.lastIndex, fmt.Errorf("Invalid character within String: %U", r)}
+		}
+		raw.WriteRune(r)
+		if !l.advance() {
+			return l.err
+		}
+	}
+}
+
+// The BlockStringValue function returns the value of the raw contents of a block string: common indentation is
+// removed from all but the first line, leading and trailing blank lines are removed, and line terminators are
+// normalized to LF.
+func BlockStringValue(raw string) string {
+	lines := strings.Split(strings.Replace(strings.Replace(raw, "\r\n", "\n", -1), "\r", "\n", -1), "\n")
+
+	common := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent < len(line) && (common == -1 || indent < common) {
+			common = indent
+		}
+	}
+	if common > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < common {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][common:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// The leadingWhitespace function returns the number of leading spaces and tabs in s.
+func leadingWhitespace(s string) int {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
 }
 
 // The readSpread method lexs a spread ("...") into the token t.
@@ -59,6 +59,14 @@ func TestReadString(t *testing.T) {
 
 		// Unicode characters.
 		{`"\u00E1"`, token.Token{token.String, 0, 7, "á"}},
+
+		// Empty and block strings.
+		{`""`, token.Token{token.String, 0, 1, ""}},
+		{`"" `, token.Token{token.String, 0, 1, ""}},
+		{`""""""`, token.Token{token.String, 0, 5, ""}},
+		{`"""test"""`, token.Token{token.String, 0, 9, "test"}},
+		{`"""a "quoted" \n "" \""" b"""`, token.Token{token.String, 0, 28, `a "quoted" \n "" """ b`}},
+		{"\"\"\"\n    Hello,\r\n      World!\n\n    Bye.\n  \"\"\"", token.Token{token.String, 0, 43, "Hello,\n  World!\n\nBye."}},
 	} {
 		l, err := NewStringLexer(testCase.input)
 		if err != nil {
@@ -85,6 +93,9 @@ func TestReadString(t *testing.T) {
 		{"\"\\u12", 5},
 		{"\"\\uGGGG", 6},
 		{`"\8`, 2},
+		{`"""`, 3},
+		{`"""a""`, 6},
+		{"\"\"\"\b\"\"\"", 3},
 	} {
 		l, err := NewStringLexer(testCase.input)
 		if err != nil {
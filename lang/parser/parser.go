@@ -5,6 +5,7 @@
 package parser
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -22,16 +23,44 @@ func ParseString(source string) (*Document, error) {
 	if err != nil {
 		return nil, err
 	}
-	return p.parseDocument()
+	d, err := p.parseDocument()
+	if err != nil {
+		return nil, err
+	}
+	d.Source = source
+	return d, nil
 }
 
 // The ParseReader function parses a Document from the Reader r.
 func ParseReader(r io.Reader) (*Document, error) {
-	p, err := newReaderParser(r)
+	var source bytes.Buffer
+	p, err := newReaderParser(io.TeeReader(r, &source))
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.parseDocument()
+	if err != nil {
+		return nil, err
+	}
+	d.Source = source.String()
+	return d, nil
+}
+
+// The ParseValue function parses a constant Value from a source string, such as a default value from an
+// introspection result.
+func ParseValue(source string) (Value, error) {
+	p, err := newStringParser(source)
+	if err != nil {
+		return nil, err
+	}
+	v, err := p.parseValueLiteral(true)
 	if err != nil {
 		return nil, err
 	}
-	return p.parseDocument()
+	if _, err := p.expect(token.EOF); err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
 // A parser parses tokens read from the Lex function into ast.Nodes.
@@ -154,27 +183,33 @@ func (p *parser) parseName(name *Name) error {
 // Definition :
 //	- OperationDefinition
 //	- FragmentDefinition
+//	- SchemaDefinition
 //	- TypeDefinition
+//	- DirectiveDefinition
 func (p *parser) parseDefinition() (Definition, error) {
 	switch p.last.Kind {
 	case token.BraceL:
 		return p.parseOpDef()
+	case token.String:
+		return p.parseTypeSystemDef()
 	case token.Name:
 		switch p.last.Value {
 		case "query", "mutation", "subscription":
 			return p.parseOpDef()
 		case "fragment":
 			return p.parseFragmentDef()
-		case "type", "interface", "union", "scalar", "enum", "input", "extend":
-			return p.parseTypeDef()
+		case "schema":
+			return p.parseSchemaDef()
+		case "type", "interface", "union", "scalar", "enum", "input", "extend", "directive":
+			return p.parseTypeSystemDef()
 		default:
 			return nil, &SyntaxError{
 				p.last.Start,
-				fmt.Errorf("unexpected name %q; expected operation, fragment, or type definition", p.last.Value),
+				fmt.Errorf("unexpected name %q; expected operation, fragment, schema, type, or directive definition", p.last.Value),
 			}
 		}
 	default:
-		return nil, &SyntaxError{p.last.Start, fmt.Errorf("unexpected kind %q; expected '{' or Name", p.last.Kind)}
+		return nil, &SyntaxError{p.last.Start, fmt.Errorf("unexpected kind %q; expected '{', Name, or String", p.last.Kind)}
 	}
 }
 
@@ -272,7 +307,7 @@ func (p *parser) parseVarDefs() (varDefs []VarDef, err error) {
 
 // Parses and returns a variable definition.
 //
-// VarDef : Variable : Type [=DefaultValue]?
+// VarDef : Variable : Type [=DefaultValue]? Directives?
 func (p *parser) parseVarDef() (varDef *VarDef, err error) {
 	varDef = &VarDef{}
 	varDef.Start = p.last.Start
@@ -301,6 +336,12 @@ func (p *parser) parseVarDef() (varDef *VarDef, err error) {
 		varDef.DefaultValue = v
 	}
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	varDef.Directives = directives
+
 	varDef.End = p.prevEnd
 
 	return
@@ -805,7 +846,72 @@ func (p *parser) parseNamedType(nt *NamedType) (*NamedType, error) {
 	return nt, nil
 }
 
-// Parses and returns a type definition.
+// Parses and returns a schema definition.
+//
+// SchemaDef : schema Directives? { OpTypeDef+ }
+func (p *parser) parseSchemaDef() (*SchemaDef, error) {
+	s := &SchemaDef{}
+
+	s.Start = p.last.Start
+
+	if _, err := p.expectKeyword("schema"); err != nil {
+		return nil, err
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	s.Directives = directives
+
+	err = p.many(token.BraceL, func() error {
+		var o OpTypeDef
+		if err := p.parseOpTypeDef(&o); err != nil {
+			return err
+		}
+		s.OpTypeDefs = append(s.OpTypeDefs, o)
+		return nil
+	}, token.BraceR)
+	if err != nil {
+		return nil, err
+	}
+
+	s.End = p.prevEnd
+
+	return s, nil
+}
+
+// Parses an operation type definition into o.
+//
+// OpTypeDef : OperationType : NamedType
+func (p *parser) parseOpTypeDef(o *OpTypeDef) error {
+	o.Start = p.last.Start
+
+	opToken, err := p.expect(token.Name)
+	if err != nil {
+		return err
+	}
+
+	op, err := parseOperation(opToken.Value)
+	if err != nil {
+		return &SyntaxError{opToken.Start, err}
+	}
+	o.OpType = op
+
+	if _, err := p.expect(token.Colon); err != nil {
+		return err
+	}
+
+	if _, err := p.parseNamedType(&o.NamedType); err != nil {
+		return err
+	}
+
+	o.End = p.prevEnd
+
+	return nil
+}
+
+// Parses and returns a type definition, and its optional description.
 //
 // TypeDef :
 //	- ObjTypeDef
@@ -816,30 +922,77 @@ func (p *parser) parseNamedType(nt *NamedType) (*NamedType, error) {
 //	- InputObjTypeDef
 //	- TypeExtDef
 func (p *parser) parseTypeDef() (t TypeDef, err error) {
+	loc := Loc{Start: p.last.Start}
+
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseDescribedTypeDef(loc, description)
+}
+
+// Parses and returns a type definition or directive definition, either of which may begin with a description.
+//
+// TypeSystemDefinition :
+//	- TypeDef
+//	- DirectiveDef
+func (p *parser) parseTypeSystemDef() (Definition, error) {
+	loc := Loc{Start: p.last.Start}
+
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.last.Kind == token.Name && p.last.Value == "directive" {
+		return p.parseDirectiveDef(&DirectiveDef{Loc: loc, Description: description})
+	}
+	return p.parseDescribedTypeDef(loc, description)
+}
+
+// Parses and returns the remainder of a type definition starting at loc, whose description has already been parsed.
+func (p *parser) parseDescribedTypeDef(loc Loc, description *String) (TypeDef, error) {
 	switch p.last.Value {
 	case "type":
-		return p.parseObjTypeDef(nil)
+		return p.parseObjTypeDef(&ObjTypeDef{Loc: loc, Description: description})
 	case "interface":
-		return p.parseInterfaceTypeDef()
+		return p.parseInterfaceTypeDef(&InterfaceTypeDef{Loc: loc, Description: description})
 	case "union":
-		return p.parseUnionTypeDef()
+		return p.parseUnionTypeDef(&UnionTypeDef{Loc: loc, Description: description})
 	case "scalar":
-		return p.parseScalarTypeDef()
+		return p.parseScalarTypeDef(&ScalarTypeDef{Loc: loc, Description: description})
 	case "enum":
-		return p.parseEnumTypeDef()
+		return p.parseEnumTypeDef(&EnumTypeDef{Loc: loc, Description: description})
 	case "input":
-		return p.parseInputObjTypeDef()
+		return p.parseInputObjTypeDef(&InputObjTypeDef{Loc: loc, Description: description})
 	case "extend":
+		if description != nil {
+			return nil, &SyntaxError{loc.Start, errors.New("type extension may not have a description")}
+		}
 		return p.parseTypeExtDef()
 	default:
 		return nil, &SyntaxError{p.last.Start, fmt.Errorf("unrecognized typeDef %q", p.last.Value)}
 	}
-	return
+}
+
+// Parses and returns an optional description, or nil if the last token is not a string.
+//
+// Description : StringValue
+func (p *parser) parseDescription() (*String, error) {
+	t := p.last
+	if t.Kind != token.String {
+		return nil, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return &String{Loc{t.Start, p.prevEnd}, t.Value}, nil
 }
 
 // Parses an object type definition into o.
 //
-// ObjTypeDef : type Name ImplementsInterfaces? { FieldDef+ }
+// ObjTypeDef : type Name ImplementsInterfaces? Directives? { FieldDef+ }
 func (p *parser) parseObjTypeDef(o *ObjTypeDef) (*ObjTypeDef, error) {
 	if o == nil {
 		o = new(ObjTypeDef)
@@ -860,6 +1013,12 @@ func (p *parser) parseObjTypeDef(o *ObjTypeDef) (*ObjTypeDef, error) {
 	}
 	o.Interfaces = interfaces
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	o.Directives = directives
+
 	err = p.any(token.BraceL, func() error {
 		var f FieldDef
 		if err := p.parseFieldDef(&f); err != nil {
@@ -895,7 +1054,7 @@ func (p *parser) parseImplementsInterfaces() ([]NamedType, error) {
 		types = append(types, nt)
 		for {
 			switch p.last.Kind {
-			case token.BraceL, token.EOF:
+			case token.BraceL, token.At, token.EOF:
 				return types, nil
 			default:
 				if _, err := p.parseNamedType(&nt); err != nil {
@@ -910,10 +1069,16 @@ func (p *parser) parseImplementsInterfaces() ([]NamedType, error) {
 
 // Parses a field definition into f.
 //
-// FieldDef : Name ArgumentsDef? : Type
+// FieldDef : Description? Name ArgumentsDef? : Type Directives?
 func (p *parser) parseFieldDef(f *FieldDef) error {
 	f.Start = p.last.Start
 
+	description, err := p.parseDescription()
+	if err != nil {
+		return err
+	}
+	f.Description = description
+
 	if err := p.parseName(&f.Name); err != nil {
 		return err
 	}
@@ -934,6 +1099,12 @@ func (p *parser) parseFieldDef(f *FieldDef) error {
 	}
 	f.RefType = t
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+	f.Directives = directives
+
 	f.End = p.prevEnd
 
 	return nil
@@ -959,10 +1130,16 @@ func (p *parser) parseArgumentsDef() (defs []InputValueDef, err error) {
 
 // Parses an input value definition into i.
 //
-// InputValueDef : Name : Type DefaultValue?
+// InputValueDef : Description? Name : Type DefaultValue? Directives?
 func (p *parser) parseInputValueDef(i *InputValueDef) error {
 	i.Start = p.last.Start
 
+	description, err := p.parseDescription()
+	if err != nil {
+		return err
+	}
+	i.Description = description
+
 	if err := p.parseName(&i.Name); err != nil {
 		return err
 	}
@@ -988,18 +1165,25 @@ func (p *parser) parseInputValueDef(i *InputValueDef) error {
 	}
 	i.DefaultValue = defaultValue
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+	i.Directives = directives
+
 	i.End = p.prevEnd
 
 	return nil
 }
 
-// Parses and returns an interface type definition.
+// Parses an interface type definition into i.
 //
-// InterfaceTypeDef : interface Name { FieldDef+ }
-func (p *parser) parseInterfaceTypeDef() (*InterfaceTypeDef, error) {
-	i := &InterfaceTypeDef{}
-
-	i.Start = p.last.Start
+// InterfaceTypeDef : interface Name Directives? { FieldDef+ }
+func (p *parser) parseInterfaceTypeDef(i *InterfaceTypeDef) (*InterfaceTypeDef, error) {
+	if i == nil {
+		i = new(InterfaceTypeDef)
+		i.Start = p.last.Start
+	}
 
 	if _, err := p.expectKeyword("interface"); err != nil {
 		return nil, err
@@ -1009,7 +1193,13 @@ func (p *parser) parseInterfaceTypeDef() (*InterfaceTypeDef, error) {
 		return nil, err
 	}
 
-	err := p.any(token.BraceL, func() error {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	i.Directives = directives
+
+	err = p.any(token.BraceL, func() error {
 		var f FieldDef
 		if err := p.parseFieldDef(&f); err != nil {
 			return err
@@ -1026,13 +1216,14 @@ func (p *parser) parseInterfaceTypeDef() (*InterfaceTypeDef, error) {
 	return i, nil
 }
 
-// Parses and returns a union type definition.
+// Parses a union type definition into u.
 //
-// UnionTypeDef : union Name = UnionMembers
-func (p *parser) parseUnionTypeDef() (*UnionTypeDef, error) {
-	u := &UnionTypeDef{}
-
-	u.Start = p.last.Start
+// UnionTypeDef : union Name Directives? = UnionMembers
+func (p *parser) parseUnionTypeDef(u *UnionTypeDef) (*UnionTypeDef, error) {
+	if u == nil {
+		u = new(UnionTypeDef)
+		u.Start = p.last.Start
+	}
 
 	if _, err := p.expectKeyword("union"); err != nil {
 		return nil, err
@@ -1042,6 +1233,12 @@ func (p *parser) parseUnionTypeDef() (*UnionTypeDef, error) {
 		return nil, err
 	}
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	u.Directives = directives
+
 	if _, err := p.expect(token.Equals); err != nil {
 		return nil, err
 	}
@@ -1076,13 +1273,14 @@ func (p *parser) parseUnionMembers() ([]NamedType, error) {
 	return members, err
 }
 
-// Parses and returns a scalar type definition.
+// Parses a scalar type definition into s.
 //
-// ScalarTypeDef : scalar Name
-func (p *parser) parseScalarTypeDef() (*ScalarTypeDef, error) {
-	s := &ScalarTypeDef{}
-
-	s.Start = p.last.Start
+// ScalarTypeDef : scalar Name Directives?
+func (p *parser) parseScalarTypeDef(s *ScalarTypeDef) (*ScalarTypeDef, error) {
+	if s == nil {
+		s = new(ScalarTypeDef)
+		s.Start = p.last.Start
+	}
 
 	if _, err := p.expectKeyword("scalar"); err != nil {
 		return nil, err
@@ -1092,18 +1290,25 @@ func (p *parser) parseScalarTypeDef() (*ScalarTypeDef, error) {
 		return nil, err
 	}
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	s.Directives = directives
+
 	s.End = p.prevEnd
 
 	return s, nil
 }
 
-// Parses and returns an enum type definition.
+// Parses an enum type definition into e.
 //
-// EnumTypeDef : enum Name { EnumValueDef+ }
-func (p *parser) parseEnumTypeDef() (*EnumTypeDef, error) {
-	e := &EnumTypeDef{}
-
-	e.Start = p.last.Start
+// EnumTypeDef : enum Name Directives? { EnumValueDef+ }
+func (p *parser) parseEnumTypeDef(e *EnumTypeDef) (*EnumTypeDef, error) {
+	if e == nil {
+		e = new(EnumTypeDef)
+		e.Start = p.last.Start
+	}
 
 	if _, err := p.expectKeyword("enum"); err != nil {
 		return nil, err
@@ -1113,7 +1318,13 @@ func (p *parser) parseEnumTypeDef() (*EnumTypeDef, error) {
 		return nil, err
 	}
 
-	err := p.many(token.BraceL, func() error {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	e.Directives = directives
+
+	err = p.many(token.BraceL, func() error {
 		var v EnumValueDef
 
 		if err := p.parseEnumValueDef(&v); err != nil {
@@ -1131,22 +1342,43 @@ func (p *parser) parseEnumTypeDef() (*EnumTypeDef, error) {
 	return e, nil
 }
 
-// Parses and returns an enum value definition.
+// Parses an enum value definition into e.
 //
-// EnumValueDefinition : EnumValue
+// EnumValueDefinition : Description? EnumValue Directives?
 //
 // EnumValue : Name
 func (p *parser) parseEnumValueDef(e *EnumValueDef) error {
-	return p.parseName((*Name)(e))
+	e.Start = p.last.Start
+
+	description, err := p.parseDescription()
+	if err != nil {
+		return err
+	}
+	e.Description = description
+
+	if err := p.parseName(&e.Name); err != nil {
+		return err
+	}
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return err
+	}
+	e.Directives = directives
+
+	e.End = p.prevEnd
+
+	return nil
 }
 
-// Parses and returns an input object type definition.
+// Parses an input object type definition into i.
 //
-// InputObjTypeDef : input Name { InputValueDefinition+ }
-func (p *parser) parseInputObjTypeDef() (*InputObjTypeDef, error) {
-	i := &InputObjTypeDef{}
-
-	i.Start = p.last.Start
+// InputObjTypeDef : input Name Directives? { InputValueDefinition+ }
+func (p *parser) parseInputObjTypeDef(i *InputObjTypeDef) (*InputObjTypeDef, error) {
+	if i == nil {
+		i = new(InputObjTypeDef)
+		i.Start = p.last.Start
+	}
 
 	if _, err := p.expectKeyword("input"); err != nil {
 		return nil, err
@@ -1156,8 +1388,14 @@ func (p *parser) parseInputObjTypeDef() (*InputObjTypeDef, error) {
 		return nil, err
 	}
 
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+	i.Directives = directives
+
 	var def InputValueDef
-	err := p.any(token.BraceL, func() error {
+	err = p.any(token.BraceL, func() error {
 		if err := p.parseInputValueDef(&def); err != nil {
 			return err
 		}
@@ -1192,6 +1430,77 @@ func (p *parser) parseTypeExtDef() (*TypeExtDef, error) {
 	return t, nil
 }
 
+// Parses a directive definition into d.
+//
+// DirectiveDef : directive @ Name ArgumentsDef? repeatable? on DirectiveLocations
+func (p *parser) parseDirectiveDef(d *DirectiveDef) (*DirectiveDef, error) {
+	if d == nil {
+		d = new(DirectiveDef)
+		d.Start = p.last.Start
+	}
+
+	if _, err := p.expectKeyword("directive"); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(token.At); err != nil {
+		return nil, err
+	}
+
+	if err := p.parseName(&d.Name); err != nil {
+		return nil, err
+	}
+
+	args, err := p.parseArgumentsDef()
+	if err != nil {
+		return nil, err
+	}
+	d.Arguments = args
+
+	if p.last.Kind == token.Name && p.last.Value == "repeatable" {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		d.Repeatable = true
+	}
+
+	if _, err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+
+	locations, err := p.parseDirectiveLocations()
+	if err != nil {
+		return nil, err
+	}
+	d.Locations = locations
+
+	d.End = p.prevEnd
+
+	return d, nil
+}
+
+// Parses and returns the locations of a directive definition.
+//
+// DirectiveLocations : |? Name ( | Name )*
+func (p *parser) parseDirectiveLocations() ([]Name, error) {
+	if _, err := p.skip(token.Pipe); err != nil {
+		return nil, err
+	}
+	var locations []Name
+	for {
+		var n Name
+		if err := p.parseName(&n); err != nil {
+			return nil, err
+		}
+		locations = append(locations, n)
+		if skipped, err := p.skip(token.Pipe); err != nil {
+			return nil, err
+		} else if !skipped {
+			return locations, nil
+		}
+	}
+}
+
 // 0 or more
 // <open>[val,...]<close>
 func (p *parser) any(open token.Kind, parseFn func() error, close token.Kind) error {
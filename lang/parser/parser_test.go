@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kr/pretty"
@@ -397,9 +398,9 @@ func TestParseDefinition(t *testing.T) {
 		{
 			"union test=a|b",
 			&UnionTypeDef{
-				Loc{0, 13},
-				Name{Loc{6, 9}, "test"},
-				[]NamedType{
+				Loc:  Loc{0, 13},
+				Name: Name{Loc{6, 9}, "test"},
+				NamedTypes: []NamedType{
 					{Loc{11, 11}, "a"},
 					{Loc{13, 13}, "b"},
 				},
@@ -408,27 +409,27 @@ func TestParseDefinition(t *testing.T) {
 		{
 			"scalar test",
 			&ScalarTypeDef{
-				Loc{0, 10},
-				Name{Loc{7, 10}, "test"},
+				Loc:  Loc{0, 10},
+				Name: Name{Loc{7, 10}, "test"},
 			},
 		},
 		{
 			"enum test {a,b}",
 			&EnumTypeDef{
-				Loc{0, 15},
-				Name{Loc{5, 8}, "test"},
-				[]EnumValueDef{
-					{Loc{11, 11}, "a"},
-					{Loc{13, 13}, "b"},
+				Loc:  Loc{0, 15},
+				Name: Name{Loc{5, 8}, "test"},
+				EnumValueDefs: []EnumValueDef{
+					{Loc: Loc{11, 11}, Name: Name{Loc{11, 11}, "a"}},
+					{Loc: Loc{13, 13}, Name: Name{Loc{13, 13}, "b"}},
 				},
 			},
 		},
 		{
 			"input test {a:int}",
 			&InputObjTypeDef{
-				Loc{0, 18},
-				Name{Loc{6, 9}, "test"},
-				[]InputValueDef{
+				Loc:  Loc{0, 18},
+				Name: Name{Loc{6, 9}, "test"},
+				Fields: []InputValueDef{
 					{
 						Loc:     Loc{12, 16},
 						Name:    Name{Loc{12, 12}, "a"},
@@ -440,10 +441,10 @@ func TestParseDefinition(t *testing.T) {
 		{
 			"extend type test implements a {b:int}",
 			&TypeExtDef{
-				Loc{0, 37},
-				Name{Loc{12, 15}, "test"},
-				[]NamedType{{Loc{28, 28}, "a"}},
-				[]FieldDef{
+				Loc:        Loc{0, 37},
+				Name:       Name{Loc{12, 15}, "test"},
+				Interfaces: []NamedType{{Loc{28, 28}, "a"}},
+				FieldDefs: []FieldDef{
 					{
 						Loc:     Loc{31, 35},
 						Name:    Name{Loc{31, 31}, "b"},
@@ -730,6 +731,21 @@ func TestParseVarDef(t *testing.T) {
 				DefaultValue: &String{Loc{10, 15}, "test"},
 			},
 		},
+		{
+			"$a:int=1@x",
+			VarDef{
+				Loc: Loc{0, 9},
+				Variable: Variable{
+					Loc{0, 1},
+					Name{Loc{1, 1}, "a"},
+				},
+				RefType:      &NamedType{Loc{3, 5}, "int"},
+				DefaultValue: &Int{Loc{7, 7}, "1"},
+				Directives: []Directive{
+					{Loc: Loc{8, 9}, Name: Name{Loc{9, 9}, "x"}},
+				},
+			},
+		},
 	} {
 		p, err := newStringParser(testCase.input)
 		if err != nil {
@@ -1470,6 +1486,43 @@ func TestFragmentDef(t *testing.T) {
 	}
 }
 
+func TestParseSchemaDef(t *testing.T) {
+	input := "schema @dir {query: Q, mutation: M}"
+	expected := SchemaDef{
+		Loc: Loc{0, 35},
+		Directives: []Directive{
+			{Loc: Loc{7, 10}, Name: Name{Loc{8, 10}, "dir"}},
+		},
+		OpTypeDefs: []OpTypeDef{
+			{Loc{13, 20}, Query, NamedType{Loc{20, 20}, "Q"}},
+			{Loc{23, 33}, Mutation, NamedType{Loc{33, 33}, "M"}},
+		},
+	}
+	p, err := newStringParser(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, err := p.parseSchemaDef(); err != nil {
+		t.Errorf("input %q; unexpected error: %s", input, err)
+	} else if err := deepEqual(*actual, expected); err != nil {
+		t.Errorf("input %q; %s", input, err)
+	}
+
+	for _, input := range []string{
+		"schema {}",
+		"schema {fragment: F}",
+		"schema {query Q}",
+	} {
+		p, err := newStringParser(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.parseSchemaDef(); err == nil {
+			t.Errorf("input %q; expected error", input)
+		}
+	}
+}
+
 func TestParseFragmentName(t *testing.T) {
 	expected := &Name{Loc{0, 3}, "test"}
 	p, err := newStringParser("test")
@@ -1586,6 +1639,29 @@ func TestParseValueLiteral(t *testing.T) {
 	}
 }
 
+func TestParseValue(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		expected Value
+	}{
+		{"7", &Int{Loc{0, 0}, "7"}},
+		{`"foo"`, &String{Loc{0, 4}, "foo"}},
+		{"[A, B]", &List{Loc{0, 6}, []Value{&Enum{Loc{1, 1}, "A"}, &Enum{Loc{4, 4}, "B"}}}},
+	} {
+		if actual, err := ParseValue(testCase.input); err != nil {
+			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
+		} else if err := deepEqual(actual, testCase.expected); err != nil {
+			t.Errorf("input %q; %s", testCase.input, err)
+		}
+	}
+
+	for _, input := range []string{"", "$a", "1 2", "null"} {
+		if v, err := ParseValue(input); err == nil {
+			t.Errorf("input %q; expected error but got %v", input, v)
+		}
+	}
+}
+
 func TestParseList(t *testing.T) {
 	for _, testCase := range []struct {
 		input    string
@@ -1881,9 +1957,9 @@ func TestParseTypeDef(t *testing.T) {
 		{
 			"union test=a|b",
 			&UnionTypeDef{
-				Loc{0, 13},
-				Name{Loc{6, 9}, "test"},
-				[]NamedType{
+				Loc:  Loc{0, 13},
+				Name: Name{Loc{6, 9}, "test"},
+				NamedTypes: []NamedType{
 					{Loc{11, 11}, "a"},
 					{Loc{13, 13}, "b"},
 				},
@@ -1892,27 +1968,27 @@ func TestParseTypeDef(t *testing.T) {
 		{
 			"scalar test",
 			&ScalarTypeDef{
-				Loc{0, 10},
-				Name{Loc{7, 10}, "test"},
+				Loc:  Loc{0, 10},
+				Name: Name{Loc{7, 10}, "test"},
 			},
 		},
 		{
 			"enum test {a,b}",
 			&EnumTypeDef{
-				Loc{0, 15},
-				Name{Loc{5, 8}, "test"},
-				[]EnumValueDef{
-					{Loc{11, 11}, "a"},
-					{Loc{13, 13}, "b"},
+				Loc:  Loc{0, 15},
+				Name: Name{Loc{5, 8}, "test"},
+				EnumValueDefs: []EnumValueDef{
+					{Loc: Loc{11, 11}, Name: Name{Loc{11, 11}, "a"}},
+					{Loc: Loc{13, 13}, Name: Name{Loc{13, 13}, "b"}},
 				},
 			},
 		},
 		{
 			"input test {a:int}",
 			&InputObjTypeDef{
-				Loc{0, 18},
-				Name{Loc{6, 9}, "test"},
-				[]InputValueDef{
+				Loc:  Loc{0, 18},
+				Name: Name{Loc{6, 9}, "test"},
+				Fields: []InputValueDef{
 					{
 						Loc:     Loc{12, 16},
 						Name:    Name{Loc{12, 12}, "a"},
@@ -1924,10 +2000,10 @@ func TestParseTypeDef(t *testing.T) {
 		{
 			"extend type test implements a {b:int}",
 			&TypeExtDef{
-				Loc{0, 37},
-				Name{Loc{12, 15}, "test"},
-				[]NamedType{{Loc{28, 28}, "a"}},
-				[]FieldDef{
+				Loc:        Loc{0, 37},
+				Name:       Name{Loc{12, 15}, "test"},
+				Interfaces: []NamedType{{Loc{28, 28}, "a"}},
+				FieldDefs: []FieldDef{
 					{
 						Loc:     Loc{31, 35},
 						Name:    Name{Loc{31, 31}, "b"},
@@ -1949,6 +2025,108 @@ func TestParseTypeDef(t *testing.T) {
 	}
 }
 
+func TestParseDescriptionsAndDirectives(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		expected TypeDef
+	}{
+		{
+			`"d" scalar s @a`,
+			&ScalarTypeDef{
+				Loc:         Loc{0, 14},
+				Description: &String{Loc{0, 2}, "d"},
+				Name:        Name{Loc{11, 11}, "s"},
+				Directives:  []Directive{{Loc: Loc{13, 14}, Name: Name{Loc{14, 14}, "a"}}},
+			},
+		},
+		{
+			`enum e {"x" A @b}`,
+			&EnumTypeDef{
+				Loc:  Loc{0, 17},
+				Name: Name{Loc{5, 5}, "e"},
+				EnumValueDefs: []EnumValueDef{
+					{
+						Loc:         Loc{8, 15},
+						Description: &String{Loc{8, 10}, "x"},
+						Name:        Name{Loc{12, 12}, "A"},
+						Directives:  []Directive{{Loc: Loc{14, 15}, Name: Name{Loc{15, 15}, "b"}}},
+					},
+				},
+			},
+		},
+		{
+			`type t {"""f""" f("a" a:int = 1 @c):int @d}`,
+			&ObjTypeDef{
+				Loc:  Loc{0, 43},
+				Name: Name{Loc{5, 5}, "t"},
+				FieldDefs: []FieldDef{
+					{
+						Loc:         Loc{8, 41},
+						Description: &String{Loc{8, 14}, "f"},
+						Name:        Name{Loc{16, 16}, "f"},
+						Arguments: []InputValueDef{
+							{
+								Loc:          Loc{18, 33},
+								Description:  &String{Loc{18, 20}, "a"},
+								Name:         Name{Loc{22, 22}, "a"},
+								RefType:      &NamedType{Loc{24, 26}, "int"},
+								DefaultValue: &Int{Loc{30, 30}, "1"},
+								Directives:   []Directive{{Loc: Loc{32, 33}, Name: Name{Loc{33, 33}, "c"}}},
+							},
+						},
+						RefType:    &NamedType{Loc{36, 38}, "int"},
+						Directives: []Directive{{Loc: Loc{40, 41}, Name: Name{Loc{41, 41}, "d"}}},
+					},
+				},
+			},
+		},
+		{
+			`"u" union u @a = b`,
+			&UnionTypeDef{
+				Loc:         Loc{0, 17},
+				Description: &String{Loc{0, 2}, "u"},
+				Name:        Name{Loc{10, 10}, "u"},
+				Directives:  []Directive{{Loc: Loc{12, 13}, Name: Name{Loc{13, 13}, "a"}}},
+				NamedTypes:  []NamedType{{Loc{17, 17}, "b"}},
+			},
+		},
+		{
+			`type t implements i @a {}`,
+			&ObjTypeDef{
+				Loc:        Loc{0, 25},
+				Name:       Name{Loc{5, 5}, "t"},
+				Interfaces: []NamedType{{Loc{18, 18}, "i"}},
+				Directives: []Directive{{Loc: Loc{20, 21}, Name: Name{Loc{21, 21}, "a"}}},
+			},
+		},
+	} {
+		p, err := newStringParser(testCase.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual, err := p.parseDefinition(); err != nil {
+			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
+		} else if err := deepEqual(actual, testCase.expected); err != nil {
+			t.Errorf("input %q; %s", testCase.input, err)
+		}
+	}
+
+	// Errors.
+	for _, input := range []string{
+		`"d" extend type t {}`,
+		`"d" query {a}`,
+		`"d"`,
+	} {
+		p, err := newStringParser(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.parseDefinition(); err == nil {
+			t.Errorf("input %q; expected error", input)
+		}
+	}
+}
+
 func TestParseObjTypeDef(t *testing.T) {
 	for _, testCase := range []struct {
 		input    string
@@ -2068,16 +2246,16 @@ func TestParseFieldDef(t *testing.T) {
 		{
 			"foo(a:int):boolean",
 			&FieldDef{
-				Loc{0, 17},
-				Name{Loc{0, 2}, "foo"},
-				[]InputValueDef{
+				Loc:  Loc{0, 17},
+				Name: Name{Loc{0, 2}, "foo"},
+				Arguments: []InputValueDef{
 					{
 						Loc:     Loc{4, 8},
 						Name:    Name{Loc{4, 4}, "a"},
 						RefType: &NamedType{Loc{6, 8}, "int"},
 					},
 				},
-				&NamedType{Loc{11, 17}, "boolean"},
+				RefType: &NamedType{Loc{11, 17}, "boolean"},
 			},
 		},
 	} {
@@ -2153,10 +2331,10 @@ func TestParseInputValueDef(t *testing.T) {
 		{
 			"foo:int = 7",
 			&InputValueDef{
-				Loc{0, 10},
-				Name{Loc{0, 2}, "foo"},
-				&NamedType{Loc{4, 6}, "int"},
-				&Int{Loc{10, 10}, "7"},
+				Loc:          Loc{0, 10},
+				Name:         Name{Loc{0, 2}, "foo"},
+				RefType:      &NamedType{Loc{4, 6}, "int"},
+				DefaultValue: &Int{Loc{10, 10}, "7"},
 			},
 		},
 	} {
@@ -2188,9 +2366,9 @@ func TestInterfaceTypeDef(t *testing.T) {
 		{
 			"interface bar {fizz:int, buzz:boolean}",
 			&InterfaceTypeDef{
-				Loc{0, 38},
-				Name{Loc{10, 12}, "bar"},
-				[]FieldDef{
+				Loc:  Loc{0, 38},
+				Name: Name{Loc{10, 12}, "bar"},
+				FieldDefs: []FieldDef{
 					{
 						Loc:     Loc{15, 22},
 						Name:    Name{Loc{15, 18}, "fizz"},
@@ -2209,7 +2387,7 @@ func TestInterfaceTypeDef(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if actual, err := p.parseInterfaceTypeDef(); err != nil {
+		if actual, err := p.parseInterfaceTypeDef(nil); err != nil {
 			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
 		} else if err := deepEqual(actual, testCase.expected); err != nil {
 			t.Errorf("input %q; %s", testCase.input, err)
@@ -2225,9 +2403,9 @@ func TestParseUnionTypeDef(t *testing.T) {
 		{
 			"union foo = bar",
 			&UnionTypeDef{
-				Loc{0, 14},
-				Name{Loc{6, 8}, "foo"},
-				[]NamedType{
+				Loc:  Loc{0, 14},
+				Name: Name{Loc{6, 8}, "foo"},
+				NamedTypes: []NamedType{
 					{Loc{Start: 12, End: 14}, "bar"},
 				},
 			},
@@ -2235,9 +2413,9 @@ func TestParseUnionTypeDef(t *testing.T) {
 		{
 			"union foo = bar | fizz | buzz",
 			&UnionTypeDef{
-				Loc{0, 28},
-				Name{Loc{6, 8}, "foo"},
-				[]NamedType{
+				Loc:  Loc{0, 28},
+				Name: Name{Loc{6, 8}, "foo"},
+				NamedTypes: []NamedType{
 					{Loc{Start: 12, End: 14}, "bar"},
 					{Loc{Start: 18, End: 21}, "fizz"},
 					{Loc{Start: 25, End: 28}, "buzz"},
@@ -2249,7 +2427,7 @@ func TestParseUnionTypeDef(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if actual, err := p.parseUnionTypeDef(); err != nil {
+		if actual, err := p.parseUnionTypeDef(nil); err != nil {
 			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
 		} else if err := deepEqual(actual, testCase.expected); err != nil {
 			t.Errorf("input %q; %s", testCase.input, err)
@@ -2295,8 +2473,8 @@ func TestParseScalarTypeDef(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	expected := &ScalarTypeDef{Loc{0, 9}, Name{Loc{7, 9}, "foo"}}
-	if actual, err := p.parseScalarTypeDef(); err != nil {
+	expected := &ScalarTypeDef{Loc: Loc{0, 9}, Name: Name{Loc{7, 9}, "foo"}}
+	if actual, err := p.parseScalarTypeDef(nil); err != nil {
 		t.Errorf("unexpected error: %s", err)
 	} else if err := deepEqual(actual, expected); err != nil {
 		t.Error(err)
@@ -2309,11 +2487,11 @@ func TestParseEnumTypeDef(t *testing.T) {
 		t.Fatal(err)
 	}
 	expected := &EnumTypeDef{
-		Loc{0, 14},
-		Name{Loc{5, 7}, "foo"},
-		[]EnumValueDef{{Loc{10, 12}, "bar"}},
+		Loc:           Loc{0, 14},
+		Name:          Name{Loc{5, 7}, "foo"},
+		EnumValueDefs: []EnumValueDef{{Loc: Loc{10, 12}, Name: Name{Loc{10, 12}, "bar"}}},
 	}
-	if actual, err := p.parseEnumTypeDef(); err != nil {
+	if actual, err := p.parseEnumTypeDef(nil); err != nil {
 		t.Errorf("unexpected error: %s", err)
 	} else if err := deepEqual(actual, expected); err != nil {
 		t.Error(err)
@@ -2325,7 +2503,7 @@ func TestParseEnumValueDef(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	expected := &EnumValueDef{Loc{0, 2}, "foo"}
+	expected := &EnumValueDef{Loc: Loc{0, 2}, Name: Name{Loc{0, 2}, "foo"}}
 	actual := new(EnumValueDef)
 	if err := p.parseEnumValueDef(actual); err != nil {
 		t.Errorf("unexpected error: %s", err)
@@ -2342,9 +2520,9 @@ func TestParseInputObjTypeDef(t *testing.T) {
 		{
 			"input foo {bar:int}",
 			&InputObjTypeDef{
-				Loc{0, 19},
-				Name{Loc{6, 8}, "foo"},
-				[]InputValueDef{
+				Loc:  Loc{0, 19},
+				Name: Name{Loc{6, 8}, "foo"},
+				Fields: []InputValueDef{
 					{
 						Loc:     Loc{11, 17},
 						Name:    Name{Loc{11, 13}, "bar"},
@@ -2356,9 +2534,9 @@ func TestParseInputObjTypeDef(t *testing.T) {
 		{
 			"input foo {bar: int, fizz: boolean, buzz: string}",
 			&InputObjTypeDef{
-				Loc{0, 49},
-				Name{Loc{6, 8}, "foo"},
-				[]InputValueDef{
+				Loc:  Loc{0, 49},
+				Name: Name{Loc{6, 8}, "foo"},
+				Fields: []InputValueDef{
 					{
 						Loc:     Loc{11, 18},
 						Name:    Name{Loc{11, 13}, "bar"},
@@ -2382,7 +2560,7 @@ func TestParseInputObjTypeDef(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if actual, err := p.parseInputObjTypeDef(); err != nil {
+		if actual, err := p.parseInputObjTypeDef(nil); err != nil {
 			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
 		} else if err := deepEqual(actual, testCase.expected); err != nil {
 			t.Errorf("input %q; %s", testCase.input, err)
@@ -2406,6 +2584,64 @@ func TestParseTypeExtDef(t *testing.T) {
 	}
 }
 
+func TestParseDirectiveDef(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		expected *DirectiveDef
+	}{
+		{
+			"directive @foo on FIELD",
+			&DirectiveDef{
+				Loc:       Loc{0, 22},
+				Name:      Name{Loc{11, 13}, "foo"},
+				Locations: []Name{{Loc{18, 22}, "FIELD"}},
+			},
+		},
+		{
+			`"d" directive @foo(a:int) repeatable on | FIELD | OBJECT`,
+			&DirectiveDef{
+				Loc:         Loc{0, 55},
+				Description: &String{Loc{0, 2}, "d"},
+				Name:        Name{Loc{15, 17}, "foo"},
+				Arguments: []InputValueDef{
+					{
+						Loc:     Loc{19, 23},
+						Name:    Name{Loc{19, 19}, "a"},
+						RefType: &NamedType{Loc{21, 23}, "int"},
+					},
+				},
+				Repeatable: true,
+				Locations:  []Name{{Loc{42, 46}, "FIELD"}, {Loc{50, 55}, "OBJECT"}},
+			},
+		},
+	} {
+		p, err := newStringParser(testCase.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual, err := p.parseTypeSystemDef(); err != nil {
+			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
+		} else if err := deepEqual(actual, testCase.expected); err != nil {
+			t.Errorf("input %q; %s", testCase.input, err)
+		}
+	}
+
+	for _, input := range []string{
+		"directive foo on FIELD",
+		"directive @foo FIELD",
+		"directive @foo on",
+		"directive @foo repeatable",
+	} {
+		p, err := newStringParser(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.parseTypeSystemDef(); err == nil {
+			t.Errorf("input %q; expected error", input)
+		}
+	}
+}
+
 func TestAny(t *testing.T) {
 	// Single element.
 	p, err := newStringParser("(a)")
@@ -2581,12 +2817,24 @@ func TestSimpleParse(t *testing.T) {
 				},
 			},
 		},
+		"{ user(id: 4) { name } }",
 	}
 	if err := deepEqual(d, expected); err != nil {
 		t.Error(err)
 	}
 }
 
+func TestParseReaderSource(t *testing.T) {
+	const source = "{ user(id: 4) {\n\tname\n} }"
+	d, err := ParseReader(strings.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Source != source {
+		t.Errorf("expected source %q but got %q", source, d.Source)
+	}
+}
+
 func deepEqual(actual, expected interface{}) error {
 	if !reflect.DeepEqual(actual, expected) {
 		return fmt.Errorf("expected:\n %# v\n\n but got:\n %# v\n\n diff:\n %v\n",
@@ -2,12 +2,14 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/jmank88/gql/lang/ast"
-	"strconv"
 )
 
 type Style int
@@ -22,12 +24,12 @@ const (
 	//		@directive(
 	//			arg:"stringVal"
 	//		){
-	//			alias name
+	//			alias:name
 	//		}
 	// }
 	Pretty Style = iota
 	// The Compact style prints the shortest legal string.
-	// Example: {query query($var:type=10)@directive(arg:"stringVal"){alias name}}
+	// Example: {query query($var:type=10)@directive(arg:"stringVal"){alias:name}}
 	Compact
 )
 
@@ -90,8 +92,8 @@ func (p *printer) node(node ast.Node) bool {
 		return p.definition(t)
 	case *ast.VarDef:
 		return p.varDef(t)
-	case *ast.Variable:
-		return p.variable(t)
+	case ast.Value:
+		return p.value(t)
 	case *ast.SelectionSet:
 		return p.selectionSet(t)
 	case *ast.Argument:
@@ -102,6 +104,8 @@ func (p *printer) node(node ast.Node) bool {
 		return p.objectField(t)
 	case *ast.Directive:
 		return p.directive(t)
+	case *ast.OpTypeDef:
+		return p.opTypeDef(t)
 	case ast.RefType:
 		return p.refType(t)
 	default:
@@ -147,8 +151,12 @@ func (p *printer) definition(d ast.Definition) bool {
 		return p.opDef(t)
 	case *ast.FragmentDef:
 		return p.fragmentDef(t)
+	case *ast.SchemaDef:
+		return p.schemaDef(t)
 	case ast.TypeDef:
 		return p.typeDef(t)
+	case *ast.DirectiveDef:
+		return p.directiveDef(t)
 	default:
 		p.err = fmt.Errorf("Unable to print unrecognized Definition type: %T", d)
 		return false
@@ -200,14 +208,14 @@ func (p *printer) varDefs(vds []ast.VarDef) bool {
 	return true
 }
 
-// Variable:Type[DefaultValue]
+// Variable:Type[DefaultValue][Directives]
 func (p *printer) varDef(vd *ast.VarDef) bool {
 	b := p.variable(&vd.Variable) && p.print(":") && p.refType(vd.RefType)
 
 	if vd.DefaultValue != nil {
 		b = b && p.defaultValue(vd.DefaultValue)
 	}
-	return b
+	return b && p.directives(vd.Directives)
 }
 
 // =Value
@@ -255,12 +263,12 @@ func (p *printer) selection(s ast.Selection) bool {
 	}
 }
 
-// [Alias ]Name[Arguments][Directives][SelectionSet]
+// [Alias:]Name[Arguments][Directives][SelectionSet]
 func (p *printer) field(f *ast.Field) bool {
 	b := true
 
 	if f.Alias.Value != "" {
-		b = b && p.name(&f.Alias) && p.print(" ")
+		b = b && p.name(&f.Alias) && p.print(":")
 	}
 
 	b = b && p.name(&f.Name) && p.arguments(f.Arguments) && p.directives(f.Directives)
@@ -307,12 +315,12 @@ func (p *printer) fragmentSpread(f *ast.FragmentSpread) bool {
 	return b
 }
 
-// ...[NamedType][Directives]SelectionSet
+// ...[on NamedType][Directives]SelectionSet
 func (p *printer) inlineFragment(i *ast.InlineFragment) bool {
 	b := p.print("...")
 
 	if i.NamedType.Value != "" {
-		b = b && p.namedType(&i.NamedType)
+		b = b && p.print("on ") && p.namedType(&i.NamedType)
 	}
 
 	if len(i.Directives) > 0 {
@@ -335,12 +343,14 @@ func (p *printer) fragmentDef(f *ast.FragmentDef) bool {
 
 func (p *printer) value(v ast.Value) bool {
 	switch t := v.(type) {
+	case *ast.Variable:
+		return p.variable(t)
 	case *ast.Int:
 		return p.print(t.Value)
 	case *ast.Float:
 		return p.print(t.Value)
 	case *ast.String:
-		return p.printf(`"%s"`, t.Value)
+		return p.print(quote(t.Value))
 	case *ast.Boolean:
 		return p.print(strconv.FormatBool(t.Value))
 	case *ast.Enum:
@@ -355,6 +365,38 @@ func (p *printer) value(v ast.Value) bool {
 	}
 }
 
+// The quote function returns s as a quoted GraphQL string, with '"', '\' and control characters escaped.
+func quote(s string) string {
+	var b bytes.Buffer
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < ' ' {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 // [Value+]
 func (p *printer) list(l *ast.List) bool {
 	if !p.print("[") {
@@ -436,6 +478,33 @@ func (p *printer) nonNullType(d *ast.NonNullType) bool {
 	return p.refType(d.RefType) && p.print("!")
 }
 
+// schema[Directives]{OpTypeDef+}
+func (p *printer) schemaDef(s *ast.SchemaDef) bool {
+	b := p.print("schema")
+
+	if len(s.Directives) > 0 {
+		b = b && p.directives(s.Directives)
+	}
+
+	if !(b && p.beginBlock("{")) {
+		return false
+	}
+	for i, _ := range s.OpTypeDefs {
+		if !(p.newLine() && p.opTypeDef(&s.OpTypeDefs[i])) {
+			return false
+		}
+		if i < len(s.OpTypeDefs)-1 && !p.print(",") {
+			return false
+		}
+	}
+	return p.endBlock("}")
+}
+
+// OperationType:NamedType
+func (p *printer) opTypeDef(o *ast.OpTypeDef) bool {
+	return p.opType(&o.OpType) && p.print(":") && p.namedType(&o.NamedType)
+}
+
 func (p *printer) typeDef(td ast.TypeDef) bool {
 	switch t := td.(type) {
 	case *ast.ObjTypeDef:
@@ -458,9 +527,46 @@ func (p *printer) typeDef(td ast.TypeDef) bool {
 	}
 }
 
-// type Name[ImplementsInterfaces][{FieldDef+}]
+// [Description]
+//
+// Multi-line descriptions are printed as block strings in the Pretty style.
+func (p *printer) description(d *ast.String) bool {
+	if d == nil {
+		return true
+	}
+	if p.Style == Pretty && isBlockString(d.Value) {
+		if !p.print(`"""`) {
+			return false
+		}
+		for _, line := range strings.Split(d.Value, "\n") {
+			if !(p.newLine() && p.print(strings.Replace(line, `"""`, `\"""`, -1))) {
+				return false
+			}
+		}
+		return p.newLine() && p.print(`"""`) && p.newLine()
+	}
+	if !p.print(quote(d.Value)) {
+		return false
+	}
+	if p.Style == Pretty {
+		return p.newLine()
+	}
+	return p.print(" ")
+}
+
+// The isBlockString function returns true if s spans multiple lines, and would be preserved when printed as a block
+// string.
+func isBlockString(s string) bool {
+	if !strings.Contains(s, "\n") || strings.ContainsAny(s, "\r") {
+		return false
+	}
+	lines := strings.Split(s, "\n")
+	return strings.TrimLeft(lines[0], " \t") != "" && strings.TrimLeft(lines[len(lines)-1], " \t") != ""
+}
+
+// [Description]type Name[ImplementsInterfaces][Directives][{FieldDef+}]
 func (p *printer) objTypeDef(o *ast.ObjTypeDef) bool {
-	b := p.print("type ")
+	b := p.description(o.Description) && p.print("type ")
 
 	b = b && p.name(&o.Name)
 
@@ -468,6 +574,8 @@ func (p *printer) objTypeDef(o *ast.ObjTypeDef) bool {
 		b = b && p.implementsInterfaces(o.Interfaces)
 	}
 
+	b = b && p.directives(o.Directives)
+
 	if len(o.FieldDefs) > 0 {
 		b = b && p.fieldDefs(o.FieldDefs)
 	}
@@ -506,9 +614,27 @@ func (p *printer) fieldDefs(fds []ast.FieldDef) bool {
 	return p.endBlock("}")
 }
 
-// Name ArgumentsDef? : Type
+// [Description]Name[(ArgumentDefs)]:Type[Directives]
 func (p *printer) fieldDef(fd *ast.FieldDef) bool {
-	return p.name(&fd.Name) && p.inputValueDefs(fd.Arguments) && p.print(":") && p.refType(fd.RefType)
+	b := p.description(fd.Description) && p.name(&fd.Name)
+
+	if len(fd.Arguments) > 0 {
+		b = b && p.beginBlock("(") && p.argumentDefs(fd.Arguments) && p.endBlock(")")
+	}
+	return b && p.print(":") && p.refType(fd.RefType) && p.directives(fd.Directives)
+}
+
+// InputValueDef+
+func (p *printer) argumentDefs(as []ast.InputValueDef) bool {
+	for i, _ := range as {
+		if !(p.newLine() && p.inputValueDef(&as[i])) {
+			return false
+		}
+		if i < len(as)-1 && !p.print(",") {
+			return false
+		}
+	}
+	return true
 }
 
 // {InputValueDef+}
@@ -530,24 +656,26 @@ func (p *printer) inputValueDefs(is []ast.InputValueDef) bool {
 	return p.endBlock("}")
 }
 
-// Name:Type[DefaultValue]
+// [Description]Name:Type[DefaultValue][Directives]
 func (p *printer) inputValueDef(i *ast.InputValueDef) bool {
-	b := p.name(&i.Name) && p.print(":") && p.refType(i.RefType)
+	b := p.description(i.Description) && p.name(&i.Name) && p.print(":") && p.refType(i.RefType)
 
 	if i.DefaultValue != nil {
 		b = b && p.defaultValue(i.DefaultValue)
 	}
-	return b
+	return b && p.directives(i.Directives)
 }
 
-// interface Name FieldDefs
+// [Description]interface Name[Directives]FieldDefs
 func (p *printer) interfaceTypeDef(i *ast.InterfaceTypeDef) bool {
-	return p.print("interface ") && p.name(&i.Name) && p.fieldDefs(i.FieldDefs)
+	return p.description(i.Description) && p.print("interface ") && p.name(&i.Name) && p.directives(i.Directives) &&
+		p.fieldDefs(i.FieldDefs)
 }
 
-// union Name=UnionMembers
+// [Description]union Name[Directives]=UnionMembers
 func (p *printer) unionTypeDef(u *ast.UnionTypeDef) bool {
-	return p.print("union ") && p.name(&u.Name) && p.print("=") && p.unionMembers(u.NamedTypes)
+	return p.description(u.Description) && p.print("union ") && p.name(&u.Name) && p.directives(u.Directives) &&
+		p.print("=") && p.unionMembers(u.NamedTypes)
 }
 
 // UnionMember[|UnionMember...]
@@ -563,43 +691,87 @@ func (p *printer) unionMembers(ums []ast.NamedType) bool {
 	return true
 }
 
-// scalar Name
+// [Description]scalar Name[Directives]
 func (p *printer) scalarTypeDef(s *ast.ScalarTypeDef) bool {
-	return p.print("scalar ") && p.name(&s.Name)
+	return p.description(s.Description) && p.print("scalar ") && p.name(&s.Name) && p.directives(s.Directives)
 }
 
-// enum Name {EnumValueDef+}
+// [Description]enum Name[Directives]{EnumValueDef+}
 func (p *printer) enumTypeDef(e *ast.EnumTypeDef) bool {
-	return p.print("enum ") && p.name(&e.Name) && p.enumValueDefs(e.EnumValueDefs)
+	return p.description(e.Description) && p.print("enum ") && p.name(&e.Name) && p.directives(e.Directives) &&
+		p.enumValueDefs(e.EnumValueDefs)
 }
 
 // {EnumValueDef+}
+//
+// The values are printed on a single line, unless any has a description or directives.
 func (p *printer) enumValueDefs(es []ast.EnumValueDef) bool {
-	if !p.print("{") {
+	block := false
+	for i := range es {
+		if es[i].Description != nil || len(es[i].Directives) > 0 {
+			block = true
+		}
+	}
+	if !block {
+		if !p.print("{") {
+			return false
+		}
+		for i, _ := range es {
+			if !p.enumValueDef(&es[i]) {
+				return false
+			}
+			if i < len(es)-1 && !p.print(",") {
+				return false
+			}
+		}
+		return p.print("}")
+	}
+	if !p.beginBlock("{") {
 		return false
 	}
 	for i, _ := range es {
-		if !p.enumValueDef(&es[i]) {
+		if !(p.newLine() && p.enumValueDef(&es[i])) {
 			return false
 		}
 		if i < len(es)-1 && !p.print(",") {
 			return false
 		}
 	}
-	return p.print("}")
+	return p.endBlock("}")
 }
 
-// Name
+// [Description]Name[Directives]
 func (p *printer) enumValueDef(e *ast.EnumValueDef) bool {
-	return p.name((*ast.Name)(e))
+	return p.description(e.Description) && p.name(&e.Name) && p.directives(e.Directives)
 }
 
-// input Name{InputValueDefinition+}
+// [Description]input Name[Directives]{InputValueDefinition+}
 func (p *printer) inputObjTypeDef(d *ast.InputObjTypeDef) bool {
-	return p.print("input ") && p.name(&d.Name) && p.inputValueDefs(d.Fields)
+	return p.description(d.Description) && p.print("input ") && p.name(&d.Name) && p.directives(d.Directives) &&
+		p.inputValueDefs(d.Fields)
 }
 
 // extend ObjTypeDef
 func (p *printer) typeExtDef(d *ast.TypeExtDef) bool {
 	return p.print("extend ") && p.objTypeDef((*ast.ObjTypeDef)(d))
 }
+
+// [Description]directive @Name[(ArgumentDefs)][repeatable] on DirectiveLocation[|DirectiveLocation...]
+func (p *printer) directiveDef(d *ast.DirectiveDef) bool {
+	b := p.description(d.Description) && p.print("directive @") && p.name(&d.Name)
+
+	if len(d.Arguments) > 0 {
+		b = b && p.beginBlock("(") && p.argumentDefs(d.Arguments) && p.endBlock(")")
+	}
+	if d.Repeatable {
+		b = b && p.print(" repeatable")
+	}
+	b = b && p.print(" on ")
+	for i := range d.Locations {
+		if i > 0 {
+			b = b && p.print("|")
+		}
+		b = b && p.name(&d.Locations[i])
+	}
+	return b
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	. "github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
 )
 
 var document = Document{
@@ -67,7 +68,14 @@ var document = Document{
 			},
 			FieldDefs: []FieldDef{
 				{
-					Name:    Name{Value: "field"},
+					Name: Name{Value: "field"},
+					Arguments: []InputValueDef{
+						{
+							Name:         Name{Value: "arg"},
+							RefType:      &NamedType{Value: "type"},
+							DefaultValue: &Int{Value: "1"},
+						},
+					},
 					RefType: &NamedType{Value: "type"},
 				},
 			},
@@ -92,8 +100,8 @@ var document = Document{
 		&EnumTypeDef{
 			Name: Name{Value: "enum"},
 			EnumValueDefs: []EnumValueDef{
-				{Value: "enumA"},
-				{Value: "enumB"},
+				{Name: Name{Value: "enumA"}},
+				{Name: Name{Value: "enumB"}},
 			},
 		},
 		&InputObjTypeDef{
@@ -142,3 +150,127 @@ func TestPrettyPrint(t *testing.T) {
 }
 
 //TODO comprehensive tests
+
+func TestPrintDescriptions(t *testing.T) {
+	source := `"""
+The type.
+  Indented.
+"""
+type T @a {
+	"A \"quoted\" field."
+	f("The arg." x: Int = 1 @b): Int @deprecated(reason: "r")
+}
+enum E {"The first." A @deprecated, B}
+enum F {A, B}`
+	d, err := parser.ParseString(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		style    Style
+		expected string
+	}{
+		{Compact, `"The type.\n  Indented." type T@a{"A \"quoted\" field." f("The arg." x:Int=1@b):Int@deprecated(reason:"r")}` +
+			"\n" + `enum E{"The first." A@deprecated,B}` +
+			"\n" + `enum F{A,B}`},
+		{Pretty, `"""
+The type.
+  Indented.
+"""
+type T
+@a{
+	"A \"quoted\" field."
+	f(
+		"The arg."
+		x:Int=1
+		@b
+	):Int
+	@deprecated(
+		reason:"r"
+	)
+}
+enum E{
+	"The first."
+	A
+	@deprecated,
+	B
+}
+enum F{A,B}`},
+	} {
+		var b bytes.Buffer
+		for i, def := range d.Definitions {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			if err := test.style.Fprint(&b, def); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if b.String() != test.expected {
+			t.Errorf("expected:\n%s\nbut got:\n%s", test.expected, b.String())
+		}
+
+		// The output must parse to an equivalent document.
+		reparsed, err := parser.ParseString(b.String())
+		if err != nil {
+			t.Fatalf("failed to parse output: %s", err)
+		}
+		var again bytes.Buffer
+		for i, def := range reparsed.Definitions {
+			if i > 0 {
+				again.WriteString("\n")
+			}
+			if err := test.style.Fprint(&again, def); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if again.String() != b.String() {
+			t.Errorf("expected reprinted output:\n%s\nbut got:\n%s", b.String(), again.String())
+		}
+	}
+}
+
+func TestPrintDirectives(t *testing.T) {
+	source := `query ($a: Int = 1 @x, $b: Int @y(z: 2)) {a}
+"The cost." directive @cost(weight: Int = 1) repeatable on FIELD_DEFINITION | OBJECT
+directive @x on VARIABLE_DEFINITION`
+	d, err := parser.ParseString(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	for i, def := range d.Definitions {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if err := Compact.Fprint(&b, def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := `query($a:Int=1@x,$b:Int@y(z:2)){a}
+"The cost." directive @cost(weight:Int=1) repeatable on FIELD_DEFINITION|OBJECT
+directive @x on VARIABLE_DEFINITION`
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, b.String())
+	}
+	if _, err := parser.ParseString(b.String()); err != nil {
+		t.Errorf("failed to parse output: %s", err)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, test := range []struct {
+		input, expected string
+	}{
+		{"", `""`},
+		{"plain", `"plain"`},
+		{`a "b" \c`, `"a \"b\" \\c"`},
+		{"\b\f\n\r\t", `"\b\f\n\r\t"`},
+		{"\x00\x1f", `"\u0000\u001F"`},
+		{"á", `"á"`},
+	} {
+		if actual := quote(test.input); actual != test.expected {
+			t.Errorf("%q: expected %s but got %s", test.input, test.expected, actual)
+		}
+	}
+}
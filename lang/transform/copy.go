@@ -0,0 +1,257 @@
+package transform
+
+import (
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The copyDefinition function returns a deep copy of d.
+func copyDefinition(d ast.Definition) ast.Definition {
+	switch t := d.(type) {
+	case *ast.OpDef:
+		c := *t
+		c.VarDefs = copyVarDefs(t.VarDefs)
+		c.Directives = copyDirectives(t.Directives)
+		c.SelectionSet = copySelectionSet(t.SelectionSet)
+		return &c
+	case *ast.FragmentDef:
+		c := *t
+		c.Directives = copyDirectives(t.Directives)
+		c.SelectionSet = copySelectionSet(t.SelectionSet)
+		return &c
+	case *ast.SchemaDef:
+		c := *t
+		c.Directives = copyDirectives(t.Directives)
+		c.OpTypeDefs = append([]ast.OpTypeDef(nil), t.OpTypeDefs...)
+		return &c
+	case *ast.ObjTypeDef:
+		return copyObjTypeDef(t)
+	case *ast.InterfaceTypeDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Directives = copyDirectives(t.Directives)
+		c.FieldDefs = copyFieldDefs(t.FieldDefs)
+		return &c
+	case *ast.UnionTypeDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Directives = copyDirectives(t.Directives)
+		c.NamedTypes = append([]ast.NamedType(nil), t.NamedTypes...)
+		return &c
+	case *ast.ScalarTypeDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Directives = copyDirectives(t.Directives)
+		return &c
+	case *ast.EnumTypeDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Directives = copyDirectives(t.Directives)
+		c.EnumValueDefs = copyEnumValueDefs(t.EnumValueDefs)
+		return &c
+	case *ast.InputObjTypeDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Directives = copyDirectives(t.Directives)
+		c.Fields = copyInputValueDefs(t.Fields)
+		return &c
+	case *ast.TypeExtDef:
+		return (*ast.TypeExtDef)(copyObjTypeDef((*ast.ObjTypeDef)(t)))
+	case *ast.DirectiveDef:
+		c := *t
+		c.Description = copyDescription(t.Description)
+		c.Arguments = copyInputValueDefs(t.Arguments)
+		c.Locations = append([]ast.Name(nil), t.Locations...)
+		return &c
+	default:
+		return d
+	}
+}
+
+func copyObjTypeDef(o *ast.ObjTypeDef) *ast.ObjTypeDef {
+	c := *o
+	c.Description = copyDescription(o.Description)
+	c.Interfaces = append([]ast.NamedType(nil), o.Interfaces...)
+	c.Directives = copyDirectives(o.Directives)
+	c.FieldDefs = copyFieldDefs(o.FieldDefs)
+	return &c
+}
+
+func copyDescription(s *ast.String) *ast.String {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func copyFieldDefs(fds []ast.FieldDef) []ast.FieldDef {
+	if fds == nil {
+		return nil
+	}
+	c := make([]ast.FieldDef, len(fds))
+	for i, fd := range fds {
+		c[i] = fd
+		c[i].Description = copyDescription(fd.Description)
+		c[i].Arguments = copyInputValueDefs(fd.Arguments)
+		c[i].RefType = copyRefType(fd.RefType)
+		c[i].Directives = copyDirectives(fd.Directives)
+	}
+	return c
+}
+
+func copyInputValueDefs(ivds []ast.InputValueDef) []ast.InputValueDef {
+	if ivds == nil {
+		return nil
+	}
+	c := make([]ast.InputValueDef, len(ivds))
+	for i, ivd := range ivds {
+		c[i] = ivd
+		c[i].Description = copyDescription(ivd.Description)
+		c[i].RefType = copyRefType(ivd.RefType)
+		c[i].DefaultValue = copyValue(ivd.DefaultValue)
+		c[i].Directives = copyDirectives(ivd.Directives)
+	}
+	return c
+}
+
+func copyEnumValueDefs(evds []ast.EnumValueDef) []ast.EnumValueDef {
+	if evds == nil {
+		return nil
+	}
+	c := make([]ast.EnumValueDef, len(evds))
+	for i, evd := range evds {
+		c[i] = evd
+		c[i].Description = copyDescription(evd.Description)
+		c[i].Directives = copyDirectives(evd.Directives)
+	}
+	return c
+}
+
+func copyVarDefs(vds []ast.VarDef) []ast.VarDef {
+	if vds == nil {
+		return nil
+	}
+	c := make([]ast.VarDef, len(vds))
+	for i, vd := range vds {
+		c[i] = vd
+		c[i].RefType = copyRefType(vd.RefType)
+		c[i].DefaultValue = copyValue(vd.DefaultValue)
+		c[i].Directives = copyDirectives(vd.Directives)
+	}
+	return c
+}
+
+func copyRefType(rt ast.RefType) ast.RefType {
+	switch t := rt.(type) {
+	case *ast.NamedType:
+		c := *t
+		return &c
+	case *ast.ListType:
+		return &ast.ListType{Loc: t.Loc, RefType: copyRefType(t.RefType)}
+	case *ast.NonNullType:
+		return &ast.NonNullType{Loc: t.Loc, RefType: copyRefType(t.RefType)}
+	default:
+		return rt
+	}
+}
+
+func copySelectionSet(ss ast.SelectionSet) ast.SelectionSet {
+	c := ast.SelectionSet{Loc: ss.Loc}
+	if ss.Selections != nil {
+		c.Selections = make([]ast.Selection, len(ss.Selections))
+		for i, s := range ss.Selections {
+			c.Selections[i] = copySelection(s)
+		}
+	}
+	return c
+}
+
+func copySelection(s ast.Selection) ast.Selection {
+	switch t := s.(type) {
+	case *ast.Field:
+		c := *t
+		c.Arguments = copyArguments(t.Arguments)
+		c.Directives = copyDirectives(t.Directives)
+		c.SelectionSet = copySelectionSet(t.SelectionSet)
+		return &c
+	case *ast.FragmentSpread:
+		c := *t
+		c.Directives = copyDirectives(t.Directives)
+		return &c
+	case *ast.InlineFragment:
+		c := *t
+		c.Directives = copyDirectives(t.Directives)
+		c.SelectionSet = copySelectionSet(t.SelectionSet)
+		return &c
+	default:
+		return s
+	}
+}
+
+func copyArguments(as []ast.Argument) []ast.Argument {
+	if as == nil {
+		return nil
+	}
+	c := make([]ast.Argument, len(as))
+	for i, a := range as {
+		c[i] = a
+		c[i].Value = copyValue(a.Value)
+	}
+	return c
+}
+
+func copyDirectives(ds []ast.Directive) []ast.Directive {
+	if ds == nil {
+		return nil
+	}
+	c := make([]ast.Directive, len(ds))
+	for i, d := range ds {
+		c[i] = d
+		c[i].Arguments = copyArguments(d.Arguments)
+	}
+	return c
+}
+
+func copyValue(v ast.Value) ast.Value {
+	switch t := v.(type) {
+	case *ast.Variable:
+		c := *t
+		return &c
+	case *ast.Int:
+		c := *t
+		return &c
+	case *ast.Float:
+		c := *t
+		return &c
+	case *ast.String:
+		c := *t
+		return &c
+	case *ast.Boolean:
+		c := *t
+		return &c
+	case *ast.Enum:
+		c := *t
+		return &c
+	case *ast.List:
+		c := &ast.List{Loc: t.Loc}
+		if t.Values != nil {
+			c.Values = make([]ast.Value, len(t.Values))
+			for i, v := range t.Values {
+				c.Values[i] = copyValue(v)
+			}
+		}
+		return c
+	case *ast.Object:
+		c := &ast.Object{Loc: t.Loc}
+		if t.Fields != nil {
+			c.Fields = make([]ast.ObjectField, len(t.Fields))
+			for i, f := range t.Fields {
+				c.Fields[i] = f
+				c.Fields[i].Value = copyValue(f.Value)
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
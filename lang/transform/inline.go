@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The InlineFragments function returns a copy of the operation named opName in d with every FragmentSpread replaced
+// by an equivalent InlineFragment. The returned Document contains no FragmentDefs. If opName is empty, d must contain
+// exactly one operation.
+//
+// The directives of a spread are kept on its InlineFragment. The directives of a FragmentDef are dropped, since they
+// are not valid on inline fragments.
+//
+// If flatten is true, inline fragments without directives are merged into their enclosing selection set when their
+// type condition is absent or matches the type condition of the enclosing fragment. Without a schema the types of
+// fields are unknown, so fragments with a type condition directly within a field's selection set are never merged,
+// e.g. {user{...F}} fragment F on User{id} keeps its ...on User.
+//
+// An error is returned if a spread refers to an unknown fragment, or if fragments spread each other cyclically.
+func InlineFragments(d *ast.Document, opName string, flatten bool) (*ast.Document, error) {
+	op, err := operation(d, opName)
+	if err != nil {
+		return nil, err
+	}
+	i := inliner{fragments: fragments(d), flatten: flatten}
+
+	o := copyDefinition(op).(*ast.OpDef)
+	if err := i.selectionSet(&o.SelectionSet, ""); err != nil {
+		return nil, err
+	}
+	return &ast.Document{Loc: d.Loc, Source: d.Source, Definitions: []ast.Definition{o}}, nil
+}
+
+// An inliner holds the state for inlining the fragments of a single operation.
+type inliner struct {
+	fragments map[string]*ast.FragmentDef
+	flatten   bool
+
+	// Names of the fragments currently being inlined, outermost first.
+	path []string
+}
+
+// The selectionSet method inlines the fragment spreads of ss in place. parentType is the type condition of the
+// enclosing fragment, or empty if unknown.
+func (i *inliner) selectionSet(ss *ast.SelectionSet, parentType string) error {
+	selections := ss.Selections[:0:0]
+	for _, s := range ss.Selections {
+		switch t := s.(type) {
+		case *ast.Field:
+			// The type of the field is unknown.
+			if err := i.selectionSet(&t.SelectionSet, ""); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			inline, err := i.fragmentSpread(t)
+			if err != nil {
+				return err
+			}
+			s = inline
+		case *ast.InlineFragment:
+			typ := t.NamedType.Value
+			if typ == "" {
+				typ = parentType
+			}
+			if err := i.selectionSet(&t.SelectionSet, typ); err != nil {
+				return err
+			}
+		}
+		if inline, ok := s.(*ast.InlineFragment); ok && i.flattens(inline, parentType) {
+			selections = append(selections, inline.Selections...)
+			continue
+		}
+		selections = append(selections, s)
+	}
+	ss.Selections = selections
+	return nil
+}
+
+// The fragmentSpread method returns an InlineFragment equivalent to the spread fs.
+func (i *inliner) fragmentSpread(fs *ast.FragmentSpread) (*ast.InlineFragment, error) {
+	name := fs.Name.Value
+	for j, n := range i.path {
+		if n == name {
+			cycle := append(append([]string(nil), i.path[j:]...), name)
+			return nil, fmt.Errorf("cannot spread fragment %q within itself via %s", name, strings.Join(cycle, ", "))
+		}
+	}
+	f, ok := i.fragments[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fragment %q", name)
+	}
+
+	inline := &ast.InlineFragment{
+		Loc:          fs.Loc,
+		NamedType:    f.TypeCondition,
+		Directives:   copyDirectives(fs.Directives),
+		SelectionSet: copySelectionSet(f.SelectionSet),
+	}
+
+	i.path = append(i.path, name)
+	err := i.selectionSet(&inline.SelectionSet, f.TypeCondition.Value)
+	i.path = i.path[:len(i.path)-1]
+	if err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+// The flattens method returns true if the inline fragment may be merged into a selection set of type parentType.
+func (i *inliner) flattens(inline *ast.InlineFragment, parentType string) bool {
+	if !i.flatten || len(inline.Directives) > 0 {
+		return false
+	}
+	return inline.NamedType.Value == "" || inline.NamedType.Value == parentType
+}
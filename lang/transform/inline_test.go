@@ -0,0 +1,108 @@
+package transform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+func TestInlineFragments(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		opName   string
+		flatten  bool
+		expected string
+	}{
+		{
+			`{user {...userFields}} fragment userFields on User {id name}`,
+			"",
+			false,
+			`{{user{...on User{id,name}}}}`,
+		},
+		{
+			`{user {...a @include(if:true)}} fragment a on User {id ...b} fragment b on User {name} fragment unused on User {id}`,
+			"",
+			false,
+			`{{user{...on User@include(if:true){id,...on User{name}}}}}`,
+		},
+		{
+			`{user {...a @include(if:true)}} fragment a on User {id ...b ...{c} ...on Node{d}} fragment b on User {name}`,
+			"",
+			true,
+			`{{user{...on User@include(if:true){id,name,c,...on Node{d}}}}}`,
+		},
+		{
+			`{user {...a}} fragment a on User {id ...{name}}`,
+			"",
+			true,
+			`{{user{...on User{id,name}}}}`,
+		},
+		{
+			`{user {...a @skip(if:false)}} fragment a on User @d {id}`,
+			"",
+			false,
+			`{{user{...on User@skip(if:false){id}}}}`,
+		},
+		{
+			`query a {...b} query c {d} fragment b on Query {e}`,
+			"a",
+			false,
+			`{query a{...on Query{e}}}`,
+		},
+	} {
+		d, err := parser.ParseString(testCase.input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", testCase.input, err)
+		}
+		inlined, err := InlineFragments(d, testCase.opName, testCase.flatten)
+		if err != nil {
+			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
+			continue
+		}
+		var b bytes.Buffer
+		if err := printer.Compact.Fprint(&b, inlined); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != testCase.expected {
+			t.Errorf("input %q; expected:\n%s\nbut got:\n%s", testCase.input, testCase.expected, b.String())
+		}
+	}
+}
+
+func TestInlineFragmentsDoesNotModifyInput(t *testing.T) {
+	d, err := parser.ParseString(`{user {...a @include(if:true) ...a @skip(if:false)}} fragment a on User @d {id}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var before, after bytes.Buffer
+	if err := printer.Compact.Fprint(&before, d); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InlineFragments(d, "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := printer.Compact.Fprint(&after, d); err != nil {
+		t.Fatal(err)
+	}
+	if before.String() != after.String() {
+		t.Errorf("expected %q but got %q", before.String(), after.String())
+	}
+}
+
+func TestInlineFragmentsErrors(t *testing.T) {
+	for _, input := range []string{
+		`{...a} fragment a on T {...b} fragment b on T {...a}`,
+		`{...a} fragment a on T {b {...a}}`,
+		`{...unknown}`,
+	} {
+		d, err := parser.ParseString(input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", input, err)
+		}
+		if _, err := InlineFragments(d, "", false); err == nil {
+			t.Errorf("input %q; expected error", input)
+		}
+	}
+}
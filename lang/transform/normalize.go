@@ -0,0 +1,202 @@
+package transform
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+// The Normalize function returns a normalized copy of the operation named opName in d, suitable for grouping
+// operations by shape rather than literal text. If opName is empty, d must contain exactly one operation.
+//
+// The normalized Document contains only the selected operation followed by the fragments it uses, sorted by name.
+// Literal argument values and variable default values are replaced with placeholders: numbers with 0, strings with
+// "", lists with [] and objects with {}. Booleans, enums and variables are retained. Selections, arguments, directives
+// and variable definitions are sorted deterministically, so that the order in which they were written does not
+// matter.
+func Normalize(d *ast.Document, opName string) (*ast.Document, error) {
+	n, err := selectOperation(d, opName)
+	if err != nil {
+		return nil, err
+	}
+
+	op := n.Definitions[0].(*ast.OpDef)
+	normalizeVarDefs(op.VarDefs)
+	normalizeDirectives(op.Directives)
+	normalizeSelectionSet(&op.SelectionSet)
+
+	frags := n.Definitions[1:]
+	for _, def := range frags {
+		f := def.(*ast.FragmentDef)
+		normalizeDirectives(f.Directives)
+		normalizeSelectionSet(&f.SelectionSet)
+	}
+	sort.Slice(frags, func(i, j int) bool {
+		return frags[i].(*ast.FragmentDef).Name.Value < frags[j].(*ast.FragmentDef).Name.Value
+	})
+
+	return n, nil
+}
+
+// A Signature identifies the shape of an operation.
+type Signature struct {
+	// The normalized operation, printed with the printer.Compact style.
+	Text string
+	// The SHA-256 hash of Text.
+	Hash [sha256.Size]byte
+}
+
+// The HexHash method returns the hex encoded Hash.
+func (s *Signature) HexHash() string {
+	return hex.EncodeToString(s.Hash[:])
+}
+
+// The OperationSignature function returns the Signature of the operation named opName in d.
+// See Normalize for details.
+func OperationSignature(d *ast.Document, opName string) (*Signature, error) {
+	n, err := Normalize(d, opName)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := printer.Compact.Fprint(&b, n); err != nil {
+		return nil, err
+	}
+	return &Signature{Text: b.String(), Hash: sha256.Sum256(b.Bytes())}, nil
+}
+
+func normalizeSelectionSet(ss *ast.SelectionSet) {
+	for _, s := range ss.Selections {
+		switch t := s.(type) {
+		case *ast.Field:
+			normalizeArguments(t.Arguments)
+			normalizeDirectives(t.Directives)
+			normalizeSelectionSet(&t.SelectionSet)
+		case *ast.FragmentSpread:
+			normalizeDirectives(t.Directives)
+		case *ast.InlineFragment:
+			normalizeDirectives(t.Directives)
+			normalizeSelectionSet(&t.SelectionSet)
+		}
+	}
+	sels := make([]printedSelection, len(ss.Selections))
+	for i, s := range ss.Selections {
+		sels[i] = printedSelection{s, printed(s)}
+	}
+	sort.SliceStable(sels, func(i, j int) bool {
+		return selectionLess(sels[i], sels[j])
+	})
+	for i := range sels {
+		ss.Selections[i] = sels[i].Selection
+	}
+}
+
+// A printedSelection is a normalized selection, and its compact printed form.
+type printedSelection struct {
+	ast.Selection
+	printed string
+}
+
+// The printed function returns n printed with the printer.Compact style.
+func printed(n ast.Node) string {
+	var b bytes.Buffer
+	if err := printer.Compact.Fprint(&b, n); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// The selectionRank function orders selections by type: fields, then fragment spreads, then inline fragments.
+func selectionRank(s ast.Selection) int {
+	switch s.(type) {
+	case *ast.Field:
+		return 0
+	case *ast.FragmentSpread:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// The selectionLess function orders selections by rank, then by name, alias, or type condition, and finally by their
+// printed forms, which include their arguments, directives and selection sets.
+func selectionLess(a, b printedSelection) bool {
+	if ra, rb := selectionRank(a.Selection), selectionRank(b.Selection); ra != rb {
+		return ra < rb
+	}
+	switch t := a.Selection.(type) {
+	case *ast.Field:
+		u := b.Selection.(*ast.Field)
+		if t.Name.Value != u.Name.Value {
+			return t.Name.Value < u.Name.Value
+		}
+		if t.Alias.Value != u.Alias.Value {
+			return t.Alias.Value < u.Alias.Value
+		}
+	case *ast.FragmentSpread:
+		if u := b.Selection.(*ast.FragmentSpread); t.Name.Value != u.Name.Value {
+			return t.Name.Value < u.Name.Value
+		}
+	case *ast.InlineFragment:
+		if u := b.Selection.(*ast.InlineFragment); t.NamedType.Value != u.NamedType.Value {
+			return t.NamedType.Value < u.NamedType.Value
+		}
+	}
+	return a.printed < b.printed
+}
+
+func normalizeArguments(as []ast.Argument) {
+	for i := range as {
+		as[i].Value = placeholder(as[i].Value)
+	}
+	sort.SliceStable(as, func(i, j int) bool {
+		return as[i].Name.Value < as[j].Name.Value
+	})
+}
+
+func normalizeDirectives(ds []ast.Directive) {
+	for i := range ds {
+		normalizeArguments(ds[i].Arguments)
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].Name.Value != ds[j].Name.Value {
+			return ds[i].Name.Value < ds[j].Name.Value
+		}
+		// Repeatable directives are ordered by their arguments.
+		return printed(&ds[i]) < printed(&ds[j])
+	})
+}
+
+func normalizeVarDefs(vds []ast.VarDef) {
+	for i := range vds {
+		if vds[i].DefaultValue != nil {
+			vds[i].DefaultValue = placeholder(vds[i].DefaultValue)
+		}
+		normalizeDirectives(vds[i].Directives)
+	}
+	sort.SliceStable(vds, func(i, j int) bool {
+		return vds[i].Variable.Name.Value < vds[j].Variable.Name.Value
+	})
+}
+
+// The placeholder function returns the placeholder value for the literal v.
+func placeholder(v ast.Value) ast.Value {
+	switch t := v.(type) {
+	case *ast.Int:
+		return &ast.Int{Loc: t.Loc, Value: "0"}
+	case *ast.Float:
+		return &ast.Float{Loc: t.Loc, Value: "0"}
+	case *ast.String:
+		return &ast.String{Loc: t.Loc}
+	case *ast.List:
+		return &ast.List{Loc: t.Loc}
+	case *ast.Object:
+		return &ast.Object{Loc: t.Loc}
+	default:
+		return v
+	}
+}
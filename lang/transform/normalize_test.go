@@ -0,0 +1,111 @@
+package transform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+func TestOperationSignature(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		opName   string
+		expected string
+	}{
+		{
+			`{user(id:4){name}}`,
+			"",
+			`{{user(id:0){name}}}`,
+		},
+		{
+			`query b {b} query a ($z:Int, $y:String="default" @y(s:"str") @x) @dir(s:"str",b:true) {c(n:1.5,l:[1,2],o:{a:1},e:ENUM,v:$z) a}`,
+			"a",
+			`{query a($y:String=""@x@y(s:""),$z:Int)@dir(b:true,s:""){a,c(e:ENUM,l:[],n:0,o:{},v:$z)}}`,
+		},
+		{
+			`query q {...on User{id} ...frag2 z ...frag1} fragment unused on User{id} fragment frag2 on User{b a} fragment frag1 on User{...frag2}`,
+			"",
+			`{query q{z,...frag1,...frag2,...on User{id}},fragment frag1 on User{...frag2},fragment frag2 on User{a,b}}`,
+		},
+	} {
+		d, err := parser.ParseString(testCase.input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", testCase.input, err)
+		}
+		s, err := OperationSignature(d, testCase.opName)
+		if err != nil {
+			t.Errorf("input %q; unexpected error: %s", testCase.input, err)
+		} else if s.Text != testCase.expected {
+			t.Errorf("input %q; expected:\n%s\nbut got:\n%s", testCase.input, testCase.expected, s.Text)
+		}
+	}
+}
+
+func TestOperationSignatureHash(t *testing.T) {
+	for _, testCase := range [][2]string{
+		{`query q ($v:Int) {b(x:1) a(y:"foo")}`, `query q ($v:Int) {a(y:"bar") b(x:2)}`},
+		{`query q ($n:Int=1, $s:String="a") {a(n:$n)}`, `query q ($s:String="b", $n:Int=2) {a(n:$n)}`},
+		// Ties are broken by arguments, directives and selection sets.
+		{`query q ($v:Int) {a(x:$v) a(x:ENUM) a @skip(if:$v) a @include(if:$v)}`, `query q ($v:Int) {a @include(if:$v) a @skip(if:$v) a(x:ENUM) a(x:$v)}`},
+		{`{...on T {b} ...on T {a} ...on T @d {a} ...f @b ...f @a}`, `{...f @a ...on T @d {a} ...on T {a} ...f @b ...on T {b}}`},
+		{`{a {c} a {b} a @d(x:1) @d(x:"y")}`, `{a @d(x:"y") @d(x:1) a {b} a {c}}`},
+	} {
+		var hashes [2]*Signature
+		for i, input := range testCase {
+			d, err := parser.ParseString(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hashes[i], err = OperationSignature(d, ""); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if hashes[0].Text != hashes[1].Text || hashes[0].Hash != hashes[1].Hash {
+			t.Errorf("expected equal hashes for %q and %q", hashes[0].Text, hashes[1].Text)
+		}
+		if len(hashes[0].HexHash()) != 64 {
+			t.Errorf("expected 64 character hex hash but got %q", hashes[0].HexHash())
+		}
+	}
+}
+
+func TestNormalizeDoesNotModifyInput(t *testing.T) {
+	d, err := parser.ParseString(`query q {b(x:1) a}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var before, after bytes.Buffer
+	if err := printer.Compact.Fprint(&before, d); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Normalize(d, "q"); err != nil {
+		t.Fatal(err)
+	}
+	if err := printer.Compact.Fprint(&after, d); err != nil {
+		t.Fatal(err)
+	}
+	if before.String() != after.String() {
+		t.Errorf("expected %q but got %q", before.String(), after.String())
+	}
+}
+
+func TestNormalizeOperationErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		input  string
+		opName string
+	}{
+		{`fragment f on T {a}`, ""},
+		{`query a {a} query b {b}`, ""},
+		{`query a {a}`, "b"},
+	} {
+		d, err := parser.ParseString(testCase.input)
+		if err != nil {
+			t.Fatalf("input %q; unexpected error: %s", testCase.input, err)
+		}
+		if _, err := Normalize(d, testCase.opName); err == nil {
+			t.Errorf("input %q; expected error", testCase.input)
+		}
+	}
+}
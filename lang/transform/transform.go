@@ -0,0 +1,99 @@
+// Package transform implements transformations of ast Documents.
+//
+// Transformations never modify their input; they operate on a deep copy of the Document.
+package transform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+var (
+	NoOperation        = errors.New("document contains no operation")
+	AmbiguousOperation = errors.New("document contains multiple operations; an operation name is required")
+)
+
+// The operation function returns the operation named name from d.
+// If name is empty, d must contain exactly one operation.
+func operation(d *ast.Document, name string) (*ast.OpDef, error) {
+	var op *ast.OpDef
+	for _, def := range d.Definitions {
+		o, ok := def.(*ast.OpDef)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if op != nil {
+				return nil, AmbiguousOperation
+			}
+			op = o
+		} else if o.Name.Value == name {
+			return o, nil
+		}
+	}
+	if op == nil {
+		if name != "" {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		return nil, NoOperation
+	}
+	return op, nil
+}
+
+// The fragments function returns a map of the fragment definitions in d, by name.
+func fragments(d *ast.Document) map[string]*ast.FragmentDef {
+	m := make(map[string]*ast.FragmentDef)
+	for _, def := range d.Definitions {
+		if f, ok := def.(*ast.FragmentDef); ok {
+			m[f.Name.Value] = f
+		}
+	}
+	return m
+}
+
+// The usedFragments function returns the names of the fragments transitively spread by ss, in the order they are
+// first encountered. Spreads of unknown fragments are ignored.
+func usedFragments(ss *ast.SelectionSet, frags map[string]*ast.FragmentDef) []string {
+	var used []string
+	seen := make(map[string]bool)
+	var visit func(ss *ast.SelectionSet)
+	visit = func(ss *ast.SelectionSet) {
+		for _, s := range ss.Selections {
+			switch t := s.(type) {
+			case *ast.Field:
+				visit(&t.SelectionSet)
+			case *ast.InlineFragment:
+				visit(&t.SelectionSet)
+			case *ast.FragmentSpread:
+				if seen[t.Name.Value] {
+					continue
+				}
+				seen[t.Name.Value] = true
+				if f, ok := frags[t.Name.Value]; ok {
+					used = append(used, t.Name.Value)
+					visit(&f.SelectionSet)
+				}
+			}
+		}
+	}
+	visit(ss)
+	return used
+}
+
+// The selectOperation function returns a copy of d containing only the operation named name and the fragments it uses.
+func selectOperation(d *ast.Document, name string) (*ast.Document, error) {
+	op, err := operation(d, name)
+	if err != nil {
+		return nil, err
+	}
+	frags := fragments(d)
+
+	result := &ast.Document{Loc: d.Loc, Source: d.Source}
+	result.Definitions = append(result.Definitions, copyDefinition(op))
+	for _, name := range usedFragments(&op.SelectionSet, frags) {
+		result.Definitions = append(result.Definitions, copyDefinition(frags[name]))
+	}
+	return result, nil
+}
@@ -0,0 +1,461 @@
+// Package bind derives schema types from Go types via reflection.
+//
+// Structs are bound to Objects, or to InputObjects when used as arguments. Their exported fields become fields, named
+// by converting the Go name to lower camel case, e.g. UserID becomes userID. Fields may be configured with a tag:
+//
+//	Name string `gql:"name,nonnull,deprecated=Use fullName."`
+//
+// The first element overrides the name, or excludes the field if it is "-". The remaining options are:
+//
+//	nonnull          the field is non-null, even if its Go type may be nil
+//	deprecated       the field is deprecated, optionally with =reason
+//
+// Types which can not be nil, e.g. string or a struct, are non-null. Pointers and slices are nullable. bool, float, and
+// string kinds are bound to the built-in scalars, slices and arrays to lists, and types implementing Enum to Enums.
+// Integer kinds are bound to Int, except for int64, uint, uint32, uint64, and uintptr, which can hold values that Int
+// can not represent. int values must still fit in 32 bits when serialized, and arguments which do not fit in a
+// narrower kind, e.g. 300 for an int8, are rejected. Maps, and other kinds, are not supported.
+//
+// Exported methods accepting a context.Context, and optionally an arguments struct, and returning a value and
+// optionally an error, are bound to fields resolved by calling the method. Example:
+//
+//	func (u *User) Friends(ctx context.Context, args struct{ First int }) ([]*User, error)
+package bind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// An Enum is a Go type bound to an Enum.
+type Enum interface {
+	// The EnumValues method returns the values of the Enum, in order.
+	EnumValues() []EnumValue
+}
+
+// An EnumValue is a value of an Enum, representing the Go value Value.
+type EnumValue struct {
+	Name              string
+	Value             interface{}
+	DeprecationReason string
+}
+
+// The default reason for the deprecated tag option.
+const defaultDeprecationReason = "No longer supported"
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	enumType    = reflect.TypeOf((*Enum)(nil)).Elem()
+)
+
+// A Binder binds Go types to schema types.
+type Binder struct {
+	// Builders for the bound types, in the order they were bound.
+	types []schema.TypeBuilder
+
+	objects      map[reflect.Type]*schema.ObjectBuilder
+	inputObjects map[reflect.Type]string
+	enums        map[reflect.Type]string
+	// Go types by the name they are bound to.
+	names map[string]reflect.Type
+
+	query, mutation *schema.ObjectBuilder
+}
+
+// The New function returns an empty Binder.
+func New() *Binder {
+	return &Binder{
+		objects:      make(map[reflect.Type]*schema.ObjectBuilder),
+		inputObjects: make(map[reflect.Type]string),
+		enums:        make(map[reflect.Type]string),
+		names:        make(map[string]reflect.Type),
+	}
+}
+
+// The Query method binds the type of v, a struct or pointer to a struct, as the query root type.
+func (b *Binder) Query(v interface{}) error {
+	o, err := b.root(v)
+	if err != nil {
+		return err
+	}
+	b.query = o
+	return nil
+}
+
+// The Mutation method binds the type of v, a struct or pointer to a struct, as the mutation root type.
+func (b *Binder) Mutation(v interface{}) error {
+	o, err := b.root(v)
+	if err != nil {
+		return err
+	}
+	b.mutation = o
+	return nil
+}
+
+// The Type method binds the type of v, and the types it references.
+func (b *Binder) Type(v interface{}) error {
+	_, err := b.typeOf(reflect.TypeOf(v), false)
+	return err
+}
+
+func (b *Binder) root(v interface{}) (*schema.ObjectBuilder, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("root type must be a struct, not %v", t)
+	}
+	if _, err := b.object(t); err != nil {
+		return nil, err
+	}
+	return b.objects[t], nil
+}
+
+// The SchemaBuilder method returns a SchemaBuilder for the bound types.
+func (b *Binder) SchemaBuilder() *schema.SchemaBuilder {
+	sb := schema.NewSchema()
+	for _, t := range b.types {
+		switch t {
+		case b.query:
+			sb.Query(b.query)
+		case b.mutation:
+			sb.Mutation(b.mutation)
+		default:
+			sb.Types(t)
+		}
+	}
+	return sb
+}
+
+// The Document method returns the definitions of the bound types.
+func (b *Binder) Document() *ast.Document {
+	return b.SchemaBuilder().Document()
+}
+
+// The Build method builds a Schema from the bound types.
+func (b *Binder) Build() (*schema.Schema, error) {
+	return b.SchemaBuilder().Build()
+}
+
+// The typeOf method returns the schema type bound to t, binding it if necessary. input indicates whether t is used as
+// an input type.
+func (b *Binder) typeOf(t reflect.Type, input bool) (schema.Type, error) {
+	if t == nil {
+		return nil, fmt.Errorf("unable to bind nil type")
+	}
+	nonNull := true
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nonNull = false
+	}
+
+	var st schema.Type
+	switch k := t.Kind(); {
+	case t.Implements(enumType) || reflect.PtrTo(t).Implements(enumType):
+		name, err := b.enum(t)
+		if err != nil {
+			return nil, err
+		}
+		st = schema.Ref(name)
+	case k == reflect.Bool:
+		st = schema.Boolean
+	case k == reflect.Int64 || k == reflect.Uint || k == reflect.Uint32 || k == reflect.Uint64 || k == reflect.Uintptr:
+		return nil, fmt.Errorf("unable to bind %v, which may not fit in the 32-bit Int", t)
+	case k >= reflect.Int && k <= reflect.Uint64:
+		st = schema.Int
+	case k == reflect.Float32 || k == reflect.Float64:
+		st = schema.Float
+	case k == reflect.String:
+		st = schema.String
+	case k == reflect.Slice || k == reflect.Array:
+		if k == reflect.Slice {
+			nonNull = false
+		}
+		elem, err := b.typeOf(t.Elem(), input)
+		if err != nil {
+			return nil, err
+		}
+		st = schema.List(elem)
+	case k == reflect.Struct && input:
+		name, err := b.inputObject(t)
+		if err != nil {
+			return nil, err
+		}
+		st = schema.Ref(name)
+	case k == reflect.Struct:
+		name, err := b.object(t)
+		if err != nil {
+			return nil, err
+		}
+		st = schema.Ref(name)
+	default:
+		return nil, fmt.Errorf("unable to bind unsupported type %v", t)
+	}
+	if nonNull {
+		st = schema.NonNull(st)
+	}
+	return st, nil
+}
+
+// The claim method reserves name for t.
+func (b *Binder) claim(name string, t reflect.Type) error {
+	if name == "" {
+		return fmt.Errorf("unable to bind unnamed type %v", t)
+	}
+	if other, ok := b.names[name]; ok {
+		return fmt.Errorf("unable to bind %v to %q, which is already bound to %v", t, name, other)
+	}
+	b.names[name] = t
+	return nil
+}
+
+// The object method binds the struct type t to an Object, returning its name.
+func (b *Binder) object(t reflect.Type) (string, error) {
+	if _, ok := b.objects[t]; ok {
+		return t.Name(), nil
+	}
+	name := t.Name()
+	if err := b.claim(name, t); err != nil {
+		return "", err
+	}
+	o := schema.NewObject(name)
+	// Register before binding fields, so that recursive references resolve.
+	b.objects[t] = o
+	b.types = append(b.types, o)
+
+	fields, err := structFields(t)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range fields {
+		ft, err := b.typeOf(f.typ, false)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %s", name, f.goName, err)
+		}
+		if f.nonNull {
+			ft = nonNull(ft)
+		}
+		var opts []schema.FieldOption
+		if f.deprecated {
+			opts = append(opts, schema.Deprecated(f.deprecationReason))
+		}
+		o.Field(f.name, ft, fieldResolver(f.index), opts...)
+	}
+
+	pt := reflect.PtrTo(t)
+	for i := 0; i < pt.NumMethod(); i++ {
+		m := pt.Method(i)
+		if !isResolverMethod(m.Type) {
+			continue
+		}
+		if err := b.method(o, m); err != nil {
+			return "", fmt.Errorf("%s.%s: %s", name, m.Name, err)
+		}
+	}
+	return name, nil
+}
+
+// The isResolverMethod function returns true if the method type mt, with receiver, accepts a context.Context.
+func isResolverMethod(mt reflect.Type) bool {
+	return mt.NumIn() >= 2 && mt.In(1) == contextType
+}
+
+// The method method binds the method m to a field of o.
+func (b *Binder) method(o *schema.ObjectBuilder, m reflect.Method) error {
+	mt := m.Type
+	if mt.NumIn() > 3 {
+		return fmt.Errorf("resolver methods accept a context.Context and optionally an arguments struct")
+	}
+	if mt.NumOut() < 1 || mt.NumOut() > 2 || (mt.NumOut() == 2 && mt.Out(1) != errorType) {
+		return fmt.Errorf("resolver methods return a value and optionally an error")
+	}
+	ft, err := b.typeOf(mt.Out(0), false)
+	if err != nil {
+		return err
+	}
+
+	var opts []schema.FieldOption
+	var args []structField
+	var argsType reflect.Type
+	if mt.NumIn() == 3 {
+		argsType = mt.In(2)
+		if argsType.Kind() != reflect.Struct {
+			return fmt.Errorf("arguments must be a struct, not %v", argsType)
+		}
+		args, err = structFields(argsType)
+		if err != nil {
+			return err
+		}
+		for _, a := range args {
+			at, err := b.typeOf(a.typ, true)
+			if err != nil {
+				return fmt.Errorf("argument %s: %s", a.goName, err)
+			}
+			if a.nonNull {
+				at = nonNull(at)
+			}
+			opts = append(opts, schema.Arg(a.name, at, nil))
+		}
+	}
+	o.Field(lowerCamel(m.Name), ft, b.methodResolver(m.Name, argsType, args), opts...)
+	return nil
+}
+
+// The inputObject method binds the struct type t to an InputObject, returning its name.
+func (b *Binder) inputObject(t reflect.Type) (string, error) {
+	if name, ok := b.inputObjects[t]; ok {
+		return name, nil
+	}
+	name := t.Name()
+	if err := b.claim(name, t); err != nil {
+		return "", err
+	}
+	b.inputObjects[t] = name
+	io := schema.NewInputObject(name)
+	b.types = append(b.types, io)
+
+	fields, err := structFields(t)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range fields {
+		ft, err := b.typeOf(f.typ, true)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %s", name, f.goName, err)
+		}
+		if f.nonNull {
+			ft = nonNull(ft)
+		}
+		io.Field(f.name, ft, nil)
+	}
+	return name, nil
+}
+
+// The enum method binds the Enum type t to an Enum, returning its name.
+func (b *Binder) enum(t reflect.Type) (string, error) {
+	if name, ok := b.enums[t]; ok {
+		return name, nil
+	}
+	name := t.Name()
+	if err := b.claim(name, t); err != nil {
+		return "", err
+	}
+	b.enums[t] = name
+	e := schema.NewEnum(name)
+	b.types = append(b.types, e)
+
+	var values []EnumValue
+	if t.Implements(enumType) {
+		values = reflect.Zero(t).Interface().(Enum).EnumValues()
+	} else {
+		values = reflect.New(t).Interface().(Enum).EnumValues()
+	}
+	for _, v := range values {
+		if v.DeprecationReason != "" {
+			e.DeprecatedValue(v.Name, v.Value, v.DeprecationReason)
+		} else {
+			e.Value(v.Name, v.Value)
+		}
+	}
+	return name, nil
+}
+
+func nonNull(t schema.Type) schema.Type {
+	if t.Kind() == schema.NonNullKind {
+		return t
+	}
+	return schema.NonNull(t)
+}
+
+// A structField is a bound field of a struct.
+type structField struct {
+	goName string
+	name   string
+	index  []int
+	typ    reflect.Type
+
+	nonNull           bool
+	deprecated        bool
+	deprecationReason string
+}
+
+// The structFields function returns the bound fields of the struct type t, including the promoted fields of
+// embedded structs.
+func structFields(t reflect.Type) ([]structField, error) {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("gql")
+		if sf.Anonymous && !hasTag {
+			et := sf.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				embedded, err := structFields(et)
+				if err != nil {
+					return nil, err
+				}
+				for _, f := range embedded {
+					f.index = append([]int{i}, f.index...)
+					fields = append(fields, f)
+				}
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			// Unexported.
+			continue
+		}
+
+		f := structField{goName: sf.Name, name: lowerCamel(sf.Name), index: []int{i}, typ: sf.Type}
+		if hasTag {
+			opts := strings.Split(tag, ",")
+			if opts[0] == "-" {
+				continue
+			}
+			if opts[0] != "" {
+				f.name = opts[0]
+			}
+			for _, opt := range opts[1:] {
+				switch {
+				case opt == "nonnull":
+					f.nonNull = true
+				case opt == "deprecated":
+					f.deprecated, f.deprecationReason = true, defaultDeprecationReason
+				case strings.HasPrefix(opt, "deprecated="):
+					f.deprecated, f.deprecationReason = true, strings.TrimPrefix(opt, "deprecated=")
+				default:
+					return nil, fmt.Errorf("%s.%s: unrecognized tag option %q", t.Name(), sf.Name, opt)
+				}
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// The lowerCamel function converts an exported Go name to lower camel case, e.g. ID to id, and HTTPServer to
+// httpServer.
+func lowerCamel(s string) string {
+	rs := []rune(s)
+	for i := range rs {
+		if !unicode.IsUpper(rs[i]) {
+			break
+		}
+		// Keep the last upper case letter of an initialism followed by a lower case letter, e.g. the S in HTTPServer.
+		if i > 0 && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+			break
+		}
+		rs[i] = unicode.ToLower(rs[i])
+	}
+	return string(rs)
+}
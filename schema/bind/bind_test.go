@@ -0,0 +1,366 @@
+package bind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jmank88/gql/lang/printer"
+	"github.com/jmank88/gql/schema"
+)
+
+type Role int
+
+const (
+	Admin Role = iota
+	Member
+	Guest
+)
+
+func (Role) EnumValues() []EnumValue {
+	return []EnumValue{
+		{Name: "ADMIN", Value: Admin},
+		{Name: "MEMBER", Value: Member},
+		{Name: "GUEST", Value: Guest, DeprecationReason: "Guests were removed."},
+	}
+}
+
+type Node struct {
+	ID string `gql:"id"`
+}
+
+type User struct {
+	Node
+	Name      *string
+	Email     string `gql:",deprecated=Use contact."`
+	Nickname  string `gql:"alias,deprecated"`
+	Role      Role
+	Friends   []*User `gql:",nonnull"`
+	Scores    []float64
+	Internal  string `gql:"-"`
+	HTTPCount int
+
+	secret string
+}
+
+type GreetingArgs struct {
+	Prefix string
+	Times  *int
+}
+
+func (u *User) Greeting(ctx context.Context, args GreetingArgs) (string, error) {
+	g := args.Prefix + " " + u.ID
+	if args.Times != nil {
+		for i := 1; i < *args.Times; i++ {
+			g += "!"
+		}
+	}
+	return g, nil
+}
+
+func (u User) Online(ctx context.Context) bool {
+	return u.Role != Guest
+}
+
+// Not a resolver.
+func (u *User) String() string {
+	return u.ID
+}
+
+type Filter struct {
+	Roles        []Role
+	NameContains string `gql:",nonnull"`
+	Limit        *int
+}
+
+type UsersArgs struct {
+	Filter *Filter
+	IDs    []string `gql:"ids"`
+}
+
+type Query struct {
+	Me *User
+}
+
+func (q *Query) Users(ctx context.Context, args UsersArgs) ([]User, error) {
+	if args.Filter == nil {
+		return nil, errors.New("filter required")
+	}
+	var users []User
+	for _, id := range args.IDs {
+		for _, r := range args.Filter.Roles {
+			users = append(users, User{Node: Node{ID: id}, Role: r, Email: args.Filter.NameContains})
+		}
+	}
+	return users, nil
+}
+
+type Mutation struct{}
+
+func (Mutation) SetRole(ctx context.Context, args struct {
+	ID   string `gql:"id"`
+	Role Role
+}) (*User, error) {
+	return &User{Node: Node{ID: args.ID}, Role: args.Role}, nil
+}
+
+func testBinder(t *testing.T) *Binder {
+	b := New()
+	if err := b.Query(&Query{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Mutation(Mutation{}); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDocument(t *testing.T) {
+	var buf bytes.Buffer
+	for _, d := range testBinder(t).Document().Definitions {
+		if err := printer.Compact.Fprint(&buf, d); err != nil {
+			t.Fatal(err)
+		}
+		buf.WriteString("\n")
+	}
+	expected := `type Query{me:User,users(filter:Filter,ids:[String!]):[User!]}
+type User{id:String!,name:String,email:String!@deprecated(reason:"Use contact."),alias:String!@deprecated,role:Role!,friends:[User]!,scores:[Float!],httpCount:Int!,greeting(prefix:String!,times:Int):String!,online:Boolean!}
+enum Role{ADMIN,MEMBER,GUEST@deprecated(reason:"Guests were removed.")}
+input Filter{roles:[Role!],nameContains:String!,limit:Int}
+type Mutation{setRole(id:String!,role:Role!):User}
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, buf.String())
+	}
+
+	// Deprecations survive building and printing.
+	s, err := testBinder(t).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := schema.Print(&buf, s, printer.Compact, false); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, buf.String())
+	}
+}
+
+func TestBuild(t *testing.T) {
+	s, err := testBinder(t).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.QueryType().Name() != "Query" || s.MutationType().Name() != "Mutation" {
+		t.Errorf("unexpected root types %v and %v", s.QueryType(), s.MutationType())
+	}
+
+	user := s.Type("User").(*schema.Object)
+	if f := user.Field("email"); !f.IsDeprecated() || f.DeprecationReason() != "Use contact." {
+		t.Errorf("expected email to be deprecated but got %q", f.DeprecationReason())
+	}
+	if f := user.Field("alias"); f.DeprecationReason() != "No longer supported" {
+		t.Errorf("expected alias to be deprecated but got %q", f.DeprecationReason())
+	}
+	role := s.Type("Role").(*schema.Enum)
+	if v := role.Value("MEMBER"); v.Value() != Member {
+		t.Errorf("expected MEMBER to represent %v but got %v", Member, v.Value())
+	}
+	if v := role.Value("GUEST"); v.DeprecationReason() != "Guests were removed." {
+		t.Errorf("expected GUEST to be deprecated but got %q", v.DeprecationReason())
+	}
+}
+
+func TestResolvers(t *testing.T) {
+	s, err := testBinder(t).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	user := s.Type("User").(*schema.Object)
+	resolve := func(o *schema.Object, field string, source interface{}, args map[string]interface{}) interface{} {
+		v, err := o.Field(field).Resolve()(ctx, source, args, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", field, err)
+		}
+		return v
+	}
+
+	name := "Bob"
+	u := &User{Node: Node{ID: "1"}, Name: &name, Role: Guest, Scores: []float64{1.5}}
+	for _, test := range []struct {
+		field    string
+		source   interface{}
+		args     map[string]interface{}
+		expected interface{}
+	}{
+		{"id", u, nil, "1"},
+		{"id", *u, nil, "1"},
+		{"name", u, nil, "Bob"},
+		{"name", &User{}, nil, nil},
+		{"friends", u, nil, nil},
+		{"scores", u, nil, []float64{1.5}},
+		{"role", u, nil, Guest},
+		{"online", u, nil, false},
+		{"online", User{}, nil, true},
+		{"greeting", u, map[string]interface{}{"prefix": "hi"}, "hi 1"},
+		{"greeting", *u, map[string]interface{}{"prefix": "hi", "times": 3}, "hi 1!!"},
+	} {
+		if actual := resolve(user, test.field, test.source, test.args); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %#v but got %#v", test.field, test.expected, actual)
+		}
+	}
+	if v := resolve(s.QueryType(), "me", &Query{Me: u}, nil); v != u {
+		t.Errorf("expected %v but got %v", u, v)
+	}
+
+	users := resolve(s.QueryType(), "users", &Query{}, map[string]interface{}{
+		"ids": []interface{}{"1", "2"},
+		"filter": map[string]interface{}{
+			"roles":        []interface{}{Admin},
+			"nameContains": "b",
+		},
+	})
+	expected := []User{
+		{Node: Node{ID: "1"}, Role: Admin, Email: "b"},
+		{Node: Node{ID: "2"}, Role: Admin, Email: "b"},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %#v but got %#v", expected, users)
+	}
+
+	if _, err := s.QueryType().Field("users").Resolve()(ctx, Query{}, nil, nil); err == nil || err.Error() != "filter required" {
+		t.Errorf("expected resolver error but got %v", err)
+	}
+
+	setRole := resolve(s.MutationType(), "setRole", Mutation{}, map[string]interface{}{"id": "3", "role": Member})
+	if expected := (&User{Node: Node{ID: "3"}, Role: Member}); !reflect.DeepEqual(setRole, expected) {
+		t.Errorf("expected %#v but got %#v", expected, setRole)
+	}
+}
+
+type RangeQuery struct{}
+
+func (RangeQuery) Sum(ctx context.Context, args struct {
+	Small int8
+	Byte  *uint8
+	Half  float32
+}) int {
+	sum := int(args.Small) + int(args.Half)
+	if args.Byte != nil {
+		sum += int(*args.Byte)
+	}
+	return sum
+}
+
+func TestDecodeRange(t *testing.T) {
+	b := New()
+	if err := b.Query(RangeQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := s.QueryType().Field("sum").Resolve()
+	for _, test := range []struct {
+		args     map[string]interface{}
+		expected interface{}
+		err      string
+	}{
+		{map[string]interface{}{"small": -128, "byte": 255, "half": 1.5}, 128, ""},
+		{map[string]interface{}{"small": 300}, nil, "small: 300 is out of range for int8"},
+		{map[string]interface{}{"small": -129}, nil, "small: -129 is out of range for int8"},
+		{map[string]interface{}{"byte": -1}, nil, "byte: -1 is out of range for uint8"},
+		{map[string]interface{}{"byte": 256}, nil, "byte: 256 is out of range for uint8"},
+		{map[string]interface{}{"half": 1e39}, nil, "half: 1e+39 is out of range for float32"},
+	} {
+		actual, err := sum(context.Background(), RangeQuery{}, test.args, nil)
+		if test.err != "" {
+			if err == nil || !strings.HasSuffix(err.Error(), test.err) {
+				t.Errorf("%v: expected error %q but got %v", test.args, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %s", test.args, err)
+		} else if actual != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.args, test.expected, actual)
+		}
+	}
+}
+
+type Both struct {
+	A int
+}
+
+type BothQuery struct {
+	B Both
+}
+
+func (BothQuery) C(ctx context.Context, args struct{ B Both }) int {
+	return 0
+}
+
+type BadTag struct {
+	A int `gql:",nullable"`
+}
+
+type BadMethod struct{}
+
+func (BadMethod) A(ctx context.Context, a, b int) int {
+	return 0
+}
+
+type BadType struct {
+	A map[string]int
+}
+
+type BadInt struct {
+	A int64
+}
+
+type BadUint struct {
+	A *uint64
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range []struct {
+		v        interface{}
+		expected string
+	}{
+		{1, "root type must be a struct, not int"},
+		{BothQuery{}, `BothQuery.C: argument B: unable to bind bind.Both to "Both", which is already bound to bind.Both`},
+		{BadTag{}, `BadTag.A: unrecognized tag option "nullable"`},
+		{BadMethod{}, `BadMethod.A: resolver methods accept a context.Context and optionally an arguments struct`},
+		{BadType{}, `BadType.A: unable to bind unsupported type map[string]int`},
+		{BadInt{}, `BadInt.A: unable to bind int64, which may not fit in the 32-bit Int`},
+		{BadUint{}, `BadUint.A: unable to bind uint64, which may not fit in the 32-bit Int`},
+		{struct{ A int }{}, `unable to bind unnamed type struct { A int }`},
+	} {
+		if err := New().Query(test.v); err == nil {
+			t.Errorf("%T: expected error", test.v)
+		} else if err.Error() != test.expected {
+			t.Errorf("%T: expected error %q but got %q", test.v, test.expected, err)
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	for input, expected := range map[string]string{
+		"ID":         "id",
+		"Name":       "name",
+		"UserID":     "userID",
+		"HTTPServer": "httpServer",
+		"URL":        "url",
+		"A":          "a",
+	} {
+		if actual := lowerCamel(input); actual != expected {
+			t.Errorf("%q: expected %q but got %q", input, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/jmank88/gql/schema"
+)
+
+// The fieldResolver function returns a ResolveFunc returning the struct field at index of the source.
+func fieldResolver(index []int) schema.ResolveFunc {
+	return func(ctx context.Context, source interface{}, args map[string]interface{}, info *schema.ResolveInfo) (interface{}, error) {
+		v := reflect.ValueOf(source)
+		for _, i := range index {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return nil, nil
+				}
+				v = v.Elem()
+			}
+			if v.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("unable to resolve field of %v", v.Type())
+			}
+			v = v.Field(i)
+		}
+		return result(v), nil
+	}
+}
+
+// The methodResolver function returns a ResolveFunc calling the method named name of the source. If argsType is not
+// nil, the arguments are decoded into a value of argsType, with fields args.
+func (b *Binder) methodResolver(name string, argsType reflect.Type, args []structField) schema.ResolveFunc {
+	return func(ctx context.Context, source interface{}, argValues map[string]interface{}, info *schema.ResolveInfo) (interface{}, error) {
+		v := reflect.ValueOf(source)
+		if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+			return nil, fmt.Errorf("unable to call method %s of nil", name)
+		}
+		if v.Kind() != reflect.Ptr {
+			// Methods with pointer receivers require an addressable value.
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p
+		}
+		m := v.MethodByName(name)
+		if !m.IsValid() {
+			return nil, fmt.Errorf("%v has no method %s", v.Type(), name)
+		}
+
+		in := []reflect.Value{reflect.ValueOf(ctx)}
+		if ctx == nil {
+			in[0] = reflect.Zero(contextType)
+		}
+		if argsType != nil {
+			a, err := b.decodeStruct(argValues, argsType, args)
+			if err != nil {
+				return nil, err
+			}
+			in = append(in, a)
+		}
+		out := m.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+		return result(out[0]), nil
+	}
+}
+
+// The result function returns the resolved value of v. Nil pointers, slices, and maps are nil, and other pointers
+// are dereferenced.
+func result(v reflect.Value) interface{} {
+	for {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if v.IsNil() {
+				return nil
+			}
+			if v.Elem().Kind() == reflect.Struct {
+				// Preserve pointers to structs, for their methods.
+				return v.Interface()
+			}
+			v = v.Elem()
+			continue
+		case reflect.Slice, reflect.Map:
+			if v.IsNil() {
+				return nil
+			}
+		case reflect.Invalid:
+			return nil
+		}
+		return v.Interface()
+	}
+}
+
+// The decodeStruct method decodes the input object value m into a new value of the struct type t, with fields.
+func (b *Binder) decodeStruct(m map[string]interface{}, t reflect.Type, fields []structField) (reflect.Value, error) {
+	s := reflect.New(t).Elem()
+	for _, f := range fields {
+		v, ok := m[f.name]
+		if !ok {
+			continue
+		}
+		fv, err := b.decode(v, f.typ)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s: %s", f.name, err)
+		}
+		s.FieldByIndex(f.index).Set(fv)
+	}
+	return s, nil
+}
+
+// The decode method decodes v, a coerced input value, into a value of type t.
+func (b *Binder) decode(v interface{}, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(t), nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		e, err := b.decode(v, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, nil
+	case reflect.Slice, reflect.Array:
+		l, ok := v.([]interface{})
+		if !ok {
+			l = []interface{}{v}
+		}
+		var s reflect.Value
+		if t.Kind() == reflect.Slice {
+			s = reflect.MakeSlice(t, len(l), len(l))
+		} else if len(l) > t.Len() {
+			return reflect.Value{}, fmt.Errorf("too many values for %v", t)
+		} else {
+			s = reflect.New(t).Elem()
+		}
+		for i, e := range l {
+			ev, err := b.decode(e, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s.Index(i).Set(ev)
+		}
+		return s, nil
+	case reflect.Struct:
+		if _, ok := b.inputObjects[t]; ok {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("expected input object but got %T", v)
+			}
+			fields, err := structFields(t)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return b.decodeStruct(m, t, fields)
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(t) {
+		return rv, nil
+	}
+	if isNumber(rv.Kind()) && isNumber(t.Kind()) {
+		if overflows(rv, t) {
+			return reflect.Value{}, fmt.Errorf("%v is out of range for %v", v, t)
+		}
+		return rv.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unable to decode %T into %v", v, t)
+}
+
+// The overflows function returns true if the number rv can not be represented by the number type t.
+func overflows(rv reflect.Value, t reflect.Type) bool {
+	z := reflect.Zero(t)
+	switch rk := rv.Kind(); {
+	case rk >= reflect.Int && rk <= reflect.Int64:
+		i := rv.Int()
+		switch {
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+			return z.OverflowInt(i)
+		case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+			return i < 0 || z.OverflowUint(uint64(i))
+		}
+	case rk >= reflect.Uint && rk <= reflect.Uint64:
+		u := rv.Uint()
+		switch {
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+			return u > math.MaxInt64 || z.OverflowInt(int64(u))
+		case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+			return z.OverflowUint(u)
+		}
+	case rk == reflect.Float32 || rk == reflect.Float64:
+		f := rv.Float()
+		switch {
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+			return f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || z.OverflowInt(int64(f))
+		case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+			return f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || z.OverflowUint(uint64(f))
+		}
+	}
+	if t.Kind() == reflect.Float32 {
+		return z.OverflowFloat(rv.Convert(reflect.TypeOf(float64(0))).Float())
+	}
+	return false
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64 && k != reflect.Uintptr
+}
@@ -0,0 +1,414 @@
+package schema
+
+import (
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The Build function returns a Schema built from the type system definitions in docs.
+//
+// Every NamedType reference is resolved, TypeExtDefs are merged into the Objects they extend, and the built-in
+// scalars are included. Root operation types are taken from the SchemaDef if present, and otherwise default to the
+// Objects named Query, Mutation, and Subscription.
+//
+// Descriptions are taken from the definitions, and the @deprecated directive marks fields and enum values as
+// deprecated, with its reason argument defaulting to DefaultDeprecationReason. The @specifiedBy directive sets the
+// specification URL of a custom scalar. Scalars use the Coercer registered for their name, if any. DirectiveDefs add
+// custom directives alongside the built-in directives, which may not be redefined.
+//
+// The resulting Schema is validated against the type system rules of the spec, e.g. that Objects implement the fields
+// of their Interfaces. Any problems are returned together as an errors.List, with the locations of the offending
+// definitions.
+func Build(docs ...*ast.Document) (*Schema, error) {
+	b := builder{schema: newSchema()}
+	for _, d := range docs {
+		b.declare(d)
+	}
+	for _, d := range b.defs {
+		b.define(d)
+	}
+	for _, e := range b.exts {
+		b.extend(e)
+	}
+	b.directives()
+	b.possibleTypes()
+	b.roots()
+	if len(b.errs) > 0 {
+		return nil, b.errs
+	}
+	if errs := validate(b.schema); len(errs) > 0 {
+		return nil, errs
+	}
+	return b.schema, nil
+}
+
+// The DefaultDeprecationReason is the reason given for a @deprecated usage without a reason argument.
+const DefaultDeprecationReason = "No longer supported"
+
+// A builder holds the state for building a single Schema.
+type builder struct {
+	schema *Schema
+	errs   errors.List
+
+	// Declared types, and their definitions.
+	defs []definition
+	// Type extensions.
+	exts []*ast.TypeExtDef
+	// The schema definition, if any.
+	schemaDef *ast.SchemaDef
+	// Custom directive definitions.
+	directiveDefs []*ast.DirectiveDef
+}
+
+// A definition pairs a declared type with its definition.
+type definition struct {
+	t   NamedType
+	def ast.TypeDef
+}
+
+func (b *builder) errorf(loc ast.Loc, format string, args ...interface{}) {
+	b.errs = append(b.errs, errors.Newf(loc, format, args...))
+}
+
+// The declare method adds an empty type to the schema for each type definition in d, so that references may be
+// resolved regardless of definition order.
+func (b *builder) declare(d *ast.Document) {
+	for _, def := range d.Definitions {
+		var t NamedType
+		switch def := def.(type) {
+		case *ast.ObjTypeDef:
+			t = &Object{name: def.Name.Value, loc: def.Loc, description: description(def.Description)}
+		case *ast.InterfaceTypeDef:
+			t = &Interface{name: def.Name.Value, loc: def.Loc, description: description(def.Description)}
+		case *ast.UnionTypeDef:
+			t = &Union{name: def.Name.Value, loc: def.Loc, description: description(def.Description)}
+		case *ast.ScalarTypeDef:
+			t = &Scalar{name: def.Name.Value, loc: def.Loc, description: description(def.Description),
+				coercer: RegisteredScalar(def.Name.Value), specifiedBy: specifiedBy(def.Directives)}
+		case *ast.EnumTypeDef:
+			t = &Enum{name: def.Name.Value, loc: def.Loc, description: description(def.Description)}
+		case *ast.InputObjTypeDef:
+			t = &InputObject{name: def.Name.Value, loc: def.Loc, description: description(def.Description)}
+		case *ast.TypeExtDef:
+			b.exts = append(b.exts, def)
+			continue
+		case *ast.SchemaDef:
+			if b.schemaDef != nil {
+				b.errorf(def.Loc, "must provide only one schema definition")
+				continue
+			}
+			b.schemaDef = def
+			continue
+		case *ast.DirectiveDef:
+			b.directiveDefs = append(b.directiveDefs, def)
+			continue
+		case *ast.OpDef:
+			b.errorf(def.Loc, "unexpected operation definition in type system document")
+			continue
+		case *ast.FragmentDef:
+			b.errorf(def.Loc, "unexpected fragment definition in type system document")
+			continue
+		default:
+			b.errs = append(b.errs, errors.New("unexpected "+def.Kind()+" in type system document"))
+			continue
+		}
+
+		if !b.schema.add(t) {
+			if s, ok := t.(*Scalar); ok && isBuiltInScalar(s.name) {
+				// Redeclaring a built-in scalar is harmless.
+				continue
+			}
+			b.errorf(t.Loc(), "duplicate type %q", t.Name())
+			continue
+		}
+		b.defs = append(b.defs, definition{t, def.(ast.TypeDef)})
+	}
+}
+
+// The define method populates the type declared for d.
+func (b *builder) define(d definition) {
+	switch def := d.def.(type) {
+	case *ast.ObjTypeDef:
+		o := d.t.(*Object)
+		o.interfaces = b.interfaces(def.Interfaces)
+		o.fieldsByName = make(map[string]*Field)
+		o.fields = b.fields(o.name, def.FieldDefs, o.fieldsByName)
+	case *ast.InterfaceTypeDef:
+		i := d.t.(*Interface)
+		i.fieldsByName = make(map[string]*Field)
+		i.fields = b.fields(i.name, def.FieldDefs, i.fieldsByName)
+	case *ast.UnionTypeDef:
+		u := d.t.(*Union)
+		for i := range def.NamedTypes {
+			nt := &def.NamedTypes[i]
+			t := b.namedType(nt)
+			if t == nil {
+				continue
+			}
+			o, ok := t.(*Object)
+			if !ok {
+				b.errorf(nt.Loc, "union %q member %q is not an object type", u.name, nt.Value)
+				continue
+			}
+			u.types = append(u.types, o)
+		}
+	case *ast.EnumTypeDef:
+		e := d.t.(*Enum)
+		e.valuesByName = make(map[string]*EnumValue)
+		for _, v := range def.EnumValueDefs {
+			if _, ok := e.valuesByName[v.Value]; ok {
+				b.errorf(v.Loc, "duplicate enum value %q in %q", v.Value, e.name)
+				continue
+			}
+			ev := &EnumValue{
+				name:              v.Value,
+				loc:               v.Loc,
+				value:             v.Value,
+				description:       description(v.Description),
+				deprecationReason: deprecation(v.Directives),
+			}
+			e.values = append(e.values, ev)
+			e.valuesByName[ev.name] = ev
+		}
+	case *ast.InputObjTypeDef:
+		o := d.t.(*InputObject)
+		o.fieldsByName = make(map[string]*InputValue)
+		for i := range def.Fields {
+			f := b.inputValue(&def.Fields[i])
+			if _, ok := o.fieldsByName[f.name]; ok {
+				b.errorf(f.loc, "duplicate input field %q in %q", f.name, o.name)
+				continue
+			}
+			o.fields = append(o.fields, f)
+			o.fieldsByName[f.name] = f
+		}
+	}
+}
+
+// The extend method merges the fields and interfaces of e into the Object it extends.
+func (b *builder) extend(e *ast.TypeExtDef) {
+	t := b.schema.Type(e.Name.Value)
+	if t == nil {
+		b.errorf(e.Name.Loc, "cannot extend unknown type %q", e.Name.Value)
+		return
+	}
+	o, ok := t.(*Object)
+	if !ok {
+		b.errorf(e.Name.Loc, "cannot extend non-object type %q", e.Name.Value)
+		return
+	}
+	for _, i := range b.interfaces(e.Interfaces) {
+		dup := false
+		for _, existing := range o.interfaces {
+			dup = dup || existing == i
+		}
+		if !dup {
+			o.interfaces = append(o.interfaces, i)
+		}
+	}
+	o.fields = append(o.fields, b.fields(o.name, e.FieldDefs, o.fieldsByName)...)
+}
+
+// The directives method adds the directives defined by the DirectiveDefs to the schema.
+func (b *builder) directives() {
+	for _, def := range b.directiveDefs {
+		if b.schema.Directive(def.Name.Value) != nil {
+			b.errorf(def.Name.Loc, "duplicate directive \"@%s\"", def.Name.Value)
+			continue
+		}
+		d := &Directive{
+			name:        def.Name.Value,
+			description: description(def.Description),
+			loc:         def.Loc,
+			repeatable:  def.Repeatable,
+		}
+		for i := range def.Arguments {
+			a := b.inputValue(&def.Arguments[i])
+			if d.Arg(a.name) != nil {
+				b.errorf(a.loc, "duplicate argument %q in \"@%s\"", a.name, d.name)
+				continue
+			}
+			d.args = append(d.args, a)
+		}
+		for _, l := range def.Locations {
+			loc := DirectiveLocation(l.Value)
+			if !isDirectiveLocation(loc) {
+				b.errorf(l.Loc, "unknown directive location %q in \"@%s\"", l.Value, d.name)
+				continue
+			}
+			d.locations = append(d.locations, loc)
+		}
+		b.schema.directives = append(b.schema.directives, d)
+	}
+}
+
+// The possibleTypes method records each Object as a possible type of the Interfaces it implements.
+func (b *builder) possibleTypes() {
+	for _, t := range b.schema.typesOrder {
+		if o, ok := t.(*Object); ok {
+			for _, i := range o.interfaces {
+				i.possibleTypes = append(i.possibleTypes, o)
+			}
+		}
+	}
+}
+
+// The roots method resolves the root operation types.
+func (b *builder) roots() {
+	s := b.schema
+	if b.schemaDef == nil {
+		s.query, _ = s.Type("Query").(*Object)
+		s.mutation, _ = s.Type("Mutation").(*Object)
+		s.subscription, _ = s.Type("Subscription").(*Object)
+		return
+	}
+	for i := range b.schemaDef.OpTypeDefs {
+		otd := &b.schemaDef.OpTypeDefs[i]
+		var root **Object
+		switch otd.OpType {
+		case ast.Query:
+			root = &s.query
+		case ast.Mutation:
+			root = &s.mutation
+		case ast.Subscription:
+			root = &s.subscription
+		}
+		if *root != nil {
+			b.errorf(otd.Loc, "must provide only one %s type in schema", otd.OpType.String())
+			continue
+		}
+		t := b.namedType(&otd.NamedType)
+		if t == nil {
+			continue
+		}
+		o, ok := t.(*Object)
+		if !ok {
+			b.errorf(otd.NamedType.Loc, "%s root type %q is not an object type", otd.OpType.String(), otd.NamedType.Value)
+			continue
+		}
+		*root = o
+	}
+}
+
+// The fields method returns the Fields defined by fds, adding each to byName. typeName is the name of the enclosing
+// type.
+func (b *builder) fields(typeName string, fds []ast.FieldDef, byName map[string]*Field) []*Field {
+	var fields []*Field
+	for i := range fds {
+		fd := &fds[i]
+		if _, ok := byName[fd.Name.Value]; ok {
+			b.errorf(fd.Loc, "duplicate field %q in %q", fd.Name.Value, typeName)
+			continue
+		}
+		f := &Field{
+			name:              fd.Name.Value,
+			loc:               fd.Loc,
+			typ:               b.refType(fd.RefType),
+			description:       description(fd.Description),
+			deprecationReason: deprecation(fd.Directives),
+			directives:        fd.Directives,
+		}
+		for j := range fd.Arguments {
+			a := b.inputValue(&fd.Arguments[j])
+			if f.Arg(a.name) != nil {
+				b.errorf(a.loc, "duplicate argument %q in \"%s.%s\"", a.name, typeName, f.name)
+				continue
+			}
+			f.args = append(f.args, a)
+		}
+		fields = append(fields, f)
+		byName[f.name] = f
+	}
+	return fields
+}
+
+func (b *builder) inputValue(ivd *ast.InputValueDef) *InputValue {
+	return &InputValue{
+		name:         ivd.Name.Value,
+		loc:          ivd.Loc,
+		typ:          b.refType(ivd.RefType),
+		defaultValue: ivd.DefaultValue,
+		description:  description(ivd.Description),
+	}
+}
+
+// The description function returns the value of an optional description.
+func description(s *ast.String) string {
+	if s == nil {
+		return ""
+	}
+	return s.Value
+}
+
+// The deprecation function returns the reason given by a @deprecated directive in ds, or nil if there is none.
+func deprecation(ds []ast.Directive) *string {
+	for _, d := range ds {
+		if d.Name.Value != "deprecated" {
+			continue
+		}
+		reason := DefaultDeprecationReason
+		for _, a := range d.Arguments {
+			if s, ok := a.Value.(*ast.String); ok && a.Name.Value == "reason" {
+				reason = s.Value
+			}
+		}
+		return &reason
+	}
+	return nil
+}
+
+// The interfaces method resolves a list of implemented interfaces.
+func (b *builder) interfaces(nts []ast.NamedType) []*Interface {
+	var is []*Interface
+	for i := range nts {
+		nt := &nts[i]
+		t := b.namedType(nt)
+		if t == nil {
+			continue
+		}
+		iface, ok := t.(*Interface)
+		if !ok {
+			b.errorf(nt.Loc, "cannot implement non-interface type %q", nt.Value)
+			continue
+		}
+		is = append(is, iface)
+	}
+	return is
+}
+
+// The refType method resolves a type reference. Unknown types are reported, and resolved as nil.
+func (b *builder) refType(rt ast.RefType) Type {
+	switch t := rt.(type) {
+	case *ast.NamedType:
+		if nt := b.namedType(t); nt != nil {
+			return nt
+		}
+	case *ast.ListType:
+		if of := b.refType(t.RefType); of != nil {
+			return List(of)
+		}
+	case *ast.NonNullType:
+		if of := b.refType(t.RefType); of != nil {
+			return NonNull(of)
+		}
+	}
+	return nil
+}
+
+// The namedType method resolves a named type reference, reporting an error if it is unknown.
+func (b *builder) namedType(nt *ast.NamedType) NamedType {
+	t := b.schema.Type(nt.Value)
+	if t == nil {
+		b.errorf(nt.Loc, "unknown type %q", nt.Value)
+	}
+	return t
+}
+
+func isBuiltInScalar(name string) bool {
+	for _, s := range builtInScalars {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+)
+
+const testSDL = `
+schema {query: Root, mutation: Mutation}
+
+type Root {
+	user(id: ID!, tags: [String] = ["a"]): User
+	node(id: ID!): Node
+	search(term: String): [SearchResult!]!
+}
+
+type Mutation {
+	setName(input: NameInput): User
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	role: Role
+}
+
+type Photo implements Node {
+	id: ID!
+	url: URL
+}
+
+union SearchResult = User | Photo
+
+enum Role {ADMIN, USER}
+
+input NameInput {
+	id: ID!
+	name: String! = "anonymous"
+}
+
+scalar URL
+`
+
+func mustParse(t *testing.T, src string) *ast.Document {
+	d, err := parser.ParseString(src)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %s", src, err)
+	}
+	return d
+}
+
+func mustBuild(t *testing.T, srcs ...string) *Schema {
+	var docs []*ast.Document
+	for _, src := range srcs {
+		docs = append(docs, mustParse(t, src))
+	}
+	s, err := Build(docs...)
+	if err != nil {
+		t.Fatalf("failed to build schema: %s", err)
+	}
+	return s
+}
+
+func TestBuild(t *testing.T) {
+	s := mustBuild(t, testSDL)
+
+	root := s.QueryType()
+	if root == nil || root.Name() != "Root" {
+		t.Fatalf("expected query type Root but got %v", root)
+	}
+	if m := s.MutationType(); m == nil || m.Name() != "Mutation" {
+		t.Errorf("expected mutation type Mutation but got %v", m)
+	}
+	if sub := s.SubscriptionType(); sub != nil {
+		t.Errorf("expected no subscription type but got %v", sub)
+	}
+
+	var names []string
+	for _, typ := range s.Types() {
+		names = append(names, typ.Name())
+	}
+	expectedNames := []string{"Int", "Float", "String", "Boolean", "ID",
+		"__Schema", "__Type", "__Field", "__InputValue", "__EnumValue", "__Directive", "__TypeKind", "__DirectiveLocation",
+		"Root", "Mutation", "Node", "User", "Photo", "SearchResult", "Role", "NameInput", "URL"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("expected types %v but got %v", expectedNames, names)
+	}
+
+	if s.Type("Int") != Int || s.Type("ID") != ID {
+		t.Error("expected built-in scalars")
+	}
+	if s.Type("Unknown") != nil {
+		t.Error("expected nil for unknown type")
+	}
+
+	user := s.Type("User").(*Object)
+	node := s.Type("Node").(*Interface)
+	photo := s.Type("Photo").(*Object)
+
+	// Type references.
+	for _, test := range []struct {
+		field    *Field
+		expected string
+	}{
+		{root.Field("user"), "User"},
+		{root.Field("node"), "Node"},
+		{root.Field("search"), "[SearchResult!]!"},
+		{user.Field("id"), "ID!"},
+		{user.Field("role"), "Role"},
+		{photo.Field("url"), "URL"},
+	} {
+		if actual := test.field.Type().String(); actual != test.expected {
+			t.Errorf("field %q: expected type %q but got %q", test.field.Name(), test.expected, actual)
+		}
+	}
+	if root.Field("user").Type() != user {
+		t.Error("expected field type to resolve to the User object")
+	}
+	search := root.Field("search").Type().(*NonNullType).OfType().(*ListType).OfType().(*NonNullType).OfType()
+	if search != s.Type("SearchResult") {
+		t.Errorf("expected SearchResult but got %v", search)
+	}
+
+	// Arguments.
+	args := root.Field("user").Args()
+	if len(args) != 2 || args[0].Name() != "id" || args[0].Type().String() != "ID!" {
+		t.Errorf("unexpected arguments: %v", args)
+	}
+	if tags := root.Field("user").Arg("tags"); tags == nil {
+		t.Error("expected tags argument")
+	} else if _, ok := tags.DefaultValue().(*ast.List); !ok {
+		t.Errorf("expected list default value but got %#v", tags.DefaultValue())
+	}
+
+	// Abstract types.
+	if !reflect.DeepEqual(user.Interfaces(), []*Interface{node}) {
+		t.Errorf("expected User to implement Node but got %v", user.Interfaces())
+	}
+	if !reflect.DeepEqual(node.PossibleTypes(), []*Object{user, photo}) {
+		t.Errorf("expected Node possible types [User Photo] but got %v", node.PossibleTypes())
+	}
+	if !reflect.DeepEqual(s.Type("SearchResult").(*Union).Types(), []*Object{user, photo}) {
+		t.Errorf("expected SearchResult types [User Photo] but got %v", s.Type("SearchResult").(*Union).Types())
+	}
+	if !s.IsPossibleType(node, photo) || s.IsPossibleType(s.Type("SearchResult"), root) {
+		t.Error("unexpected IsPossibleType result")
+	}
+
+	// Enums and input objects.
+	role := s.Type("Role").(*Enum)
+	if len(role.Values()) != 2 || role.Value("ADMIN") == nil || role.Value("NONE") != nil {
+		t.Errorf("unexpected enum values: %v", role.Values())
+	}
+	input := s.Type("NameInput").(*InputObject)
+	if f := input.Field("name"); f == nil || f.Type().String() != "String!" {
+		t.Errorf("unexpected input field: %v", f)
+	}
+
+	// Locations.
+	if l := user.Loc(); l.Start == 0 || l.End <= l.Start {
+		t.Errorf("unexpected location %v", l)
+	}
+	if l := Int.Loc(); l != (ast.Loc{}) {
+		t.Errorf("expected zero location for built-in type but got %v", l)
+	}
+}
+
+func TestBuildDefaultRoots(t *testing.T) {
+	s := mustBuild(t, "type Query {a: Int}", "type Mutation {b: Int}", "type Subscription {c: Int}")
+	if s.QueryType() != s.Type("Query") {
+		t.Errorf("expected query type Query but got %v", s.QueryType())
+	}
+	if s.MutationType() != s.Type("Mutation") {
+		t.Errorf("expected mutation type Mutation but got %v", s.MutationType())
+	}
+	if s.SubscriptionType() != s.Type("Subscription") {
+		t.Errorf("expected subscription type Subscription but got %v", s.SubscriptionType())
+	}
+}
+
+func TestBuildExtensions(t *testing.T) {
+	s := mustBuild(t,
+		"type Query {a: Int} interface Named {name: String}",
+		"extend type Query implements Named {name: String, b: Query}",
+		"extend type Query {c: [Int]}",
+	)
+	q := s.QueryType()
+	var names []string
+	for _, f := range q.Fields() {
+		names = append(names, f.Name())
+	}
+	if expected := []string{"a", "name", "b", "c"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected fields %v but got %v", expected, names)
+	}
+	if q.Field("b").Type() != q {
+		t.Error("expected extension field to resolve")
+	}
+	named := s.Type("Named").(*Interface)
+	if !reflect.DeepEqual(q.Interfaces(), []*Interface{named}) {
+		t.Errorf("expected Query to implement Named but got %v", q.Interfaces())
+	}
+	if !reflect.DeepEqual(named.PossibleTypes(), []*Object{q}) {
+		t.Errorf("expected Named possible types [Query] but got %v", named.PossibleTypes())
+	}
+}
+
+func TestBuildDescriptions(t *testing.T) {
+	s := mustBuild(t, `
+"""The root."""
+type Query {
+	"A field." a("An arg." x: Int): Int @deprecated(reason: "Use b.")
+	b: Int @deprecated
+	c: Int
+}
+"A scalar." scalar Time
+"An enum." enum Color {"Red." RED, BLUE @deprecated(reason: "Too sad.")}
+"An input." input In {"A field." a: Int}
+`)
+	q := s.QueryType()
+	for _, test := range []struct {
+		actual, expected string
+	}{
+		{q.Description(), "The root."},
+		{q.Field("a").Description(), "A field."},
+		{q.Field("a").Arg("x").Description(), "An arg."},
+		{q.Field("a").DeprecationReason(), "Use b."},
+		{q.Field("b").DeprecationReason(), DefaultDeprecationReason},
+		{s.Type("Time").Description(), "A scalar."},
+		{s.Type("Color").Description(), "An enum."},
+		{s.Type("Color").(*Enum).Value("RED").Description(), "Red."},
+		{s.Type("Color").(*Enum).Value("BLUE").DeprecationReason(), "Too sad."},
+		{s.Type("In").Description(), "An input."},
+		{s.Type("In").(*InputObject).Field("a").Description(), "A field."},
+	} {
+		if test.actual != test.expected {
+			t.Errorf("expected %q but got %q", test.expected, test.actual)
+		}
+	}
+	if q.Field("c").IsDeprecated() || s.Type("Color").(*Enum).Value("RED").IsDeprecated() {
+		t.Error("expected no deprecation")
+	}
+}
+
+func TestBuildFieldDirectives(t *testing.T) {
+	q := mustBuild(t, `type Query {a: Int @cost(complexity: 2) @deprecated, b: Int}`).QueryType()
+	var names []string
+	for _, d := range q.Field("a").Directives() {
+		names = append(names, d.Name.Value)
+	}
+	if expected := []string{"cost", "deprecated"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v but got %v", expected, names)
+	}
+	if ds := q.Field("b").Directives(); len(ds) != 0 {
+		t.Errorf("expected no directives but got %v", ds)
+	}
+}
+
+func TestBuildDirectives(t *testing.T) {
+	s := mustBuild(t, `"The cost." directive @cost(weight: Int = 1) repeatable on FIELD_DEFINITION | OBJECT
+		directive @tag on VARIABLE_DEFINITION type Query {a: Int @cost}`)
+	if s.Directive("skip") != SkipDirective {
+		t.Error("expected built-in directives")
+	}
+	cost := s.Directive("cost")
+	if cost == nil {
+		t.Fatal("expected directive @cost")
+	}
+	if cost.Description() != "The cost." || !cost.IsRepeatable() {
+		t.Errorf("unexpected directive %q: %q repeatable=%t", cost.Name(), cost.Description(), cost.IsRepeatable())
+	}
+	if expected := []DirectiveLocation{LocationFieldDefinition, LocationObject}; !reflect.DeepEqual(cost.Locations(), expected) {
+		t.Errorf("expected locations %v but got %v", expected, cost.Locations())
+	}
+	if a := cost.Arg("weight"); a == nil || a.Type() != Int {
+		t.Errorf("unexpected argument %v", a)
+	}
+	if tag := s.Directive("tag"); tag == nil || tag.IsRepeatable() || len(tag.Args()) != 0 {
+		t.Errorf("unexpected directive %v", tag)
+	}
+}
+
+func TestBuildBuiltInRedeclared(t *testing.T) {
+	s := mustBuild(t, "scalar String type Query {a: String}")
+	if s.QueryType().Field("a").Type() != String {
+		t.Error("expected built-in String")
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected []string
+	}{
+		{"type Query {a: Unknown}", []string{`unknown type "Unknown"`}},
+		{"type Query {a(b: [Unknown!]): Int}", []string{`unknown type "Unknown"`}},
+		{"type Query {a: Int} type Query {b: Int}", []string{`duplicate type "Query"`}},
+		{"type Query {a: Int} enum Query {A}", []string{`duplicate type "Query"`}},
+		{"type Query {a: Int, a: String}", []string{`duplicate field "a" in "Query"`}},
+		{"type Query {a(b: Int, b: Int): Int}", []string{`duplicate argument "b" in "Query.a"`}},
+		{"enum E {A, A}", []string{`duplicate enum value "A" in "E"`}},
+		{"input I {a: Int, a: Int}", []string{`duplicate input field "a" in "I"`}},
+		{"type Query {a: Int} extend type Query {a: Int}", []string{`duplicate field "a" in "Query"`}},
+		{"extend type Query {a: Int}", []string{`cannot extend unknown type "Query"`}},
+		{"scalar S extend type S {a: Int}", []string{`cannot extend non-object type "S"`}},
+		{"type Query implements Query {a: Int}", []string{`cannot implement non-interface type "Query"`}},
+		{"scalar S union U = S", []string{`union "U" member "S" is not an object type`}},
+		{"scalar S schema {query: S}", []string{`query root type "S" is not an object type`}},
+		{"type Q {a: Int} schema {query: Q, query: Q}", []string{`must provide only one query type in schema`}},
+		{"type Q {a: Int} schema {query: Q} schema {query: Q}", []string{`must provide only one schema definition`}},
+		{"schema {query: Q}", []string{`unknown type "Q"`}},
+		{"{a}", []string{`unexpected operation definition in type system document`}},
+		{"fragment F on Q {a}", []string{`unexpected fragment definition in type system document`}},
+		{"type Query {a: A, b: B}", []string{`unknown type "A"`, `unknown type "B"`}},
+		{"type Query {a: Int} directive @d on FIELD directive @d on FIELD", []string{`duplicate directive "@d"`}},
+		{"type Query {a: Int} directive @skip on FIELD", []string{`duplicate directive "@skip"`}},
+		{"type Query {a: Int} directive @d(a: Int, a: Int) on FIELD", []string{`duplicate argument "a" in "@d"`}},
+		{"type Query {a: Int} directive @d(a: Unknown) on FIELD", []string{`unknown type "Unknown"`}},
+		{"type Query {a: Int} directive @d on FIELD | NOWHERE", []string{`unknown directive location "NOWHERE" in "@d"`}},
+	} {
+		s, err := Build(mustParse(t, test.input))
+		if err == nil {
+			t.Errorf("input %q: expected errors but got schema %v", test.input, s)
+			continue
+		}
+		list, ok := err.(errors.List)
+		if !ok {
+			t.Errorf("input %q: expected errors.List but got %T", test.input, err)
+			continue
+		}
+		var messages []string
+		for _, e := range list {
+			messages = append(messages, e.Message)
+			if len(e.Locations) != 1 || e.Locations[0].End == 0 {
+				t.Errorf("input %q: expected a location for error %q but got %v", test.input, e.Message, e.Locations)
+			}
+		}
+		if !reflect.DeepEqual(messages, test.expected) {
+			t.Errorf("input %q: expected errors %q but got %q", test.input, test.expected, messages)
+		}
+	}
+}
+
+func TestBuildErrorLocation(t *testing.T) {
+	_, err := Build(mustParse(t, "type Query {a: Unknown}"))
+	list := err.(errors.List)
+	if expected := (ast.Loc{Start: 15, End: 21}); len(list) != 1 || !reflect.DeepEqual(list[0].Locations, []ast.Loc{expected}) {
+		t.Errorf("expected location %v but got %v", expected, list)
+	}
+}
@@ -0,0 +1,509 @@
+package schema
+
+import (
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// A SchemaBuilder builds a Schema from Go code, as an alternative to SDL.
+//
+// Type builders generate ast definitions, including descriptions and deprecations, which are built into a Schema by
+// the Build function exactly like SDL documents. Resolvers and other properties which can not be expressed in SDL are
+// then applied to the built types.
+// Example:
+//
+//	s, err := schema.NewSchema().
+//		Query(schema.NewObject("Query").
+//			Field("user", schema.Ref("User"), resolveUser, schema.Arg("id", schema.NonNull(schema.ID), nil))).
+//		Types(schema.NewObject("User").
+//			Field("id", schema.NonNull(schema.ID), nil).
+//			Field("friends", schema.List(schema.Ref("User")), resolveFriends)).
+//		Build()
+type SchemaBuilder struct {
+	types                         []TypeBuilder
+	query, mutation, subscription string
+}
+
+// The NewSchema function returns an empty SchemaBuilder.
+func NewSchema() *SchemaBuilder {
+	return &SchemaBuilder{}
+}
+
+// The Query method adds o as the query root type.
+func (b *SchemaBuilder) Query(o *ObjectBuilder) *SchemaBuilder {
+	b.query = o.name
+	return b.Types(o)
+}
+
+// The Mutation method adds o as the mutation root type.
+func (b *SchemaBuilder) Mutation(o *ObjectBuilder) *SchemaBuilder {
+	b.mutation = o.name
+	return b.Types(o)
+}
+
+// The Subscription method adds o as the subscription root type.
+func (b *SchemaBuilder) Subscription(o *ObjectBuilder) *SchemaBuilder {
+	b.subscription = o.name
+	return b.Types(o)
+}
+
+// The Types method adds types to the schema.
+func (b *SchemaBuilder) Types(types ...TypeBuilder) *SchemaBuilder {
+	b.types = append(b.types, types...)
+	return b
+}
+
+// The Document method returns the type system definitions of the schema. A SchemaDef is included only if the root
+// types are not named Query, Mutation, and Subscription.
+func (b *SchemaBuilder) Document() *ast.Document {
+	d := &ast.Document{}
+	if sd := schemaDef(b.query, b.mutation, b.subscription); sd != nil {
+		d.Definitions = append(d.Definitions, sd)
+	}
+	for _, t := range b.types {
+		d.Definitions = append(d.Definitions, t.TypeDef())
+	}
+	return d
+}
+
+// The Build method builds the Schema.
+func (b *SchemaBuilder) Build() (*Schema, error) {
+	s, err := Build(b.Document())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range b.types {
+		t.apply(s)
+	}
+	return s, nil
+}
+
+// The schemaDef function returns a SchemaDef declaring the named root types, or nil if they all have their default
+// names. Empty names are omitted.
+func schemaDef(query, mutation, subscription string) *ast.SchemaDef {
+	if (query == "" || query == "Query") && (mutation == "" || mutation == "Mutation") &&
+		(subscription == "" || subscription == "Subscription") {
+		return nil
+	}
+	sd := &ast.SchemaDef{}
+	for _, root := range []struct {
+		ast.OpType
+		name string
+	}{{ast.Query, query}, {ast.Mutation, mutation}, {ast.Subscription, subscription}} {
+		if root.name != "" {
+			sd.OpTypeDefs = append(sd.OpTypeDefs, ast.OpTypeDef{OpType: root.OpType, NamedType: ast.NamedType{Value: root.name}})
+		}
+	}
+	return sd
+}
+
+// A TypeBuilder builds a named type from Go code.
+type TypeBuilder interface {
+	// The TypeDef method returns the definition of the type.
+	TypeDef() ast.TypeDef
+	// The apply method configures the type built from the TypeDef in s, with the properties the TypeDef can not hold.
+	apply(s *Schema)
+}
+
+// The Ref function returns a reference to the type named name, for referring to types which are built separately,
+// or recursively.
+func Ref(name string) NamedType {
+	return &typeRef{name}
+}
+
+// A typeRef is a reference to a NamedType by name. It only exists within builders.
+type typeRef struct {
+	name string
+}
+
+func (*typeRef) Kind() Kind            { return Kind(-1) }
+func (r *typeRef) String() string      { return r.name }
+func (r *typeRef) Name() string        { return r.name }
+func (r *typeRef) Description() string { return "" }
+func (r *typeRef) Loc() ast.Loc        { return ast.Loc{} }
+
+// The refType function returns the ast reference to t.
+func refType(t Type) ast.RefType {
+	switch t := t.(type) {
+	case *ListType:
+		return &ast.ListType{RefType: refType(t.ofType)}
+	case *NonNullType:
+		return &ast.NonNullType{RefType: refType(t.ofType)}
+	case NamedType:
+		return &ast.NamedType{Value: t.Name()}
+	}
+	return nil
+}
+
+// A FieldOption configures a field built by an ObjectBuilder or InterfaceBuilder.
+type FieldOption func(*fieldConfig)
+
+// The Arg function returns a FieldOption adding an argument to a field. defaultValue may be nil.
+func Arg(name string, t Type, defaultValue ast.Value) FieldOption {
+	return func(f *fieldConfig) {
+		f.args = append(f.args, inputValueConfig{name, t, defaultValue})
+	}
+}
+
+// The Deprecated function returns a FieldOption marking a field as deprecated, for reason.
+func Deprecated(reason string) FieldOption {
+	return func(f *fieldConfig) {
+		f.deprecationReason = &reason
+	}
+}
+
+// The Describe function returns a FieldOption setting the description of a field.
+func Describe(description string) FieldOption {
+	return func(f *fieldConfig) {
+		f.description = description
+	}
+}
+
+type fieldConfig struct {
+	name              string
+	description       string
+	typ               Type
+	args              []inputValueConfig
+	deprecationReason *string
+	resolve           ResolveFunc
+}
+
+func newFieldConfig(name string, t Type, resolve ResolveFunc, opts []FieldOption) *fieldConfig {
+	f := &fieldConfig{name: name, typ: t, resolve: resolve}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func fieldDefs(fs []*fieldConfig) []ast.FieldDef {
+	fds := make([]ast.FieldDef, len(fs))
+	for i, f := range fs {
+		fds[i] = ast.FieldDef{
+			Description: descriptionOf(f.description),
+			Name:        ast.Name{Value: f.name},
+			Arguments:   inputValueDefs(f.args),
+			RefType:     refType(f.typ),
+			Directives:  deprecatedDirectivesOf(f.deprecationReason),
+		}
+	}
+	return fds
+}
+
+// The applyFields function sets the resolvers of the fields built from fs.
+func applyFields(fs []*fieldConfig, byName map[string]*Field) {
+	for _, fc := range fs {
+		if f := byName[fc.name]; f != nil {
+			f.resolve = fc.resolve
+		}
+	}
+}
+
+type inputValueConfig struct {
+	name         string
+	typ          Type
+	defaultValue ast.Value
+}
+
+func inputValueDefs(vs []inputValueConfig) []ast.InputValueDef {
+	if len(vs) == 0 {
+		return nil
+	}
+	ivds := make([]ast.InputValueDef, len(vs))
+	for i, v := range vs {
+		ivds[i] = ast.InputValueDef{
+			Name:         ast.Name{Value: v.name},
+			RefType:      refType(v.typ),
+			DefaultValue: v.defaultValue,
+		}
+	}
+	return ivds
+}
+
+func namedTypes(names []string) []ast.NamedType {
+	if len(names) == 0 {
+		return nil
+	}
+	nts := make([]ast.NamedType, len(names))
+	for i, n := range names {
+		nts[i] = ast.NamedType{Value: n}
+	}
+	return nts
+}
+
+// An ObjectBuilder builds an Object.
+type ObjectBuilder struct {
+	name        string
+	description string
+	interfaces  []string
+	fields      []*fieldConfig
+}
+
+// The NewObject function returns an ObjectBuilder for an Object named name.
+func NewObject(name string) *ObjectBuilder {
+	return &ObjectBuilder{name: name}
+}
+
+// The Description method sets the description of the Object.
+func (b *ObjectBuilder) Description(description string) *ObjectBuilder {
+	b.description = description
+	return b
+}
+
+// The Implements method adds the interfaces named names to the Object.
+func (b *ObjectBuilder) Implements(names ...string) *ObjectBuilder {
+	b.interfaces = append(b.interfaces, names...)
+	return b
+}
+
+// The Field method adds a field of type t to the Object. resolve may be nil.
+func (b *ObjectBuilder) Field(name string, t Type, resolve ResolveFunc, opts ...FieldOption) *ObjectBuilder {
+	b.fields = append(b.fields, newFieldConfig(name, t, resolve, opts))
+	return b
+}
+
+func (b *ObjectBuilder) TypeDef() ast.TypeDef {
+	return &ast.ObjTypeDef{
+		Description: descriptionOf(b.description),
+		Name:        ast.Name{Value: b.name},
+		Interfaces:  namedTypes(b.interfaces),
+		FieldDefs:   fieldDefs(b.fields),
+	}
+}
+
+func (b *ObjectBuilder) apply(s *Schema) {
+	if o, ok := s.Type(b.name).(*Object); ok {
+		applyFields(b.fields, o.fieldsByName)
+	}
+}
+
+// An InterfaceBuilder builds an Interface.
+type InterfaceBuilder struct {
+	name        string
+	description string
+	fields      []*fieldConfig
+	resolveType ResolveTypeFunc
+}
+
+// The NewInterface function returns an InterfaceBuilder for an Interface named name.
+func NewInterface(name string) *InterfaceBuilder {
+	return &InterfaceBuilder{name: name}
+}
+
+// The Description method sets the description of the Interface.
+func (b *InterfaceBuilder) Description(description string) *InterfaceBuilder {
+	b.description = description
+	return b
+}
+
+// The Field method adds a field of type t to the Interface.
+func (b *InterfaceBuilder) Field(name string, t Type, opts ...FieldOption) *InterfaceBuilder {
+	b.fields = append(b.fields, newFieldConfig(name, t, nil, opts))
+	return b
+}
+
+// The ResolveType method sets the function resolving the runtime types of values of the Interface.
+func (b *InterfaceBuilder) ResolveType(resolveType ResolveTypeFunc) *InterfaceBuilder {
+	b.resolveType = resolveType
+	return b
+}
+
+func (b *InterfaceBuilder) TypeDef() ast.TypeDef {
+	return &ast.InterfaceTypeDef{
+		Description: descriptionOf(b.description),
+		Name:        ast.Name{Value: b.name},
+		FieldDefs:   fieldDefs(b.fields),
+	}
+}
+
+func (b *InterfaceBuilder) apply(s *Schema) {
+	if i, ok := s.Type(b.name).(*Interface); ok {
+		i.resolveType = b.resolveType
+		applyFields(b.fields, i.fieldsByName)
+	}
+}
+
+// A UnionBuilder builds a Union.
+type UnionBuilder struct {
+	name        string
+	description string
+	types       []string
+	resolveType ResolveTypeFunc
+}
+
+// The NewUnion function returns a UnionBuilder for a Union named name.
+func NewUnion(name string) *UnionBuilder {
+	return &UnionBuilder{name: name}
+}
+
+// The Description method sets the description of the Union.
+func (b *UnionBuilder) Description(description string) *UnionBuilder {
+	b.description = description
+	return b
+}
+
+// The Types method adds the Objects named names as members of the Union.
+func (b *UnionBuilder) Types(names ...string) *UnionBuilder {
+	b.types = append(b.types, names...)
+	return b
+}
+
+// The ResolveType method sets the function resolving the runtime types of values of the Union.
+func (b *UnionBuilder) ResolveType(resolveType ResolveTypeFunc) *UnionBuilder {
+	b.resolveType = resolveType
+	return b
+}
+
+func (b *UnionBuilder) TypeDef() ast.TypeDef {
+	return &ast.UnionTypeDef{
+		Description: descriptionOf(b.description),
+		Name:        ast.Name{Value: b.name},
+		NamedTypes:  namedTypes(b.types),
+	}
+}
+
+func (b *UnionBuilder) apply(s *Schema) {
+	if u, ok := s.Type(b.name).(*Union); ok {
+		u.resolveType = b.resolveType
+	}
+}
+
+// An EnumBuilder builds an Enum.
+type EnumBuilder struct {
+	name        string
+	description string
+	values      []enumValueConfig
+}
+
+type enumValueConfig struct {
+	name              string
+	value             interface{}
+	deprecationReason *string
+}
+
+// The NewEnum function returns an EnumBuilder for an Enum named name.
+func NewEnum(name string) *EnumBuilder {
+	return &EnumBuilder{name: name}
+}
+
+// The Description method sets the description of the Enum.
+func (b *EnumBuilder) Description(description string) *EnumBuilder {
+	b.description = description
+	return b
+}
+
+// The Value method adds a value named name to the Enum, representing the internal value.
+func (b *EnumBuilder) Value(name string, value interface{}) *EnumBuilder {
+	b.values = append(b.values, enumValueConfig{name: name, value: value})
+	return b
+}
+
+// The DeprecatedValue method adds a value named name to the Enum, representing the internal value, and deprecated for
+// reason.
+func (b *EnumBuilder) DeprecatedValue(name string, value interface{}, reason string) *EnumBuilder {
+	b.values = append(b.values, enumValueConfig{name: name, value: value, deprecationReason: &reason})
+	return b
+}
+
+func (b *EnumBuilder) TypeDef() ast.TypeDef {
+	evds := make([]ast.EnumValueDef, len(b.values))
+	for i, v := range b.values {
+		evds[i] = ast.EnumValueDef{
+			Name:       ast.Name{Value: v.name},
+			Directives: deprecatedDirectivesOf(v.deprecationReason),
+		}
+	}
+	return &ast.EnumTypeDef{
+		Description:   descriptionOf(b.description),
+		Name:          ast.Name{Value: b.name},
+		EnumValueDefs: evds,
+	}
+}
+
+func (b *EnumBuilder) apply(s *Schema) {
+	if e, ok := s.Type(b.name).(*Enum); ok {
+		for _, vc := range b.values {
+			if v := e.valuesByName[vc.name]; v != nil {
+				v.value = vc.value
+			}
+		}
+	}
+}
+
+// An InputObjectBuilder builds an InputObject.
+type InputObjectBuilder struct {
+	name        string
+	description string
+	fields      []inputValueConfig
+}
+
+// The NewInputObject function returns an InputObjectBuilder for an InputObject named name.
+func NewInputObject(name string) *InputObjectBuilder {
+	return &InputObjectBuilder{name: name}
+}
+
+// The Description method sets the description of the InputObject.
+func (b *InputObjectBuilder) Description(description string) *InputObjectBuilder {
+	b.description = description
+	return b
+}
+
+// The Field method adds a field of type t to the InputObject. defaultValue may be nil.
+func (b *InputObjectBuilder) Field(name string, t Type, defaultValue ast.Value) *InputObjectBuilder {
+	b.fields = append(b.fields, inputValueConfig{name, t, defaultValue})
+	return b
+}
+
+func (b *InputObjectBuilder) TypeDef() ast.TypeDef {
+	return &ast.InputObjTypeDef{
+		Description: descriptionOf(b.description),
+		Name:        ast.Name{Value: b.name},
+		Fields:      inputValueDefs(b.fields),
+	}
+}
+
+func (b *InputObjectBuilder) apply(s *Schema) {}
+
+// A ScalarBuilder builds a Scalar.
+type ScalarBuilder struct {
+	name        string
+	description string
+	specifiedBy string
+	coercer     Coercer
+}
+
+// The NewScalar function returns a ScalarBuilder for a Scalar named name.
+func NewScalar(name string) *ScalarBuilder {
+	return &ScalarBuilder{name: name}
+}
+
+// The Description method sets the description of the Scalar.
+func (b *ScalarBuilder) Description(description string) *ScalarBuilder {
+	b.description = description
+	return b
+}
+
+// The SpecifiedByURL method sets the URL of the specification of the Scalar.
+func (b *ScalarBuilder) SpecifiedByURL(url string) *ScalarBuilder {
+	b.specifiedBy = url
+	return b
+}
+
+// The Coercer method sets the Coercer of the Scalar, overriding any registered with the RegisterScalar function.
+func (b *ScalarBuilder) Coercer(c Coercer) *ScalarBuilder {
+	b.coercer = c
+	return b
+}
+
+func (b *ScalarBuilder) TypeDef() ast.TypeDef {
+	return &ast.ScalarTypeDef{
+		Description: descriptionOf(b.description),
+		Name:        ast.Name{Value: b.name},
+		Directives:  specifiedByDirectives(b.specifiedBy),
+	}
+}
+
+func (b *ScalarBuilder) apply(s *Schema) {
+	if sc, ok := s.Type(b.name).(*Scalar); ok && !isBuiltInScalar(sc.name) && b.coercer != nil {
+		sc.coercer = b.coercer
+	}
+}
@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+func resolveConst(v interface{}) ResolveFunc {
+	return func(context.Context, interface{}, map[string]interface{}, *ResolveInfo) (interface{}, error) {
+		return v, nil
+	}
+}
+
+func testBuilder() *SchemaBuilder {
+	return NewSchema().
+		Query(NewObject("Root").
+			Field("user", Ref("User"), resolveConst("user"),
+				Arg("id", NonNull(ID), nil),
+				Arg("tags", List(String), &ast.List{Values: []ast.Value{&ast.String{Value: "a"}}})).
+			Field("node", Ref("Node"), nil, Arg("id", NonNull(ID), nil)).
+			Field("search", NonNull(List(NonNull(Ref("SearchResult")))), nil, Arg("term", String, nil))).
+		Mutation(NewObject("Mutation").
+			Field("setName", Ref("User"), nil, Arg("input", Ref("NameInput"), nil))).
+		Types(
+			NewInterface("Node").
+				Description("An object with an ID.").
+				Field("id", NonNull(ID)),
+			NewObject("User").
+				Description("A user.").
+				Implements("Node").
+				Field("id", NonNull(ID), resolveConst("1")).
+				Field("name", String, nil, Describe("The name."), Deprecated("Use fullName.")).
+				Field("role", Ref("Role"), nil),
+			NewObject("Photo").
+				Implements("Node").
+				Field("id", NonNull(ID), nil).
+				Field("url", Ref("URL"), nil),
+			NewUnion("SearchResult").Types("User", "Photo"),
+			NewEnum("Role").Value("ADMIN", 1).DeprecatedValue("USER", 2, "Everyone is an admin."),
+			NewInputObject("NameInput").
+				Field("id", NonNull(ID), nil).
+				Field("name", NonNull(String), &ast.String{Value: "anonymous"}),
+			NewScalar("URL").Description("A URL."),
+		)
+}
+
+// The summarize function returns a description of the types and fields of s, for comparison.
+func summarize(t *testing.T, s *Schema) []string {
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+	value := func(v ast.Value) string {
+		if v == nil {
+			return ""
+		}
+		var b bytes.Buffer
+		if err := printer.Compact.Fprint(&b, v); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+	for _, t := range s.Types() {
+		add("%s %s", t.Kind(), t.Name())
+		switch t := t.(type) {
+		case *Object:
+			for _, i := range t.Interfaces() {
+				add("\timplements %s", i.Name())
+			}
+			for _, f := range t.Fields() {
+				add("\t%s: %s", f.Name(), f.Type())
+				for _, a := range f.Args() {
+					add("\t\t%s: %s = %s", a.Name(), a.Type(), value(a.DefaultValue()))
+				}
+			}
+		case *Interface:
+			for _, f := range t.Fields() {
+				add("\t%s: %s", f.Name(), f.Type())
+			}
+		case *Union:
+			for _, o := range t.Types() {
+				add("\t%s", o.Name())
+			}
+		case *Enum:
+			for _, v := range t.Values() {
+				add("\t%s", v.Name())
+			}
+		case *InputObject:
+			for _, f := range t.Fields() {
+				add("\t%s: %s = %s", f.Name(), f.Type(), value(f.DefaultValue()))
+			}
+		}
+	}
+	add("query %v mutation %v subscription %v", s.QueryType(), s.MutationType(), s.SubscriptionType())
+	return lines
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	s, err := testBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same schema as from SDL.
+	expected := summarize(t, mustBuild(t, testSDL))
+	if actual := summarize(t, s); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected:\n%q\nbut got:\n%q", expected, actual)
+	}
+
+	// Resolvers.
+	user := s.Type("User").(*Object)
+	for _, test := range []struct {
+		field    *Field
+		expected interface{}
+	}{
+		{s.QueryType().Field("user"), "user"},
+		{user.Field("id"), "1"},
+	} {
+		if test.field.Resolve() == nil {
+			t.Errorf("expected resolver for field %q", test.field.Name())
+			continue
+		}
+		if v, err := test.field.Resolve()(context.Background(), nil, nil, nil); err != nil {
+			t.Error(err)
+		} else if v != test.expected {
+			t.Errorf("expected %v but got %v", test.expected, v)
+		}
+	}
+	if user.Field("role").Resolve() != nil {
+		t.Error("expected nil resolver")
+	}
+
+	// Descriptions and deprecations.
+	if d := user.Description(); d != "A user." {
+		t.Errorf("unexpected description %q", d)
+	}
+	if d := s.Type("URL").Description(); d != "A URL." {
+		t.Errorf("unexpected description %q", d)
+	}
+	name := user.Field("name")
+	if name.Description() != "The name." || !name.IsDeprecated() || name.DeprecationReason() != "Use fullName." {
+		t.Errorf("unexpected field %q: %q deprecated=%t %q",
+			name.Name(), name.Description(), name.IsDeprecated(), name.DeprecationReason())
+	}
+	if user.Field("id").IsDeprecated() {
+		t.Error("unexpected deprecation")
+	}
+	role := s.Type("Role").(*Enum)
+	if v := role.Value("ADMIN"); v.Value() != 1 || v.IsDeprecated() {
+		t.Errorf("unexpected enum value %q: %v", v.Name(), v.Value())
+	}
+	if v := role.Value("USER"); v.Value() != 2 || v.DeprecationReason() != "Everyone is an admin." {
+		t.Errorf("unexpected enum value %q: %v %q", v.Name(), v.Value(), v.DeprecationReason())
+	}
+}
+
+func TestSchemaBuilderDocument(t *testing.T) {
+	var b bytes.Buffer
+	for _, d := range testBuilder().Document().Definitions {
+		if err := printer.Compact.Fprint(&b, d); err != nil {
+			t.Fatal(err)
+		}
+		b.WriteString("\n")
+	}
+	expected := `schema{query:Root,mutation:Mutation}
+type Root{user(id:ID!,tags:[String]=["a"]):User,node(id:ID!):Node,search(term:String):[SearchResult!]!}
+type Mutation{setName(input:NameInput):User}
+"An object with an ID." interface Node{id:ID!}
+"A user." type User implements Node{id:ID!,"The name." name:String@deprecated(reason:"Use fullName."),role:Role}
+type Photo implements Node{id:ID!,url:URL}
+union SearchResult=User|Photo
+enum Role{ADMIN,USER@deprecated(reason:"Everyone is an admin.")}
+input NameInput{id:ID!,name:String!="anonymous"}
+"A URL." scalar URL
+`
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, b.String())
+	}
+
+	// The document round-trips through Build and Print.
+	s, err := Build(testBuilder().Document())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Reset()
+	if err := Print(&b, s, printer.Compact, false); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, b.String())
+	}
+
+	// Default root names need no schema definition.
+	d := NewSchema().Query(NewObject("Query").Field("a", Int, nil)).Document()
+	if len(d.Definitions) != 1 {
+		t.Errorf("expected a single definition but got %d", len(d.Definitions))
+	}
+}
+
+func TestSchemaBuilderErrors(t *testing.T) {
+	_, err := NewSchema().
+		Query(NewObject("Query").Field("a", Ref("Unknown"), nil)).
+		Build()
+	if err == nil || err.Error() != `unknown type "Unknown" (at position 0)` {
+		t.Errorf("expected unknown type error but got %v", err)
+	}
+}
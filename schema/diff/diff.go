@@ -0,0 +1,475 @@
+// Package diff compares Schemas, reporting the changes between them classified by their impact on existing clients.
+//
+// Changes are detected by name, so both Schemas are typically built from separate versions of the same SDL. Each
+// Change holds the locations of the affected definitions in both sources, when they exist.
+//
+// The classification follows the javascript reference implementation:
+// https://github.com/graphql/graphql-js/blob/master/src/utilities/findBreakingChanges.js
+package diff
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+	"github.com/jmank88/gql/schema"
+)
+
+// The Criticality of a Change describes its impact on existing clients.
+type Criticality int
+
+const (
+	// Safe changes do not affect existing clients.
+	Safe Criticality = iota
+	// Dangerous changes do not break existing operations, but may change their results, e.g. a new enum value which
+	// clients do not expect.
+	Dangerous
+	// Breaking changes cause existing operations to fail validation or execution.
+	Breaking
+)
+
+var criticalityStrings = map[Criticality]string{
+	Safe:      "SAFE",
+	Dangerous: "DANGEROUS",
+	Breaking:  "BREAKING",
+}
+
+func (c Criticality) String() string {
+	if s, ok := criticalityStrings[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("Criticality(%d)", int(c))
+}
+
+// The ChangeType identifies what changed.
+type ChangeType int
+
+const (
+	TypeAdded ChangeType = iota
+	TypeRemoved
+	TypeKindChanged
+	RootTypeChanged
+	FieldAdded
+	FieldRemoved
+	FieldTypeChanged
+	FieldDeprecated
+	ArgAdded
+	ArgRemoved
+	ArgTypeChanged
+	ArgDefaultChanged
+	InputFieldAdded
+	InputFieldRemoved
+	InputFieldTypeChanged
+	InputFieldDefaultChanged
+	EnumValueAdded
+	EnumValueRemoved
+	EnumValueDeprecated
+	UnionMemberAdded
+	UnionMemberRemoved
+	InterfaceAdded
+	InterfaceRemoved
+)
+
+var changeTypeStrings = map[ChangeType]string{
+	TypeAdded:                "TYPE_ADDED",
+	TypeRemoved:              "TYPE_REMOVED",
+	TypeKindChanged:          "TYPE_KIND_CHANGED",
+	RootTypeChanged:          "ROOT_TYPE_CHANGED",
+	FieldAdded:               "FIELD_ADDED",
+	FieldRemoved:             "FIELD_REMOVED",
+	FieldTypeChanged:         "FIELD_TYPE_CHANGED",
+	FieldDeprecated:          "FIELD_DEPRECATED",
+	ArgAdded:                 "ARG_ADDED",
+	ArgRemoved:               "ARG_REMOVED",
+	ArgTypeChanged:           "ARG_TYPE_CHANGED",
+	ArgDefaultChanged:        "ARG_DEFAULT_CHANGED",
+	InputFieldAdded:          "INPUT_FIELD_ADDED",
+	InputFieldRemoved:        "INPUT_FIELD_REMOVED",
+	InputFieldTypeChanged:    "INPUT_FIELD_TYPE_CHANGED",
+	InputFieldDefaultChanged: "INPUT_FIELD_DEFAULT_CHANGED",
+	EnumValueAdded:           "ENUM_VALUE_ADDED",
+	EnumValueRemoved:         "ENUM_VALUE_REMOVED",
+	EnumValueDeprecated:      "ENUM_VALUE_DEPRECATED",
+	UnionMemberAdded:         "UNION_MEMBER_ADDED",
+	UnionMemberRemoved:       "UNION_MEMBER_REMOVED",
+	InterfaceAdded:           "INTERFACE_ADDED",
+	InterfaceRemoved:         "INTERFACE_REMOVED",
+}
+
+func (t ChangeType) String() string {
+	if s, ok := changeTypeStrings[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(t))
+}
+
+// A Change is a single difference between two Schemas.
+type Change struct {
+	Type ChangeType
+	Criticality
+	// The Path of the changed element, e.g. "User", "User.email", or "Query.user(id:)".
+	Path    string
+	Message string
+	// The locations of the changed element in the old and new sources. A Loc is zero when the element does not exist in
+	// that Schema, or was not defined in a Document.
+	OldLoc, NewLoc ast.Loc
+}
+
+// The String method returns a summary of c, e.g. "BREAKING: Field User.email was removed.".
+func (c *Change) String() string {
+	return c.Criticality.String() + ": " + c.Message
+}
+
+// The Diff function returns the Changes from oldSchema to newSchema, in the order of the old types followed by added
+// types.
+func Diff(oldSchema, newSchema *schema.Schema) []*Change {
+	d := &differ{}
+	d.roots(oldSchema, newSchema)
+	for _, ot := range oldSchema.Types() {
+		if schema.IsIntrospectionType(ot) {
+			continue
+		}
+		nt := newSchema.Type(ot.Name())
+		if nt == nil {
+			d.add(TypeRemoved, Breaking, ot.Name(), ot.Loc(), ast.Loc{}, "Type %s was removed.", ot.Name())
+			continue
+		}
+		if ot.Kind() != nt.Kind() {
+			d.add(TypeKindChanged, Breaking, ot.Name(), ot.Loc(), nt.Loc(),
+				"Type %s changed from %s to %s.", ot.Name(), kindName(ot.Kind()), kindName(nt.Kind()))
+			continue
+		}
+		d.namedType(ot, nt)
+	}
+	for _, nt := range newSchema.Types() {
+		if oldSchema.Type(nt.Name()) == nil {
+			d.add(TypeAdded, Safe, nt.Name(), ast.Loc{}, nt.Loc(), "Type %s was added.", nt.Name())
+		}
+	}
+	return d.changes
+}
+
+// The Filter function returns the Changes with at least Criticality min.
+func Filter(changes []*Change, min Criticality) []*Change {
+	var filtered []*Change
+	for _, c := range changes {
+		if c.Criticality >= min {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// A differ accumulates Changes.
+type differ struct {
+	changes []*Change
+}
+
+func (d *differ) add(t ChangeType, c Criticality, path string, oldLoc, newLoc ast.Loc, format string, args ...interface{}) {
+	d.changes = append(d.changes, &Change{
+		Type:        t,
+		Criticality: c,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+		OldLoc:      oldLoc,
+		NewLoc:      newLoc,
+	})
+}
+
+func (d *differ) roots(oldSchema, newSchema *schema.Schema) {
+	for _, root := range []struct {
+		op       string
+		old, new *schema.Object
+	}{
+		{"query", oldSchema.QueryType(), newSchema.QueryType()},
+		{"mutation", oldSchema.MutationType(), newSchema.MutationType()},
+		{"subscription", oldSchema.SubscriptionType(), newSchema.SubscriptionType()},
+	} {
+		oldName, newName := objectName(root.old), objectName(root.new)
+		if oldName == newName {
+			continue
+		}
+		c := Breaking
+		if root.old == nil {
+			c = Safe
+		}
+		d.add(RootTypeChanged, c, root.op, objectLoc(root.old), objectLoc(root.new),
+			"The %s root type changed from %q to %q.", root.op, oldName, newName)
+	}
+}
+
+// The namedType method compares types of the same kind.
+func (d *differ) namedType(ot, nt schema.NamedType) {
+	switch ot := ot.(type) {
+	case *schema.Object:
+		nt := nt.(*schema.Object)
+		d.interfaces(ot, nt)
+		d.fields(ot.Name(), ot.Fields(), nt.Fields())
+	case *schema.Interface:
+		d.fields(ot.Name(), ot.Fields(), nt.(*schema.Interface).Fields())
+	case *schema.Union:
+		d.unionMembers(ot, nt.(*schema.Union))
+	case *schema.Enum:
+		d.enumValues(ot, nt.(*schema.Enum))
+	case *schema.InputObject:
+		d.inputFields(ot, nt.(*schema.InputObject))
+	}
+}
+
+func (d *differ) interfaces(ot, nt *schema.Object) {
+	for _, oi := range ot.Interfaces() {
+		if !implements(nt, oi.Name()) {
+			d.add(InterfaceRemoved, Breaking, ot.Name(), ot.Loc(), nt.Loc(),
+				"%s no longer implements interface %s.", ot.Name(), oi.Name())
+		}
+	}
+	for _, ni := range nt.Interfaces() {
+		if !implements(ot, ni.Name()) {
+			d.add(InterfaceAdded, Dangerous, ot.Name(), ot.Loc(), nt.Loc(),
+				"%s now implements interface %s.", ot.Name(), ni.Name())
+		}
+	}
+}
+
+func (d *differ) fields(typeName string, ofs, nfs []*schema.Field) {
+	for _, of := range ofs {
+		path := typeName + "." + of.Name()
+		nf := field(nfs, of.Name())
+		if nf == nil {
+			d.add(FieldRemoved, Breaking, path, of.Loc(), ast.Loc{}, "Field %s was removed.", path)
+			continue
+		}
+		if !safeOutputChange(of.Type(), nf.Type()) {
+			d.add(FieldTypeChanged, Breaking, path, of.Loc(), nf.Loc(),
+				"Field %s changed type from %s to %s.", path, of.Type(), nf.Type())
+		} else if of.Type().String() != nf.Type().String() {
+			d.add(FieldTypeChanged, Safe, path, of.Loc(), nf.Loc(),
+				"Field %s changed type from %s to %s.", path, of.Type(), nf.Type())
+		}
+		if !of.IsDeprecated() && nf.IsDeprecated() {
+			d.add(FieldDeprecated, Safe, path, of.Loc(), nf.Loc(), "Field %s was deprecated.", path)
+		}
+		d.args(path, of, nf)
+	}
+	for _, nf := range nfs {
+		if field(ofs, nf.Name()) == nil {
+			path := typeName + "." + nf.Name()
+			d.add(FieldAdded, Safe, path, ast.Loc{}, nf.Loc(), "Field %s was added.", path)
+		}
+	}
+}
+
+func (d *differ) args(fieldPath string, of, nf *schema.Field) {
+	for _, oa := range of.Args() {
+		path := fieldPath + "(" + oa.Name() + ":)"
+		na := nf.Arg(oa.Name())
+		if na == nil {
+			d.add(ArgRemoved, Breaking, path, oa.Loc(), ast.Loc{}, "Argument %s was removed.", path)
+			continue
+		}
+		d.inputValue(ArgTypeChanged, ArgDefaultChanged, "Argument", path, oa, na)
+	}
+	for _, na := range nf.Args() {
+		if of.Arg(na.Name()) == nil {
+			path := fieldPath + "(" + na.Name() + ":)"
+			if required(na) {
+				d.add(ArgAdded, Breaking, path, ast.Loc{}, na.Loc(), "Required argument %s was added.", path)
+			} else {
+				d.add(ArgAdded, Dangerous, path, ast.Loc{}, na.Loc(), "Optional argument %s was added.", path)
+			}
+		}
+	}
+}
+
+func (d *differ) inputFields(ot, nt *schema.InputObject) {
+	for _, of := range ot.Fields() {
+		path := ot.Name() + "." + of.Name()
+		nf := nt.Field(of.Name())
+		if nf == nil {
+			d.add(InputFieldRemoved, Breaking, path, of.Loc(), ast.Loc{}, "Input field %s was removed.", path)
+			continue
+		}
+		d.inputValue(InputFieldTypeChanged, InputFieldDefaultChanged, "Input field", path, of, nf)
+	}
+	for _, nf := range nt.Fields() {
+		if ot.Field(nf.Name()) == nil {
+			path := ot.Name() + "." + nf.Name()
+			if required(nf) {
+				d.add(InputFieldAdded, Breaking, path, ast.Loc{}, nf.Loc(), "Required input field %s was added.", path)
+			} else {
+				d.add(InputFieldAdded, Dangerous, path, ast.Loc{}, nf.Loc(), "Optional input field %s was added.", path)
+			}
+		}
+	}
+}
+
+// The inputValue method compares the types and default values of an argument or input field.
+func (d *differ) inputValue(typeChanged, defaultChanged ChangeType, what, path string, ov, nv *schema.InputValue) {
+	if !safeInputChange(ov.Type(), nv.Type()) {
+		d.add(typeChanged, Breaking, path, ov.Loc(), nv.Loc(),
+			"%s %s changed type from %s to %s.", what, path, ov.Type(), nv.Type())
+	} else if ov.Type().String() != nv.Type().String() {
+		d.add(typeChanged, Safe, path, ov.Loc(), nv.Loc(),
+			"%s %s changed type from %s to %s.", what, path, ov.Type(), nv.Type())
+	}
+	if od, nd := valueString(ov.DefaultValue()), valueString(nv.DefaultValue()); od != nd {
+		d.add(defaultChanged, Dangerous, path, ov.Loc(), nv.Loc(),
+			"%s %s changed default value from %q to %q.", what, path, od, nd)
+	}
+}
+
+func (d *differ) enumValues(ot, nt *schema.Enum) {
+	for _, ov := range ot.Values() {
+		path := ot.Name() + "." + ov.Name()
+		nv := nt.Value(ov.Name())
+		if nv == nil {
+			d.add(EnumValueRemoved, Breaking, path, ov.Loc(), ast.Loc{}, "Enum value %s was removed.", path)
+			continue
+		}
+		if !ov.IsDeprecated() && nv.IsDeprecated() {
+			d.add(EnumValueDeprecated, Safe, path, ov.Loc(), nv.Loc(), "Enum value %s was deprecated.", path)
+		}
+	}
+	for _, nv := range nt.Values() {
+		if ot.Value(nv.Name()) == nil {
+			path := ot.Name() + "." + nv.Name()
+			d.add(EnumValueAdded, Dangerous, path, ast.Loc{}, nv.Loc(), "Enum value %s was added.", path)
+		}
+	}
+}
+
+func (d *differ) unionMembers(ot, nt *schema.Union) {
+	for _, om := range ot.Types() {
+		if !hasMember(nt, om.Name()) {
+			d.add(UnionMemberRemoved, Breaking, ot.Name(), ot.Loc(), nt.Loc(),
+				"%s was removed from union %s.", om.Name(), ot.Name())
+		}
+	}
+	for _, nm := range nt.Types() {
+		if !hasMember(ot, nm.Name()) {
+			d.add(UnionMemberAdded, Dangerous, ot.Name(), ot.Loc(), nt.Loc(),
+				"%s was added to union %s.", nm.Name(), ot.Name())
+		}
+	}
+}
+
+// The safeOutputChange function returns true if a field of type ot may be changed to nt without breaking clients,
+// i.e. nt is the same type, or a non-null version of it.
+func safeOutputChange(ot, nt schema.Type) bool {
+	switch o := ot.(type) {
+	case *schema.ListType:
+		switch n := nt.(type) {
+		case *schema.ListType:
+			return safeOutputChange(o.OfType(), n.OfType())
+		case *schema.NonNullType:
+			return safeOutputChange(ot, n.OfType())
+		}
+		return false
+	case *schema.NonNullType:
+		n, ok := nt.(*schema.NonNullType)
+		return ok && safeOutputChange(o.OfType(), n.OfType())
+	}
+	switch n := nt.(type) {
+	case *schema.NonNullType:
+		return safeOutputChange(ot, n.OfType())
+	case schema.NamedType:
+		return n.Name() == ot.(schema.NamedType).Name()
+	}
+	return false
+}
+
+// The safeInputChange function returns true if an argument or input field of type ot may be changed to nt without
+// breaking clients, i.e. nt is the same type, or a nullable version of it.
+func safeInputChange(ot, nt schema.Type) bool {
+	switch o := ot.(type) {
+	case *schema.ListType:
+		n, ok := nt.(*schema.ListType)
+		return ok && safeInputChange(o.OfType(), n.OfType())
+	case *schema.NonNullType:
+		if n, ok := nt.(*schema.NonNullType); ok {
+			return safeInputChange(o.OfType(), n.OfType())
+		}
+		return safeInputChange(o.OfType(), nt)
+	}
+	n, ok := nt.(schema.NamedType)
+	return ok && n.Name() == ot.(schema.NamedType).Name()
+}
+
+// The required function returns true if v is non-null without a default value.
+func required(v *schema.InputValue) bool {
+	_, nonNull := v.Type().(*schema.NonNullType)
+	return nonNull && v.DefaultValue() == nil
+}
+
+func field(fs []*schema.Field, name string) *schema.Field {
+	for _, f := range fs {
+		if f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func implements(o *schema.Object, name string) bool {
+	for _, i := range o.Interfaces() {
+		if i.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMember(u *schema.Union, name string) bool {
+	for _, o := range u.Types() {
+		if o.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func objectName(o *schema.Object) string {
+	if o == nil {
+		return ""
+	}
+	return o.Name()
+}
+
+func objectLoc(o *schema.Object) ast.Loc {
+	if o == nil {
+		return ast.Loc{}
+	}
+	return o.Loc()
+}
+
+// The kindName function returns a readable name for a named type Kind, e.g. "an input object".
+func kindName(k schema.Kind) string {
+	switch k {
+	case schema.ScalarKind:
+		return "a scalar"
+	case schema.ObjectKind:
+		return "an object"
+	case schema.InterfaceKind:
+		return "an interface"
+	case schema.UnionKind:
+		return "a union"
+	case schema.EnumKind:
+		return "an enum"
+	case schema.InputObjectKind:
+		return "an input object"
+	}
+	return k.String()
+}
+
+// The valueString function returns v printed compactly, or "" if v is nil.
+func valueString(v ast.Value) string {
+	if v == nil {
+		return ""
+	}
+	var b bytes.Buffer
+	if err := printer.Compact.Fprint(&b, v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return b.String()
+}
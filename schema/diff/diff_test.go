@@ -0,0 +1,228 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+	"github.com/kr/pretty"
+)
+
+func mustBuild(t *testing.T, sdl string) *schema.Schema {
+	d, err := parser.ParseString(sdl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Build(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func summarize(changes []*Change) []string {
+	var s []string
+	for _, c := range changes {
+		s = append(s, c.String())
+	}
+	return s
+}
+
+const oldSDL = `
+type Query {
+	user(id: ID!, limit: Int = 10): User
+	users(filter: Filter): [User]
+	node: Node
+}
+interface Node {id: ID!}
+type User implements Node {
+	id: ID!
+	name: String
+	role: Role
+	friends: [User!]
+}
+union Result = User
+enum Role {ADMIN, USER}
+input Filter {role: Role, name: String!}
+scalar Time
+`
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		new      string
+		expected []string
+	}{
+		{"identical", oldSDL, nil},
+		{
+			"types",
+			`type Query {
+				user(id: ID!, limit: Int = 10): User
+				users(filter: Filter): [User]
+				node: Node
+			}
+			interface Node {id: ID!}
+			type User implements Node {
+				id: ID!
+				name: String
+				role: Role
+				friends: [User!]
+			}
+			enum Result {USER}
+			enum Role {ADMIN, USER}
+			input Filter {role: Role, name: String!}
+			scalar Date`,
+			[]string{
+				"BREAKING: Type Result changed from a union to an enum.",
+				"BREAKING: Type Time was removed.",
+				"SAFE: Type Date was added.",
+			},
+		},
+		{
+			"fields",
+			`type Query {
+				user(id: ID!, limit: Int = 10): User!
+				users(filter: Filter): User
+				node: Node
+				me: User
+			}
+			interface Node {id: ID}
+			type User {
+				id: ID!
+				role: Role
+				friends: [User]
+			}
+			union Result = User
+			enum Role {ADMIN, USER}
+			input Filter {role: Role, name: String!}
+			scalar Time`,
+			[]string{
+				"SAFE: Field Query.user changed type from User to User!.",
+				"BREAKING: Field Query.users changed type from [User] to User.",
+				"SAFE: Field Query.me was added.",
+				"BREAKING: Field Node.id changed type from ID! to ID.",
+				"BREAKING: User no longer implements interface Node.",
+				"BREAKING: Field User.name was removed.",
+				"BREAKING: Field User.friends changed type from [User!] to [User].",
+			},
+		},
+		{
+			"arguments",
+			`type Query {
+				user(id: ID, limit: Int = 20, active: Boolean!): User
+				users(filter: Filter!, first: Int): [User]
+				node(id: [ID]): Node
+			}
+			interface Node {id: ID!}
+			type User implements Node {
+				id: ID!
+				name: String
+				role: Role
+				friends: [User!]
+			}
+			union Result = User
+			enum Role {ADMIN, USER}
+			input Filter {role: Role, name: String!}
+			scalar Time`,
+			[]string{
+				"SAFE: Argument Query.user(id:) changed type from ID! to ID.",
+				`DANGEROUS: Argument Query.user(limit:) changed default value from "10" to "20".`,
+				"BREAKING: Required argument Query.user(active:) was added.",
+				"BREAKING: Argument Query.users(filter:) changed type from Filter to Filter!.",
+				"DANGEROUS: Optional argument Query.users(first:) was added.",
+				"DANGEROUS: Optional argument Query.node(id:) was added.",
+			},
+		},
+		{
+			"inputs and enums",
+			`type Query {
+				user(id: ID!, limit: Int = 10): User
+				users(filter: Filter): [User]
+				node: Node
+			}
+			interface Node {id: ID!}
+			type User implements Node {
+				id: ID!
+				name: String
+				role: Role
+				friends: [User!]
+			}
+			type Bot implements Node {id: ID!}
+			union Result = Bot
+			enum Role {ADMIN, GUEST}
+			input Filter {name: [String!], limit: Int = 10, after: String!}
+			scalar Time`,
+			[]string{
+				"BREAKING: User was removed from union Result.",
+				"DANGEROUS: Bot was added to union Result.",
+				"BREAKING: Enum value Role.USER was removed.",
+				"DANGEROUS: Enum value Role.GUEST was added.",
+				"BREAKING: Input field Filter.role was removed.",
+				"BREAKING: Input field Filter.name changed type from String! to [String!].",
+				"DANGEROUS: Optional input field Filter.limit was added.",
+				"BREAKING: Required input field Filter.after was added.",
+				"SAFE: Type Bot was added.",
+			},
+		},
+		{
+			"roots",
+			`schema {query: Root, mutation: Mutation}
+			type Root {node: Node}
+			type Mutation {noop: Boolean}
+			interface Node {id: ID!}
+			type User implements Node {
+				id: ID!
+				name: String
+				role: Role
+				friends: [User!]
+			}
+			union Result = User
+			enum Role {ADMIN, USER}
+			input Filter {role: Role, name: String!}
+			scalar Time`,
+			[]string{
+				`BREAKING: The query root type changed from "Query" to "Root".`,
+				`SAFE: The mutation root type changed from "" to "Mutation".`,
+				"BREAKING: Type Query was removed.",
+				"SAFE: Type Root was added.",
+				"SAFE: Type Mutation was added.",
+			},
+		},
+	} {
+		changes := Diff(mustBuild(t, oldSDL), mustBuild(t, test.new))
+		if actual := summarize(changes); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %# v but got %# v", test.name, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+func TestDiffLocations(t *testing.T) {
+	oldSchema := mustBuild(t, `type Query {a: Int, b: Int}`)
+	newSchema := mustBuild(t, `type Query {
+	b: String
+}`)
+	expected := []*Change{
+		{Type: FieldRemoved, Criticality: Breaking, Path: "Query.a", Message: "Field Query.a was removed.",
+			OldLoc: oldSchema.QueryType().Field("a").Loc()},
+		{Type: FieldTypeChanged, Criticality: Breaking, Path: "Query.b", Message: "Field Query.b changed type from Int to String.",
+			OldLoc: oldSchema.QueryType().Field("b").Loc(), NewLoc: newSchema.QueryType().Field("b").Loc()},
+	}
+	actual := Diff(oldSchema, newSchema)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %# v but got %# v", pretty.Formatter(expected), pretty.Formatter(actual))
+	}
+	if loc := actual[1].NewLoc; loc.Start != 14 {
+		t.Errorf("expected new location at 14 but got %v", loc)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	changes := []*Change{{Criticality: Safe}, {Criticality: Breaking}, {Criticality: Dangerous}}
+	if actual := Filter(changes, Dangerous); !reflect.DeepEqual(actual, changes[1:]) {
+		t.Errorf("unexpected changes %v", actual)
+	}
+	if actual := Filter(changes, Breaking); !reflect.DeepEqual(actual, changes[1:2]) {
+		t.Errorf("unexpected changes %v", actual)
+	}
+}
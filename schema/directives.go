@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// Built-in directives, supported by every Schema.
+var (
+	// SkipDirective excludes a field or fragment from execution when its "if" argument is true.
+	SkipDirective = &Directive{
+		name:        "skip",
+		description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		locations:   []DirectiveLocation{LocationField, LocationFragmentSpread, LocationInlineFragment},
+		args:        []*InputValue{{name: "if", description: "Skipped when true.", typ: NonNull(Boolean)}},
+	}
+	// IncludeDirective includes a field or fragment in execution only when its "if" argument is true.
+	IncludeDirective = &Directive{
+		name:        "include",
+		description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		locations:   []DirectiveLocation{LocationField, LocationFragmentSpread, LocationInlineFragment},
+		args:        []*InputValue{{name: "if", description: "Included when true.", typ: NonNull(Boolean)}},
+	}
+	// DeprecatedDirective marks a field or enum value as deprecated, for the reason given by its "reason" argument.
+	DeprecatedDirective = &Directive{
+		name:        "deprecated",
+		description: "Marks an element of a GraphQL schema as no longer supported.",
+		locations:   []DirectiveLocation{LocationFieldDefinition, LocationEnumValue},
+		args: []*InputValue{{
+			name:         "reason",
+			description:  "Explains why this element was deprecated, usually also including a suggestion for how to access supported similar data. Formatted using the Markdown syntax, as specified by [CommonMark](https://commonmark.org/).",
+			typ:          String,
+			defaultValue: &ast.String{Value: DefaultDeprecationReason},
+		}},
+	}
+	// SpecifiedByDirective links a custom scalar to the specification of its behavior, given by its "url" argument.
+	SpecifiedByDirective = &Directive{
+		name:        "specifiedBy",
+		description: "Exposes a URL that specifies the behavior of this scalar.",
+		locations:   []DirectiveLocation{LocationScalar},
+		args:        []*InputValue{{name: "url", description: "The URL that specifies the behavior of this scalar.", typ: NonNull(String)}},
+	}
+)
+
+var builtInDirectives = []*Directive{SkipDirective, IncludeDirective, DeprecatedDirective, SpecifiedByDirective}
+
+// The IsBuiltInDirective function returns true if name is the name of a built-in directive.
+func IsBuiltInDirective(name string) bool {
+	for _, d := range builtInDirectives {
+		if d.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// The specifiedBy function returns the url argument of the @specifiedBy directive in ds, or "" if there is none.
+func specifiedBy(ds []ast.Directive) string {
+	for _, d := range ds {
+		if d.Name.Value != SpecifiedByDirective.name {
+			continue
+		}
+		for _, a := range d.Arguments {
+			if s, ok := a.Value.(*ast.String); ok && a.Name.Value == "url" {
+				return s.Value
+			}
+		}
+	}
+	return ""
+}
+
+// The specifiedByDirectives function returns a @specifiedBy directive with url, or nil if url is empty.
+func specifiedByDirectives(url string) []ast.Directive {
+	if url == "" {
+		return nil
+	}
+	return []ast.Directive{{
+		Name:      ast.Name{Value: SpecifiedByDirective.name},
+		Arguments: []ast.Argument{{Name: ast.Name{Value: "url"}, Value: &ast.String{Value: url}}},
+	}}
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/printer"
+)
+
+func TestBuiltInDirectives(t *testing.T) {
+	s := mustBuild(t, `type Query {a: Time} scalar Time @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")`)
+	if !reflect.DeepEqual(s.Directives(), builtInDirectives) {
+		t.Errorf("expected built-in directives but got %v", s.Directives())
+	}
+	for _, test := range []struct {
+		name      string
+		expected  *Directive
+		locations []DirectiveLocation
+		args      []string
+	}{
+		{"skip", SkipDirective, []DirectiveLocation{LocationField, LocationFragmentSpread, LocationInlineFragment}, []string{"if: Boolean!"}},
+		{"include", IncludeDirective, []DirectiveLocation{LocationField, LocationFragmentSpread, LocationInlineFragment}, []string{"if: Boolean!"}},
+		{"deprecated", DeprecatedDirective, []DirectiveLocation{LocationFieldDefinition, LocationEnumValue}, []string{"reason: String"}},
+		{"specifiedBy", SpecifiedByDirective, []DirectiveLocation{LocationScalar}, []string{"url: String!"}},
+	} {
+		d := s.Directive(test.name)
+		if d != test.expected {
+			t.Errorf("%s: expected %v but got %v", test.name, test.expected, d)
+			continue
+		}
+		if !reflect.DeepEqual(d.Locations(), test.locations) {
+			t.Errorf("%s: expected locations %v but got %v", test.name, test.locations, d.Locations())
+		}
+		var args []string
+		for _, a := range d.Args() {
+			args = append(args, a.Name()+": "+a.Type().String())
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%s: expected arguments %v but got %v", test.name, test.args, args)
+		}
+		if d.IsRepeatable() || !IsBuiltInDirective(test.name) {
+			t.Errorf("%s: expected non-repeatable built-in directive", test.name)
+		}
+	}
+	if IsBuiltInDirective("cost") || s.Directive("cost") != nil {
+		t.Error("unexpected directive cost")
+	}
+
+	scalar := s.Type("Time").(*Scalar)
+	if u := scalar.SpecifiedByURL(); u != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("unexpected specification URL %q", u)
+	}
+	specifiedByURL := TypeType.Field("specifiedByURL")
+	if v := resolve(t, specifiedByURL, scalar, nil, nil); v != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("unexpected introspected specification URL %v", v)
+	}
+	if v := resolve(t, specifiedByURL, String, nil, nil); v != nil {
+		t.Errorf("expected no specification URL but got %v", v)
+	}
+	if v := resolve(t, DirectiveType.Field("isRepeatable"), SkipDirective, nil, nil); v != false {
+		t.Errorf("expected non-repeatable but got %v", v)
+	}
+
+	var b bytes.Buffer
+	if err := Print(&b, s, printer.Compact, false); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "type Query{a:Time}\nscalar Time@specifiedBy(url:\"https://tools.ietf.org/html/rfc3339\")\n"; b.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, b.String())
+	}
+
+	built, err := NewSchema().
+		Query(NewObject("Query").Field("a", Ref("Time"), nil)).
+		Types(NewScalar("Time").SpecifiedByURL("https://tools.ietf.org/html/rfc3339")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := built.Type("Time").(*Scalar).SpecifiedByURL(); u != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("unexpected built specification URL %q", u)
+	}
+}
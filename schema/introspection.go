@@ -0,0 +1,556 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+// Introspection types, included in every Schema.
+var (
+	SchemaType            = &Object{name: "__Schema"}
+	TypeType              = &Object{name: "__Type"}
+	FieldType             = &Object{name: "__Field"}
+	InputValueType        = &Object{name: "__InputValue"}
+	EnumValueType         = &Object{name: "__EnumValue"}
+	DirectiveType         = &Object{name: "__Directive"}
+	TypeKindType          = &Enum{name: "__TypeKind"}
+	DirectiveLocationType = &Enum{name: "__DirectiveLocation"}
+)
+
+var introspectionTypes = []NamedType{
+	SchemaType, TypeType, FieldType, InputValueType, EnumValueType, DirectiveType, TypeKindType, DirectiveLocationType,
+}
+
+// Introspection meta-fields, which are implicitly fields of every Object, Interface, or Union. See the Field method of
+// Schema.
+var (
+	// __schema: __Schema!, on the query root type only.
+	SchemaMetaField = &Field{
+		name:        "__schema",
+		description: "Access the current type schema of this server.",
+		typ:         NonNull(SchemaType),
+		resolve: func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error) {
+			return info.Schema, nil
+		},
+	}
+	// __type(name: String!): __Type, on the query root type only.
+	TypeMetaField = &Field{
+		name:        "__type",
+		description: "Request the type information of a single type.",
+		typ:         TypeType,
+		args:        []*InputValue{{name: "name", typ: NonNull(String)}},
+		resolve: func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error) {
+			name, _ := args["name"].(string)
+			if t := info.Schema.Type(name); t != nil {
+				return t, nil
+			}
+			return nil, nil
+		},
+	}
+	// __typename: String!
+	TypeNameMetaField = &Field{
+		name:        "__typename",
+		description: "The name of the current Object type at runtime.",
+		typ:         NonNull(String),
+		resolve: func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error) {
+			return info.ParentType.name, nil
+		},
+	}
+)
+
+// The IsIntrospectionType function returns true if t is one of the introspection types, e.g. __Type.
+func IsIntrospectionType(t NamedType) bool {
+	for _, i := range introspectionTypes {
+		if t == i {
+			return true
+		}
+	}
+	return false
+}
+
+// A DirectiveLocation is a location where a Directive may be used.
+type DirectiveLocation string
+
+const (
+	// Executable locations.
+	LocationQuery              DirectiveLocation = "QUERY"
+	LocationMutation           DirectiveLocation = "MUTATION"
+	LocationSubscription       DirectiveLocation = "SUBSCRIPTION"
+	LocationField              DirectiveLocation = "FIELD"
+	LocationFragmentDefinition DirectiveLocation = "FRAGMENT_DEFINITION"
+	LocationFragmentSpread     DirectiveLocation = "FRAGMENT_SPREAD"
+	LocationInlineFragment     DirectiveLocation = "INLINE_FRAGMENT"
+	LocationVariableDefinition DirectiveLocation = "VARIABLE_DEFINITION"
+
+	// Type system locations.
+	LocationSchema               DirectiveLocation = "SCHEMA"
+	LocationScalar               DirectiveLocation = "SCALAR"
+	LocationObject               DirectiveLocation = "OBJECT"
+	LocationFieldDefinition      DirectiveLocation = "FIELD_DEFINITION"
+	LocationArgumentDefinition   DirectiveLocation = "ARGUMENT_DEFINITION"
+	LocationInterface            DirectiveLocation = "INTERFACE"
+	LocationUnion                DirectiveLocation = "UNION"
+	LocationEnum                 DirectiveLocation = "ENUM"
+	LocationEnumValue            DirectiveLocation = "ENUM_VALUE"
+	LocationInputObject          DirectiveLocation = "INPUT_OBJECT"
+	LocationInputFieldDefinition DirectiveLocation = "INPUT_FIELD_DEFINITION"
+)
+
+var directiveLocations = []DirectiveLocation{
+	LocationQuery, LocationMutation, LocationSubscription, LocationField, LocationFragmentDefinition,
+	LocationFragmentSpread, LocationInlineFragment, LocationVariableDefinition,
+	LocationSchema, LocationScalar, LocationObject, LocationFieldDefinition, LocationArgumentDefinition,
+	LocationInterface, LocationUnion, LocationEnum, LocationEnumValue, LocationInputObject,
+	LocationInputFieldDefinition,
+}
+
+// The isDirectiveLocation function returns true if l is one of the DirectiveLocations defined by the spec.
+func isDirectiveLocation(l DirectiveLocation) bool {
+	for _, dl := range directiveLocations {
+		if dl == l {
+			return true
+		}
+	}
+	return false
+}
+
+// A Directive describes a directive which may annotate a Document.
+type Directive struct {
+	name        string
+	description string
+	loc         ast.Loc
+	locations   []DirectiveLocation
+	args        []*InputValue
+	repeatable  bool
+}
+
+func (d *Directive) Name() string        { return d.name }
+func (d *Directive) Description() string { return d.description }
+func (d *Directive) Loc() ast.Loc        { return d.loc }
+
+// The Locations method returns the locations where d may be used.
+func (d *Directive) Locations() []DirectiveLocation { return d.locations }
+
+// The IsRepeatable method returns true if d may be used more than once at a single location.
+func (d *Directive) IsRepeatable() bool { return d.repeatable }
+
+// The Args method returns the arguments of d, in definition order.
+func (d *Directive) Args() []*InputValue { return d.args }
+
+// The Arg method returns the argument of d named name, or nil if there is none.
+func (d *Directive) Arg(name string) *InputValue {
+	for _, a := range d.args {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// The resolver function returns a ResolveFunc returning the result of get applied to the source.
+func resolver(get func(source interface{}, args map[string]interface{}) interface{}) ResolveFunc {
+	return func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error) {
+		return get(source, args), nil
+	}
+}
+
+// The optional function returns s, or nil if s is empty.
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// The includeDeprecated function returns the value of the includeDeprecated argument.
+func includeDeprecated(args map[string]interface{}) bool {
+	b, _ := args["includeDeprecated"].(bool)
+	return b
+}
+
+// The setFields function sets the fields of the introspection type o.
+func setFields(o *Object, description string, fields ...*Field) {
+	o.description = description
+	o.fields = fields
+	o.fieldsByName = make(map[string]*Field, len(fields))
+	for _, f := range fields {
+		o.fieldsByName[f.name] = f
+	}
+}
+
+func init() {
+	includeDeprecatedArg := []*InputValue{{name: "includeDeprecated", typ: Boolean, defaultValue: &ast.Boolean{Value: false}}}
+
+	setFields(SchemaType,
+		"A GraphQL Schema defines the capabilities of a GraphQL server. It exposes all available types and directives on the server, as well as the entry points for query, mutation, and subscription operations.",
+		&Field{
+			name:        "types",
+			description: "A list of all types supported by this server.",
+			typ:         NonNull(List(NonNull(TypeType))),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Schema).typesOrder
+			}),
+		},
+		&Field{
+			name:        "queryType",
+			description: "The type that query operations will be rooted at.",
+			typ:         NonNull(TypeType),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Schema).query
+			}),
+		},
+		&Field{
+			name:        "mutationType",
+			description: "If this server supports mutation, the type that mutation operations will be rooted at.",
+			typ:         TypeType,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if m := source.(*Schema).mutation; m != nil {
+					return m
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name:        "subscriptionType",
+			description: "If this server supports subscription, the type that subscription operations will be rooted at.",
+			typ:         TypeType,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if s := source.(*Schema).subscription; s != nil {
+					return s
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name:        "directives",
+			description: "A list of all directives supported by this server.",
+			typ:         NonNull(List(NonNull(DirectiveType))),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Schema).directives
+			}),
+		},
+	)
+
+	setFields(TypeType,
+		"The fundamental unit of any GraphQL Schema is the type. There are many kinds of types in GraphQL as represented by the `__TypeKind` enum.",
+		&Field{
+			name: "kind",
+			typ:  NonNull(TypeKindType),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(Type).Kind()
+			}),
+		},
+		&Field{
+			name: "name",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if t, ok := source.(NamedType); ok {
+					return t.Name()
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "description",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if t, ok := source.(NamedType); ok {
+					return optional(t.Description())
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "specifiedByURL",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if s, ok := source.(*Scalar); ok {
+					return optional(s.specifiedBy)
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "fields",
+			typ:  List(NonNull(FieldType)),
+			args: includeDeprecatedArg,
+			resolve: resolver(func(source interface{}, args map[string]interface{}) interface{} {
+				var fields []*Field
+				switch t := source.(type) {
+				case *Object:
+					fields = t.fields
+				case *Interface:
+					fields = t.fields
+				default:
+					return nil
+				}
+				if includeDeprecated(args) {
+					return fields
+				}
+				active := make([]*Field, 0, len(fields))
+				for _, f := range fields {
+					if !f.IsDeprecated() {
+						active = append(active, f)
+					}
+				}
+				return active
+			}),
+		},
+		&Field{
+			name: "interfaces",
+			typ:  List(NonNull(TypeType)),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if o, ok := source.(*Object); ok {
+					return o.interfaces
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "possibleTypes",
+			typ:  List(NonNull(TypeType)),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				switch t := source.(type) {
+				case *Interface:
+					return t.possibleTypes
+				case *Union:
+					return t.types
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "enumValues",
+			typ:  List(NonNull(EnumValueType)),
+			args: includeDeprecatedArg,
+			resolve: resolver(func(source interface{}, args map[string]interface{}) interface{} {
+				e, ok := source.(*Enum)
+				if !ok {
+					return nil
+				}
+				if includeDeprecated(args) {
+					return e.values
+				}
+				active := make([]*EnumValue, 0, len(e.values))
+				for _, v := range e.values {
+					if !v.IsDeprecated() {
+						active = append(active, v)
+					}
+				}
+				return active
+			}),
+		},
+		&Field{
+			name: "inputFields",
+			typ:  List(NonNull(InputValueType)),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if o, ok := source.(*InputObject); ok {
+					return o.fields
+				}
+				return nil
+			}),
+		},
+		&Field{
+			name: "ofType",
+			typ:  TypeType,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				switch t := source.(type) {
+				case *ListType:
+					return t.ofType
+				case *NonNullType:
+					return t.ofType
+				}
+				return nil
+			}),
+		},
+	)
+
+	setFields(FieldType,
+		"Object and Interface types are described by a list of Fields, each of which has a name, potentially a list of arguments, and a return type.",
+		&Field{
+			name: "name",
+			typ:  NonNull(String),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Field).name
+			}),
+		},
+		&Field{
+			name: "description",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return optional(source.(*Field).description)
+			}),
+		},
+		&Field{
+			name: "args",
+			typ:  NonNull(List(NonNull(InputValueType))),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if args := source.(*Field).args; args != nil {
+					return args
+				}
+				return []*InputValue{}
+			}),
+		},
+		&Field{
+			name: "type",
+			typ:  NonNull(TypeType),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Field).typ
+			}),
+		},
+		&Field{
+			name: "isDeprecated",
+			typ:  NonNull(Boolean),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Field).IsDeprecated()
+			}),
+		},
+		&Field{
+			name: "deprecationReason",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if r := source.(*Field).deprecationReason; r != nil {
+					return *r
+				}
+				return nil
+			}),
+		},
+	)
+
+	setFields(InputValueType,
+		"Arguments provided to Fields or Directives and the input fields of an InputObject are represented as Input Values which describe their type and optionally a default value.",
+		&Field{
+			name: "name",
+			typ:  NonNull(String),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*InputValue).name
+			}),
+		},
+		&Field{
+			name: "description",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return optional(source.(*InputValue).description)
+			}),
+		},
+		&Field{
+			name: "type",
+			typ:  NonNull(TypeType),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*InputValue).typ
+			}),
+		},
+		&Field{
+			name:        "defaultValue",
+			description: "A GraphQL-formatted string representing the default value for this input value.",
+			typ:         String,
+			resolve: func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error) {
+				v := source.(*InputValue).defaultValue
+				if v == nil {
+					return nil, nil
+				}
+				var b bytes.Buffer
+				if err := printer.Compact.Fprint(&b, v); err != nil {
+					return nil, err
+				}
+				return b.String(), nil
+			},
+		},
+	)
+
+	setFields(EnumValueType,
+		"One possible value for a given Enum. Enum values are unique values, not a placeholder for a string or numeric value. However an Enum value is returned in a JSON response as a string.",
+		&Field{
+			name: "name",
+			typ:  NonNull(String),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*EnumValue).name
+			}),
+		},
+		&Field{
+			name: "description",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return optional(source.(*EnumValue).description)
+			}),
+		},
+		&Field{
+			name: "isDeprecated",
+			typ:  NonNull(Boolean),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*EnumValue).IsDeprecated()
+			}),
+		},
+		&Field{
+			name: "deprecationReason",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if r := source.(*EnumValue).deprecationReason; r != nil {
+					return *r
+				}
+				return nil
+			}),
+		},
+	)
+
+	setFields(DirectiveType,
+		"A Directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document.",
+		&Field{
+			name: "name",
+			typ:  NonNull(String),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Directive).name
+			}),
+		},
+		&Field{
+			name: "description",
+			typ:  String,
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return optional(source.(*Directive).description)
+			}),
+		},
+		&Field{
+			name: "locations",
+			typ:  NonNull(List(NonNull(DirectiveLocationType))),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Directive).locations
+			}),
+		},
+		&Field{
+			name: "isRepeatable",
+			typ:  NonNull(Boolean),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				return source.(*Directive).repeatable
+			}),
+		},
+		&Field{
+			name: "args",
+			typ:  NonNull(List(NonNull(InputValueType))),
+			resolve: resolver(func(source interface{}, _ map[string]interface{}) interface{} {
+				if args := source.(*Directive).args; args != nil {
+					return args
+				}
+				return []*InputValue{}
+			}),
+		},
+	)
+
+	TypeKindType.description = "An enum describing what kind of type a given `__Type` is."
+	TypeKindType.valuesByName = make(map[string]*EnumValue)
+	for _, k := range []Kind{ScalarKind, ObjectKind, InterfaceKind, UnionKind, EnumKind, InputObjectKind, ListKind, NonNullKind} {
+		v := &EnumValue{name: k.String(), value: k}
+		TypeKindType.values = append(TypeKindType.values, v)
+		TypeKindType.valuesByName[v.name] = v
+	}
+
+	DirectiveLocationType.description = "A Directive can be adjacent to many parts of the GraphQL language, a __DirectiveLocation describes one such possible adjacencies."
+	DirectiveLocationType.valuesByName = make(map[string]*EnumValue)
+	for _, l := range directiveLocations {
+		v := &EnumValue{name: string(l), value: l}
+		DirectiveLocationType.values = append(DirectiveLocationType.values, v)
+		DirectiveLocationType.valuesByName[v.name] = v
+	}
+}
@@ -0,0 +1,390 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+)
+
+// The source of the canonical introspection query.
+const introspectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      description
+      locations
+      args {
+        ...InputValue
+      }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  specifiedByURL
+  fields(includeDeprecated: true) {
+    name
+    description
+    args {
+      ...InputValue
+    }
+    type {
+      ...TypeRef
+    }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields {
+    ...InputValue
+  }
+  interfaces {
+    ...TypeRef
+  }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes {
+    ...TypeRef
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// The IntrospectionQuery function returns a new Document holding the canonical introspection query, named
+// IntrospectionQuery. Its JSON result can be converted back into a Schema by FromIntrospection.
+func IntrospectionQuery() *ast.Document {
+	d, err := parser.ParseString(introspectionQuery)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse introspection query: %s", err))
+	}
+	return d
+}
+
+// The FromIntrospection function decodes the JSON result of the IntrospectionQuery from r, and returns both the
+// Schema it describes and a Document of its type definitions, which the printer renders as SDL. Built-in scalars and
+// introspection types are omitted from the Document. r may hold either a complete response, with "data" and "errors"
+// members, or only the data. Response errors are returned as an errors.List.
+//
+// Descriptions are preserved, and deprecations and scalar specification URLs are converted to @deprecated and
+// @specifiedBy directives. Directive definitions are not converted.
+func FromIntrospection(r io.Reader) (*Schema, *ast.Document, error) {
+	var resp introspectionResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode introspection result: %s", err)
+	}
+	if len(resp.Errors) > 0 {
+		var errs errors.List
+		for _, e := range resp.Errors {
+			errs = append(errs, errors.New(e.Message))
+		}
+		return nil, nil, errs
+	}
+	data := resp.introspectionData
+	if resp.Data != nil {
+		data = *resp.Data
+	}
+	if data.Schema == nil {
+		return nil, nil, fmt.Errorf("introspection result has no __schema")
+	}
+
+	d, err := data.Schema.document()
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := Build(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, d, nil
+}
+
+// The introspectionResponse type is a JSON response to the IntrospectionQuery.
+type introspectionResponse struct {
+	Data *introspectionData
+	introspectionData
+	Errors []struct {
+		Message string
+	}
+}
+
+type introspectionData struct {
+	Schema *introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	QueryType, MutationType, SubscriptionType *struct {
+		Name string
+	}
+	Types []introspectionType
+}
+
+// An introspectionTypeRef is a __Type, as selected by the TypeRef fragment.
+type introspectionTypeRef struct {
+	Kind   string
+	Name   string
+	OfType *introspectionTypeRef
+}
+
+// An introspectionType is a __Type, as selected by the FullType fragment.
+type introspectionType struct {
+	Kind           string
+	Name           string
+	Description    string
+	SpecifiedByURL string
+	Fields         []introspectionField
+	InputFields    []introspectionInputValue
+	Interfaces     []introspectionTypeRef
+	EnumValues     []introspectionEnumValue
+	PossibleTypes  []introspectionTypeRef
+}
+
+type introspectionField struct {
+	Name              string
+	Description       string
+	Args              []introspectionInputValue
+	Type              introspectionTypeRef
+	IsDeprecated      bool
+	DeprecationReason *string
+}
+
+type introspectionInputValue struct {
+	Name         string
+	Description  string
+	Type         introspectionTypeRef
+	DefaultValue *string
+}
+
+type introspectionEnumValue struct {
+	Name              string
+	Description       string
+	IsDeprecated      bool
+	DeprecationReason *string
+}
+
+// The document method returns a Document of the type definitions of s.
+func (s *introspectionSchema) document() (*ast.Document, error) {
+	var query, mutation, subscription string
+	if s.QueryType != nil {
+		query = s.QueryType.Name
+	}
+	if s.MutationType != nil {
+		mutation = s.MutationType.Name
+	}
+	if s.SubscriptionType != nil {
+		subscription = s.SubscriptionType.Name
+	}
+
+	d := &ast.Document{}
+	if sd := schemaDef(query, mutation, subscription); sd != nil {
+		d.Definitions = append(d.Definitions, sd)
+	}
+	for _, t := range s.Types {
+		if t.builtIn() {
+			continue
+		}
+		td, err := t.typeDef()
+		if err != nil {
+			return nil, err
+		}
+		d.Definitions = append(d.Definitions, td)
+	}
+	return d, nil
+}
+
+// The builtIn method returns true if t is a built-in scalar or introspection type, which every Schema shares.
+func (t *introspectionType) builtIn() bool {
+	return strings.HasPrefix(t.Name, "__") || (t.Kind == "SCALAR" && isBuiltInScalar(t.Name))
+}
+
+// The typeDef method returns the definition of t.
+func (t *introspectionType) typeDef() (ast.TypeDef, error) {
+	name := ast.Name{Value: t.Name}
+	description := descriptionOf(t.Description)
+	switch t.Kind {
+	case "SCALAR":
+		return &ast.ScalarTypeDef{Description: description, Name: name, Directives: specifiedByDirectives(t.SpecifiedByURL)}, nil
+	case "OBJECT":
+		fds, err := fieldDefsOf(t.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.ObjTypeDef{Description: description, Name: name, Interfaces: namedTypesOf(t.Interfaces),
+			FieldDefs: fds}, nil
+	case "INTERFACE":
+		fds, err := fieldDefsOf(t.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.InterfaceTypeDef{Description: description, Name: name, FieldDefs: fds}, nil
+	case "UNION":
+		return &ast.UnionTypeDef{Description: description, Name: name, NamedTypes: namedTypesOf(t.PossibleTypes)}, nil
+	case "ENUM":
+		evds := make([]ast.EnumValueDef, len(t.EnumValues))
+		for i, v := range t.EnumValues {
+			evds[i] = ast.EnumValueDef{
+				Description: descriptionOf(v.Description),
+				Name:        ast.Name{Value: v.Name},
+				Directives:  deprecatedDirectives(v.IsDeprecated, v.DeprecationReason),
+			}
+		}
+		return &ast.EnumTypeDef{Description: description, Name: name, EnumValueDefs: evds}, nil
+	case "INPUT_OBJECT":
+		ivds, err := inputValueDefsOf(t.InputFields)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.InputObjTypeDef{Description: description, Name: name, Fields: ivds}, nil
+	}
+	return nil, fmt.Errorf("type %q has unknown kind %q", t.Name, t.Kind)
+}
+
+// The descriptionOf function returns a description with value s, or nil if s is empty.
+func descriptionOf(s string) *ast.String {
+	if s == "" {
+		return nil
+	}
+	return &ast.String{Value: s}
+}
+
+// The deprecatedDirectives function returns a @deprecated directive if isDeprecated, or nil. A null reason is omitted,
+// so that the DefaultDeprecationReason applies.
+func deprecatedDirectives(isDeprecated bool, reason *string) []ast.Directive {
+	if !isDeprecated {
+		return nil
+	}
+	d := ast.Directive{Name: ast.Name{Value: "deprecated"}}
+	if reason != nil {
+		d.Arguments = []ast.Argument{{Name: ast.Name{Value: "reason"}, Value: &ast.String{Value: *reason}}}
+	}
+	return []ast.Directive{d}
+}
+
+func fieldDefsOf(ifs []introspectionField) ([]ast.FieldDef, error) {
+	fds := make([]ast.FieldDef, len(ifs))
+	for i, f := range ifs {
+		args, err := inputValueDefsOf(f.Args)
+		if err != nil {
+			return nil, err
+		}
+		rt, err := f.Type.refType()
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", f.Name, err)
+		}
+		fds[i] = ast.FieldDef{
+			Description: descriptionOf(f.Description),
+			Name:        ast.Name{Value: f.Name},
+			Arguments:   args,
+			RefType:     rt,
+			Directives:  deprecatedDirectives(f.IsDeprecated, f.DeprecationReason),
+		}
+	}
+	return fds, nil
+}
+
+func inputValueDefsOf(ivs []introspectionInputValue) ([]ast.InputValueDef, error) {
+	if len(ivs) == 0 {
+		return nil, nil
+	}
+	ivds := make([]ast.InputValueDef, len(ivs))
+	for i, v := range ivs {
+		rt, err := v.Type.refType()
+		if err != nil {
+			return nil, fmt.Errorf("input value %q: %s", v.Name, err)
+		}
+		ivds[i] = ast.InputValueDef{Description: descriptionOf(v.Description), Name: ast.Name{Value: v.Name}, RefType: rt}
+		if v.DefaultValue != nil && *v.DefaultValue != "null" {
+			dv, err := parser.ParseValue(*v.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("input value %q: invalid default value %q: %s", v.Name, *v.DefaultValue, err)
+			}
+			ivds[i].DefaultValue = dv
+		}
+	}
+	return ivds, nil
+}
+
+func namedTypesOf(refs []introspectionTypeRef) []ast.NamedType {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.Name
+	}
+	return namedTypes(names)
+}
+
+// The refType method returns the ast reference to the type r.
+func (r *introspectionTypeRef) refType() (ast.RefType, error) {
+	switch r.Kind {
+	case "LIST", "NON_NULL":
+		if r.OfType == nil {
+			return nil, fmt.Errorf("%s type reference is missing ofType", r.Kind)
+		}
+		of, err := r.OfType.refType()
+		if err != nil {
+			return nil, err
+		}
+		if r.Kind == "LIST" {
+			return &ast.ListType{RefType: of}, nil
+		}
+		return &ast.NonNullType{RefType: of}, nil
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("%s type reference is missing name", r.Kind)
+	}
+	return &ast.NamedType{Value: r.Name}, nil
+}
@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+const introspectionResult = `{"data": {"__schema": {
+	"queryType": {"name": "Root"},
+	"mutationType": null,
+	"subscriptionType": null,
+	"types": [
+		{"kind": "OBJECT", "name": "Root", "description": "The root.", "fields": [
+			{"name": "user", "description": "Find a user.", "isDeprecated": false, "deprecationReason": null,
+				"args": [
+					{"name": "id", "description": "The id.", "defaultValue": "\"1\"",
+						"type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "ID", "ofType": null}}},
+					{"name": "filter", "description": null, "defaultValue": "{role: ADMIN, tags: [\"a\"]}",
+						"type": {"kind": "INPUT_OBJECT", "name": "Filter", "ofType": null}}
+				],
+				"type": {"kind": "INTERFACE", "name": "Node", "ofType": null}},
+			{"name": "search", "description": null, "isDeprecated": true, "deprecationReason": null, "args": [],
+				"type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "LIST", "name": null, "ofType": {"kind": "UNION", "name": "Result", "ofType": null}}}}
+		], "inputFields": null, "interfaces": [], "enumValues": null, "possibleTypes": null},
+		{"kind": "INTERFACE", "name": "Node", "description": null, "fields": [
+			{"name": "id", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+				"type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "ID", "ofType": null}}}
+		], "inputFields": null, "interfaces": null, "enumValues": null,
+			"possibleTypes": [{"kind": "OBJECT", "name": "User", "ofType": null}]},
+		{"kind": "OBJECT", "name": "User", "description": null, "fields": [
+			{"name": "id", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+				"type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "ID", "ofType": null}}},
+			{"name": "email", "description": null, "isDeprecated": true, "deprecationReason": "Use contact.", "args": [],
+				"type": {"kind": "SCALAR", "name": "String", "ofType": null}},
+			{"name": "role", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+				"type": {"kind": "ENUM", "name": "Role", "ofType": null}},
+			{"name": "joined", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+				"type": {"kind": "SCALAR", "name": "Time", "ofType": null}}
+		], "inputFields": null, "interfaces": [{"kind": "INTERFACE", "name": "Node", "ofType": null}],
+			"enumValues": null, "possibleTypes": null},
+		{"kind": "UNION", "name": "Result", "description": "A search result.", "fields": null, "inputFields": null,
+			"interfaces": null, "enumValues": null, "possibleTypes": [{"kind": "OBJECT", "name": "User", "ofType": null}]},
+		{"kind": "ENUM", "name": "Role", "description": null, "fields": null, "inputFields": null, "interfaces": null,
+			"enumValues": [
+				{"name": "ADMIN", "description": "Can do anything.", "isDeprecated": false, "deprecationReason": null},
+				{"name": "GUEST", "description": null, "isDeprecated": true, "deprecationReason": "Removed."}
+			], "possibleTypes": null},
+		{"kind": "INPUT_OBJECT", "name": "Filter", "description": null, "fields": null, "inputFields": [
+			{"name": "role", "description": "Only this role.", "defaultValue": null,
+				"type": {"kind": "ENUM", "name": "Role", "ofType": null}},
+			{"name": "tags", "description": null, "defaultValue": null,
+				"type": {"kind": "LIST", "name": null, "ofType": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}}}
+		], "interfaces": null, "enumValues": null, "possibleTypes": null},
+		{"kind": "SCALAR", "name": "Time", "description": "RFC 3339 time.",
+			"specifiedByURL": "https://tools.ietf.org/html/rfc3339", "fields": null, "inputFields": null,
+			"interfaces": null, "enumValues": null, "possibleTypes": null},
+		{"kind": "SCALAR", "name": "String", "description": "Built-in.", "fields": null, "inputFields": null,
+			"interfaces": null, "enumValues": null, "possibleTypes": null},
+		{"kind": "SCALAR", "name": "ID", "description": "Built-in.", "fields": null, "inputFields": null,
+			"interfaces": null, "enumValues": null, "possibleTypes": null},
+		{"kind": "ENUM", "name": "__TypeKind", "description": null, "fields": null, "inputFields": null,
+			"interfaces": null, "enumValues": [], "possibleTypes": null}
+	],
+	"directives": []
+}}}`
+
+func TestFromIntrospection(t *testing.T) {
+	s, d, err := FromIntrospection(strings.NewReader(introspectionResult))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	for _, def := range d.Definitions {
+		if err := printer.Compact.Fprint(&buf, def); err != nil {
+			t.Fatal(err)
+		}
+		buf.WriteString("\n")
+	}
+	expected := `schema{query:Root}
+"The root." type Root{"Find a user." user("The id." id:ID!="1",filter:Filter={role:ADMIN,tags:["a"]}):Node,search:[Result]!@deprecated}
+interface Node{id:ID!}
+type User implements Node{id:ID!,email:String@deprecated(reason:"Use contact."),role:Role,joined:Time}
+"A search result." union Result=User
+enum Role{"Can do anything." ADMIN,GUEST@deprecated(reason:"Removed.")}
+input Filter{"Only this role." role:Role,tags:[String!]}
+"RFC 3339 time." scalar Time@specifiedBy(url:"https://tools.ietf.org/html/rfc3339")
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, buf.String())
+	}
+
+	if s.QueryType().Name() != "Root" || s.QueryType().Description() != "The root." {
+		t.Errorf("unexpected query type %q: %q", s.QueryType(), s.QueryType().Description())
+	}
+	user := s.QueryType().Field("user")
+	if user.Description() != "Find a user." || user.Arg("id").Description() != "The id." {
+		t.Errorf("unexpected descriptions %q and %q", user.Description(), user.Arg("id").Description())
+	}
+	if search := s.QueryType().Field("search"); search.DeprecationReason() != DefaultDeprecationReason {
+		t.Errorf("expected search to be deprecated with the default reason but got %q", search.DeprecationReason())
+	}
+	if email := s.Type("User").(*Object).Field("email"); email.DeprecationReason() != "Use contact." {
+		t.Errorf("expected email to be deprecated but got %q", email.DeprecationReason())
+	}
+	role := s.Type("Role").(*Enum)
+	if role.Value("ADMIN").Description() != "Can do anything." || role.Value("GUEST").DeprecationReason() != "Removed." {
+		t.Errorf("unexpected enum values %v", role.Values())
+	}
+	if f := s.Type("Filter").(*InputObject).Field("role"); f.Description() != "Only this role." {
+		t.Errorf("unexpected input field description %q", f.Description())
+	}
+	if d := s.Type("Time").Description(); d != "RFC 3339 time." {
+		t.Errorf("unexpected scalar description %q", d)
+	}
+	if u := s.Type("Time").(*Scalar).SpecifiedByURL(); u != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("unexpected scalar specification URL %q", u)
+	}
+	if d := s.Type("String").Description(); d == "Built-in." {
+		t.Errorf("built-in scalar should not be modified")
+	}
+
+	// Only the data.
+	data := strings.TrimSuffix(strings.TrimPrefix(introspectionResult, `{"data": `), "}")
+	if _, _, err := FromIntrospection(strings.NewReader(data)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestFromIntrospectionErrors(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected string
+	}{
+		{`{`, "failed to decode introspection result: unexpected EOF"},
+		{`{"data": null, "errors": [{"message": "not allowed"}]}`, "not allowed"},
+		{`{"data": {}}`, "introspection result has no __schema"},
+		{`{"__schema": {"types": [{"kind": "THING", "name": "A"}]}}`, `type "A" has unknown kind "THING"`},
+		{`{"__schema": {"types": [{"kind": "OBJECT", "name": "A", "fields": [{"name": "a", "type": {"kind": "LIST"}}]}]}}`,
+			`field "a": LIST type reference is missing ofType`},
+		{`{"__schema": {"types": [{"kind": "INPUT_OBJECT", "name": "A", "inputFields": [{"name": "a", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "$a"}]}]}}`,
+			`input value "a": invalid default value "$a": variable may not be constant`},
+		{`{"__schema": {"types": [{"kind": "OBJECT", "name": "A", "fields": [{"name": "a", "type": {"kind": "SCALAR", "name": "B"}}]}]}}`,
+			`unknown type "B" (at position 0)`},
+	} {
+		if _, _, err := FromIntrospection(strings.NewReader(test.input)); err == nil {
+			t.Errorf("%s: expected error", test.input)
+		} else if err.Error() != test.expected {
+			t.Errorf("%s: expected error %q but got %q", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestIntrospectionQuery(t *testing.T) {
+	d := IntrospectionQuery()
+	if d == IntrospectionQuery() {
+		t.Error("expected a new Document")
+	}
+	if len(d.Definitions) != 4 {
+		t.Fatalf("expected 4 definitions but got %d", len(d.Definitions))
+	}
+	op, ok := d.Definitions[0].(*ast.OpDef)
+	if !ok || op.Name.Value != "IntrospectionQuery" || op.OpType != ast.Query {
+		t.Errorf("unexpected operation %v", d.Definitions[0])
+	}
+	for i, name := range []string{"FullType", "InputValue", "TypeRef"} {
+		if f, ok := d.Definitions[i+1].(*ast.FragmentDef); !ok || f.Name.Value != name {
+			t.Errorf("expected fragment %s but got %v", name, d.Definitions[i+1])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+const introspectionSDL = `
+type Query {
+	user(id: ID! = "1", limit: Int = 10): User
+	search: [Result]
+}
+interface Node {id: ID!}
+type User implements Node {
+	id: ID!
+	name: String @deprecated(reason: "Use id.")
+	role: Role
+}
+union Result = User
+enum Role {ADMIN, USER @deprecated(reason: "Use id.")}
+input Filter {role: Role = ADMIN}
+`
+
+func testIntrospectionSchema(t *testing.T) *Schema {
+	return mustBuild(t, introspectionSDL)
+}
+
+func resolve(t *testing.T, f *Field, source interface{}, args map[string]interface{}, info *ResolveInfo) interface{} {
+	v, err := f.Resolve()(context.Background(), source, args, info)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %s", f.Name(), err)
+	}
+	return v
+}
+
+func TestMetaFields(t *testing.T) {
+	s := testIntrospectionSchema(t)
+	query := s.QueryType()
+	user := s.Type("User").(*Object)
+	info := &ResolveInfo{Schema: s, ParentType: user}
+
+	if v := resolve(t, SchemaMetaField, nil, nil, info); v != s {
+		t.Errorf("expected schema but got %v", v)
+	}
+	if v := resolve(t, TypeMetaField, nil, map[string]interface{}{"name": "User"}, info); v != user {
+		t.Errorf("expected User but got %v", v)
+	}
+	if v := resolve(t, TypeMetaField, nil, map[string]interface{}{"name": "Unknown"}, info); v != nil {
+		t.Errorf("expected nil but got %#v", v)
+	}
+	if v := resolve(t, TypeNameMetaField, nil, nil, info); v != "User" {
+		t.Errorf("expected User but got %v", v)
+	}
+
+	for _, test := range []struct {
+		parent   NamedType
+		name     string
+		expected *Field
+	}{
+		{query, "__schema", SchemaMetaField},
+		{query, "__type", TypeMetaField},
+		{query, "__typename", TypeNameMetaField},
+		{query, "user", query.Field("user")},
+		{user, "__schema", nil},
+		{user, "__type", nil},
+		{user, "__typename", TypeNameMetaField},
+		{s.Type("Node"), "__typename", TypeNameMetaField},
+		{s.Type("Node"), "id", s.Type("Node").(*Interface).Field("id")},
+		{s.Type("Result"), "__typename", TypeNameMetaField},
+		{s.Type("Result"), "id", nil},
+		{String, "__typename", nil},
+		{user, "unknown", nil},
+	} {
+		if actual := s.Field(test.parent, test.name); actual != test.expected {
+			t.Errorf("%s.%s: expected %v but got %v", test.parent, test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestIntrospectionTypes(t *testing.T) {
+	s := testIntrospectionSchema(t)
+	user := s.Type("User").(*Object)
+	role := s.Type("Role").(*Enum)
+	field := func(o *Object, name string) *Field {
+		f := o.Field(name)
+		if f == nil {
+			t.Fatalf("%s has no field %q", o.Name(), name)
+		}
+		return f
+	}
+	includeDeprecated := map[string]interface{}{"includeDeprecated": true}
+	excludeDeprecated := map[string]interface{}{"includeDeprecated": false}
+
+	for _, test := range []struct {
+		field    *Field
+		source   interface{}
+		args     map[string]interface{}
+		expected interface{}
+	}{
+		// __Schema
+		{field(SchemaType, "types"), s, nil, s.Types()},
+		{field(SchemaType, "queryType"), s, nil, s.QueryType()},
+		{field(SchemaType, "mutationType"), s, nil, nil},
+		{field(SchemaType, "subscriptionType"), s, nil, nil},
+
+		// __Type
+		{field(TypeType, "kind"), user, nil, ObjectKind},
+		{field(TypeType, "kind"), NonNull(user), nil, NonNullKind},
+		{field(TypeType, "name"), user, nil, "User"},
+		{field(TypeType, "name"), List(user), nil, nil},
+		{field(TypeType, "description"), user, nil, nil},
+		{field(TypeType, "description"), SchemaType, nil, SchemaType.Description()},
+		{field(TypeType, "fields"), user, includeDeprecated, user.Fields()},
+		{field(TypeType, "fields"), user, excludeDeprecated, []*Field{user.Field("id"), user.Field("role")}},
+		{field(TypeType, "fields"), user, nil, []*Field{user.Field("id"), user.Field("role")}},
+		{field(TypeType, "fields"), s.Type("Node"), nil, s.Type("Node").(*Interface).Fields()},
+		{field(TypeType, "fields"), role, nil, nil},
+		{field(TypeType, "interfaces"), user, nil, user.Interfaces()},
+		{field(TypeType, "interfaces"), role, nil, nil},
+		{field(TypeType, "possibleTypes"), s.Type("Node"), nil, []*Object{user}},
+		{field(TypeType, "possibleTypes"), s.Type("Result"), nil, []*Object{user}},
+		{field(TypeType, "possibleTypes"), user, nil, nil},
+		{field(TypeType, "enumValues"), role, includeDeprecated, role.Values()},
+		{field(TypeType, "enumValues"), role, nil, []*EnumValue{role.Value("ADMIN")}},
+		{field(TypeType, "enumValues"), user, nil, nil},
+		{field(TypeType, "inputFields"), s.Type("Filter"), nil, s.Type("Filter").(*InputObject).Fields()},
+		{field(TypeType, "inputFields"), user, nil, nil},
+		{field(TypeType, "ofType"), NonNull(user), nil, user},
+		{field(TypeType, "ofType"), user, nil, nil},
+
+		// __Field
+		{field(FieldType, "name"), user.Field("name"), nil, "name"},
+		{field(FieldType, "description"), user.Field("name"), nil, nil},
+		{field(FieldType, "args"), user.Field("name"), nil, []*InputValue{}},
+		{field(FieldType, "args"), s.QueryType().Field("user"), nil, s.QueryType().Field("user").Args()},
+		{field(FieldType, "type"), user.Field("id"), nil, user.Field("id").Type()},
+		{field(FieldType, "isDeprecated"), user.Field("name"), nil, true},
+		{field(FieldType, "isDeprecated"), user.Field("id"), nil, false},
+		{field(FieldType, "deprecationReason"), user.Field("name"), nil, "Use id."},
+		{field(FieldType, "deprecationReason"), user.Field("id"), nil, nil},
+
+		// __InputValue
+		{field(InputValueType, "name"), s.QueryType().Field("user").Arg("limit"), nil, "limit"},
+		{field(InputValueType, "type"), s.QueryType().Field("user").Arg("limit"), nil, Int},
+		{field(InputValueType, "defaultValue"), s.QueryType().Field("user").Arg("limit"), nil, "10"},
+		{field(InputValueType, "defaultValue"), s.QueryType().Field("user").Arg("id"), nil, `"1"`},
+		{field(InputValueType, "defaultValue"), s.Type("Filter").(*InputObject).Field("role"), nil, "ADMIN"},
+		{field(InputValueType, "defaultValue"), TypeMetaField.Args()[0], nil, nil},
+
+		// __EnumValue
+		{field(EnumValueType, "name"), role.Value("USER"), nil, "USER"},
+		{field(EnumValueType, "isDeprecated"), role.Value("USER"), nil, true},
+		{field(EnumValueType, "deprecationReason"), role.Value("USER"), nil, "Use id."},
+		{field(EnumValueType, "deprecationReason"), role.Value("ADMIN"), nil, nil},
+	} {
+		if actual := resolve(t, test.field, test.source, test.args, nil); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s of %v: expected %#v but got %#v", test.field.Name(), test.source, test.expected, actual)
+		}
+	}
+}
+
+func TestIntrospectionEnums(t *testing.T) {
+	if v := TypeKindType.Value("INPUT_OBJECT"); v == nil || v.Value() != InputObjectKind {
+		t.Errorf("unexpected __TypeKind value %v", v)
+	}
+	if v := DirectiveLocationType.Value("FRAGMENT_SPREAD"); v == nil || v.Value() != LocationFragmentSpread {
+		t.Errorf("unexpected __DirectiveLocation value %v", v)
+	}
+	if len(TypeKindType.Values()) != 8 {
+		t.Errorf("expected 8 type kinds but got %d", len(TypeKindType.Values()))
+	}
+}
+
+func TestIntrospectionTypesValid(t *testing.T) {
+	// The introspection types are exempt from validation, but must otherwise satisfy it.
+	v := validator{schema: newSchema(), cycleVisited: make(map[*InputObject]bool), cyclePathIndex: make(map[*InputObject]int)}
+	for _, t := range introspectionTypes {
+		switch t := t.(type) {
+		case *Object:
+			v.object(t)
+		case *Enum:
+			v.enum(t)
+		}
+	}
+	for _, f := range []*Field{SchemaMetaField, TypeMetaField, TypeNameMetaField} {
+		v.fields("Query", f.loc, []*Field{f})
+	}
+	var messages []string
+	for _, e := range v.errs {
+		if e.Message[:6] != "name \"" {
+			messages = append(messages, e.Message)
+		}
+	}
+	if len(messages) > 0 {
+		t.Errorf("unexpected errors: %q", messages)
+	}
+}
@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"sort"
+	"strings"
+)
+
+// A lineIndex maps rune offsets to line numbers.
+type lineIndex []int
+
+// The newLineIndex function returns a lineIndex of source.
+func newLineIndex(source string) lineIndex {
+	starts := lineIndex{0}
+	i := 0
+	for _, r := range source {
+		i++
+		if r == '\n' {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// The line method returns the line, starting from 1, containing the rune at offset.
+func (l lineIndex) line(offset int) int {
+	return sort.Search(len(l), func(i int) bool { return l[i] > offset })
+}
+
+// A comment is the text of a comment following '#', and the rune offset of the '#'.
+type comment struct {
+	text  string
+	start int
+}
+
+// The comments function returns the comments of source, excluding '#' characters within strings.
+func comments(source string) []comment {
+	var cs []comment
+	rs := []rune(source)
+	for i := 0; i < len(rs); i++ {
+		switch rs[i] {
+		case '#':
+			start := i
+			for i < len(rs) && rs[i] != '\n' && rs[i] != '\r' {
+				i++
+			}
+			cs = append(cs, comment{text: string(rs[start+1 : i]), start: start})
+		case '"':
+			if i+2 < len(rs) && rs[i+1] == '"' && rs[i+2] == '"' {
+				// Block string, ending with an unescaped """.
+				for i += 3; i < len(rs); i++ {
+					if rs[i] == '\\' && i+3 < len(rs) && string(rs[i+1:i+4]) == `"""` {
+						i += 3
+					} else if i+2 < len(rs) && string(rs[i:i+3]) == `"""` {
+						i += 2
+						break
+					}
+				}
+			} else {
+				for i++; i < len(rs) && rs[i] != '"' && rs[i] != '\n'; i++ {
+					if rs[i] == '\\' {
+						i++
+					}
+				}
+			}
+		}
+	}
+	return cs
+}
+
+// The all key of a lineRanges applies to all rules.
+const all = "*"
+
+// A lineRanges holds the inclusive ranges of lines on which rules are disabled or enabled, keyed by rule name.
+type lineRanges struct {
+	// The ranges disabled by gql-lint-disable-line and gql-lint-disable-next-line.
+	lines map[string][][2]int
+	// The ranges disabled by gql-lint-disable.
+	blocks map[string][][2]int
+	// The ranges on which a rule is enabled by name while all rules are disabled.
+	enabled map[string][][2]int
+}
+
+// The contains method returns true if the rule named name is disabled on line.
+func (r *lineRanges) contains(name string, line int) bool {
+	return inRanges(r.lines[name], line) || inRanges(r.lines[all], line) || inRanges(r.blocks[name], line) ||
+		inRanges(r.blocks[all], line) && !inRanges(r.enabled[name], line)
+}
+
+// The inRanges function returns true if any of ranges contains line.
+func inRanges(ranges [][2]int, line int) bool {
+	for _, lr := range ranges {
+		if lr[0] <= line && line <= lr[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// The disabledLines function returns the lines of source on which rules are disabled by comments.
+func disabledLines(source string, lines lineIndex) *lineRanges {
+	const end = int(^uint(0) >> 1)
+	ranges := &lineRanges{
+		lines:   make(map[string][][2]int),
+		blocks:  make(map[string][][2]int),
+		enabled: make(map[string][][2]int),
+	}
+	// The start lines of the open disabled and enabled ranges.
+	open := make(map[string]int)
+	openEnabled := make(map[string]int)
+	closeEnabled := func(n string, line int) {
+		if start, ok := openEnabled[n]; ok {
+			ranges.enabled[n] = append(ranges.enabled[n], [2]int{start, line - 1})
+			delete(openEnabled, n)
+		}
+	}
+	for _, c := range comments(source) {
+		fields := strings.Fields(c.text)
+		if len(fields) == 0 {
+			continue
+		}
+		line := lines.line(c.start)
+		names := ruleNames(strings.Join(fields[1:], " "))
+		switch fields[0] {
+		case "gql-lint-disable":
+			for _, n := range names {
+				closeEnabled(n, line)
+				if _, ok := open[n]; !ok {
+					open[n] = line
+				}
+			}
+		case "gql-lint-enable":
+			if len(fields) == 1 {
+				// Re-enable everything.
+				names = names[:0]
+				for n := range open {
+					names = append(names, n)
+				}
+			}
+			for _, n := range names {
+				if start, ok := open[n]; ok {
+					ranges.blocks[n] = append(ranges.blocks[n], [2]int{start, line - 1})
+					delete(open, n)
+				}
+				if n == all {
+					for e := range openEnabled {
+						closeEnabled(e, line)
+					}
+				} else if _, ok := open[all]; ok {
+					// Split the range of all rules around this one.
+					if _, ok := openEnabled[n]; !ok {
+						openEnabled[n] = line
+					}
+				}
+			}
+		case "gql-lint-disable-line":
+			for _, n := range names {
+				ranges.lines[n] = append(ranges.lines[n], [2]int{line, line})
+			}
+		case "gql-lint-disable-next-line":
+			for _, n := range names {
+				ranges.lines[n] = append(ranges.lines[n], [2]int{line + 1, line + 1})
+			}
+		}
+	}
+	for n, start := range open {
+		ranges.blocks[n] = append(ranges.blocks[n], [2]int{start, end})
+	}
+	for n, start := range openEnabled {
+		ranges.enabled[n] = append(ranges.enabled[n], [2]int{start, end})
+	}
+	return ranges
+}
+
+// The ruleNames function returns the comma or space separated rule names of s, or all if there are none.
+func ruleNames(s string) []string {
+	names := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(names) == 0 {
+		return []string{all}
+	}
+	return names
+}
@@ -0,0 +1,167 @@
+// Package lint checks schema SDL against style rules, beyond the validity required by the spec: naming conventions,
+// descriptions, deprecation reasons, and the like.
+//
+// Rules may be disabled for a whole Linter, or for parts of a source by comments, similar to eslint:
+//
+//	# gql-lint-disable [rule, ...]
+//	# gql-lint-enable [rule, ...]
+//	type foo {bar: Int} # gql-lint-disable-line type-names
+//	# gql-lint-disable-next-line
+//	type baz {qux: Int}
+//
+// Without rule names, the comments apply to all rules. A disable comment applies from its own line until a matching
+// enable comment, or the end of the source. Enabling a rule by name while all rules are disabled re-enables just that
+// rule.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+)
+
+// A Rule checks one aspect of the style of a schema.
+type Rule struct {
+	// The Name identifies the Rule, e.g. in comments disabling it.
+	Name        string
+	Description string
+	// Check is called with each definition, field definition, argument, input field, and enum value, and reports
+	// problems to c.
+	Check func(c *Context, n ast.Node)
+}
+
+// An Issue is a problem reported by a Rule.
+type Issue struct {
+	Rule    string
+	Message string
+	Loc     ast.Loc
+	// The line of Loc, starting from 1, or 0 if the source is unknown.
+	Line int
+}
+
+// The String method returns a summary of i, e.g. `type-names: Type "foo" should be PascalCase. (at line 3)`.
+func (i *Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s (at position %d)", i.Rule, i.Message, i.Loc.Start)
+	}
+	return fmt.Sprintf("%s: %s (at line %d)", i.Rule, i.Message, i.Line)
+}
+
+// A Context holds the state of a Rule checking a single node.
+type Context struct {
+	rule   *Rule
+	parent ast.Node
+	issues *[]Issue
+}
+
+// The Parent method returns the definition containing the current node, or nil for top level definitions. For
+// example, the ObjTypeDef of a FieldDef, or the FieldDef of an argument's InputValueDef.
+func (c *Context) Parent() ast.Node { return c.parent }
+
+// The Reportf method reports an issue at loc, with a message formatted by fmt.Sprintf.
+func (c *Context) Reportf(loc ast.Loc, format string, args ...interface{}) {
+	*c.issues = append(*c.issues, Issue{Rule: c.rule.Name, Message: fmt.Sprintf(format, args...), Loc: loc})
+}
+
+// A Linter checks documents with a set of Rules, each of which may be disabled.
+type Linter struct {
+	rules    []*Rule
+	disabled map[string]bool
+}
+
+// The New function returns a Linter checking rules, or BuiltInRules if none are given, all enabled.
+func New(rules ...*Rule) *Linter {
+	if len(rules) == 0 {
+		rules = BuiltInRules
+	}
+	return &Linter{rules: rules, disabled: make(map[string]bool)}
+}
+
+// The Disable method disables the rules named names, and returns l.
+func (l *Linter) Disable(names ...string) *Linter {
+	for _, n := range names {
+		l.disabled[n] = true
+	}
+	return l
+}
+
+// The Enable method enables the rules named names, and returns l.
+func (l *Linter) Enable(names ...string) *Linter {
+	for _, n := range names {
+		delete(l.disabled, n)
+	}
+	return l
+}
+
+// The Lint method parses and checks source, returning the issues which are not disabled by comments, ordered by
+// location.
+func (l *Linter) Lint(source string) ([]Issue, error) {
+	d, err := parser.ParseString(source)
+	if err != nil {
+		return nil, err
+	}
+	issues := l.LintDocument(d)
+	lines := newLineIndex(source)
+	disabled := disabledLines(source, lines)
+	var enabled []Issue
+	for _, i := range issues {
+		i.Line = lines.line(i.Loc.Start)
+		if !disabled.contains(i.Rule, i.Line) {
+			enabled = append(enabled, i)
+		}
+	}
+	return enabled, nil
+}
+
+// The LintDocument method checks d, returning the issues ordered by location. Without the source, comments can not
+// disable rules.
+func (l *Linter) LintDocument(d *ast.Document) []Issue {
+	var issues []Issue
+	for _, r := range l.rules {
+		if l.disabled[r.Name] {
+			continue
+		}
+		c := &Context{rule: r, issues: &issues}
+		for _, def := range d.Definitions {
+			walk(c, def)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Loc.Start < issues[j].Loc.Start })
+	return issues
+}
+
+// The walk function checks def and the definitions it contains with c's rule.
+func walk(c *Context, def ast.Definition) {
+	check := func(parent, n ast.Node) {
+		c.parent = parent
+		c.rule.Check(c, n)
+	}
+	check(nil, def)
+	fields := func(fds []ast.FieldDef) {
+		for i := range fds {
+			fd := &fds[i]
+			check(def, fd)
+			for j := range fd.Arguments {
+				check(fd, &fd.Arguments[j])
+			}
+		}
+	}
+	switch def := def.(type) {
+	case *ast.ObjTypeDef:
+		fields(def.FieldDefs)
+	case *ast.TypeExtDef:
+		fields(def.FieldDefs)
+	case *ast.InterfaceTypeDef:
+		fields(def.FieldDefs)
+	case *ast.EnumTypeDef:
+		for i := range def.EnumValueDefs {
+			check(def, &def.EnumValueDefs[i])
+		}
+	case *ast.InputObjTypeDef:
+		for i := range def.Fields {
+			check(def, &def.Fields[i])
+		}
+	}
+}
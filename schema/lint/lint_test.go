@@ -0,0 +1,206 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+// The lint function returns the summaries of the issues of source.
+func lint(t *testing.T, l *Linter, source string) []string {
+	issues, err := l.Lint(source)
+	if err != nil {
+		t.Fatalf("failed to lint %q: %s", source, err)
+	}
+	var actual []string
+	for i := range issues {
+		actual = append(actual, issues[i].String())
+	}
+	return actual
+}
+
+func TestRules(t *testing.T) {
+	for _, test := range []struct {
+		rule     *Rule
+		source   string
+		expected []string
+	}{
+		{TypeNames, `type User {id: ID} enum HTTPStatus {OK} input UserInput2 {a: Int} scalar URL union SearchResult = User`, nil},
+		{TypeNames, `type user {id: ID}
+interface Node_Type {id: ID}
+input user_input {a: Int}
+extend type userExt {a: Int}`, []string{
+			`type-names: Type "user" should be PascalCase. (at line 1)`,
+			`type-names: Type "Node_Type" should be PascalCase. (at line 2)`,
+			`type-names: Type "user_input" should be PascalCase. (at line 3)`,
+			`type-names: Type "userExt" should be PascalCase. (at line 4)`,
+		}},
+		{FieldNames, `type User {id: ID, firstName(Upper_Case: Boolean): String, url2: String} input In {someField: Int}`, nil},
+		{FieldNames, `type User {
+	first_name: String
+	LastName: String
+}
+input In {Some_field: Int}`, []string{
+			`field-names: Field "User.first_name" should be camelCase. (at line 2)`,
+			`field-names: Field "User.LastName" should be camelCase. (at line 3)`,
+			`field-names: Field "In.Some_field" should be camelCase. (at line 5)`,
+		}},
+		{EnumValueNames, `enum Status {OK, NOT_FOUND, HTTP2_ERROR}`, nil},
+		{EnumValueNames, `enum Status {
+	ok
+	NotFound
+	NOT__FOUND
+}`, []string{
+			`enum-values: Enum value "Status.ok" should be SCREAMING_CASE. (at line 2)`,
+			`enum-values: Enum value "Status.NotFound" should be SCREAMING_CASE. (at line 3)`,
+			`enum-values: Enum value "Status.NOT__FOUND" should be SCREAMING_CASE. (at line 4)`,
+		}},
+		{Descriptions, `"A user." type User {"The id." id: ID} extend type User {"A name." name: String} "An input." input In {"A field." a: Int}`, nil},
+		{Descriptions, `type User {
+	id(arg: Int): ID
+	"""  """
+	name: String
+}
+"An input."
+input In {a: Int}`, []string{
+			`descriptions: Type "User" should have a description. (at line 1)`,
+			`descriptions: Field "User.id" should have a description. (at line 2)`,
+			`descriptions: Field "User.name" should have a description. (at line 4)`,
+			`descriptions: Field "In.a" should have a description. (at line 7)`,
+		}},
+		{DeprecationReasons, `type User {name: String @deprecated(reason: "Use fullName."), fullName: String} enum E {A @deprecated(reason: "Use B."), B}`, nil},
+		{DeprecationReasons, `type User {
+	name: String @deprecated
+	nick: String @deprecated(reason: " ")
+}
+enum E {A @deprecated}`, []string{
+			`deprecation-reasons: Deprecation of "User.name" should have a reason. (at line 2)`,
+			`deprecation-reasons: Deprecation of "User.nick" should have a reason. (at line 3)`,
+			`deprecation-reasons: Deprecation of "E.A" should have a reason. (at line 5)`,
+		}},
+		{ConnectionPageInfo, `type UserConnection {edges: [UserEdge], pageInfo: PageInfo} type Connection2 {a: Int}`, nil},
+		{ConnectionPageInfo, `type UserConnection {edges: [UserEdge]}
+interface Connection {edges: [Edge]}`, []string{
+			`connection-page-info: Connection type "UserConnection" should have a "pageInfo" field. (at line 1)`,
+			`connection-page-info: Connection type "Connection" should have a "pageInfo" field. (at line 2)`,
+		}},
+	} {
+		if actual := lint(t, New(test.rule), test.source); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: %s: expected %v but got %v\n%s", test.rule.Name, test.source, test.expected, actual, pretty.Diff(test.expected, actual))
+		}
+	}
+}
+
+const badSDL = `type user {
+	first_name: String @deprecated
+}
+`
+
+func TestLinter(t *testing.T) {
+	all := []string{
+		`type-names: Type "user" should be PascalCase. (at line 1)`,
+		`descriptions: Type "user" should have a description. (at line 1)`,
+		`field-names: Field "user.first_name" should be camelCase. (at line 2)`,
+		`descriptions: Field "user.first_name" should have a description. (at line 2)`,
+		`deprecation-reasons: Deprecation of "user.first_name" should have a reason. (at line 2)`,
+	}
+	l := New()
+	if actual := lint(t, l, badSDL); !reflect.DeepEqual(actual, all) {
+		t.Errorf("expected %v but got %v\n%s", all, actual, pretty.Diff(all, actual))
+	}
+	l.Disable(Descriptions.Name, DeprecationReasons.Name)
+	expected := []string{all[0], all[2]}
+	if actual := lint(t, l, badSDL); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but got %v\n%s", expected, actual, pretty.Diff(expected, actual))
+	}
+	l.Enable(Descriptions.Name)
+	expected = []string{all[0], all[1], all[2], all[3]}
+	if actual := lint(t, l, badSDL); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but got %v\n%s", expected, actual, pretty.Diff(expected, actual))
+	}
+}
+
+func TestLintComments(t *testing.T) {
+	l := New(TypeNames, FieldNames)
+	for _, test := range []struct {
+		source   string
+		expected []string
+	}{
+		{`# gql-lint-disable
+type a {b_c: Int}
+type d {e_f: Int}`, nil},
+		{`type a {b_c: Int} # gql-lint-disable-line type-names
+type d {e_f: Int} # gql-lint-disable-line field-names, type-names`, []string{
+			`field-names: Field "a.b_c" should be camelCase. (at line 1)`,
+		}},
+		{`# gql-lint-disable-next-line
+type a {b_c: Int}
+type d {e_f: Int}`, []string{
+			`type-names: Type "d" should be PascalCase. (at line 3)`,
+			`field-names: Field "d.e_f" should be camelCase. (at line 3)`,
+		}},
+		{`# gql-lint-disable field-names
+type a {
+	b_c: Int
+	# gql-lint-enable field-names
+	d_e: Int
+}
+# gql-lint-disable
+type f {g_h: Int}
+# gql-lint-enable
+type i {j_k: Int}`, []string{
+			`type-names: Type "a" should be PascalCase. (at line 2)`,
+			`field-names: Field "a.d_e" should be camelCase. (at line 5)`,
+			`type-names: Type "i" should be PascalCase. (at line 10)`,
+			`field-names: Field "i.j_k" should be camelCase. (at line 10)`,
+		}},
+		// Enabling a rule by name splits the range disabling all rules.
+		{`# gql-lint-disable
+type a {b_c: Int}
+# gql-lint-enable type-names
+type d {e_f: Int}
+type g {h_i: Int} # gql-lint-disable-line
+# gql-lint-disable type-names
+type j {k_l: Int}
+# gql-lint-enable field-names
+type m {n_o: Int}
+# gql-lint-enable
+type p {q_r: Int}`, []string{
+			`type-names: Type "d" should be PascalCase. (at line 4)`,
+			`field-names: Field "m.n_o" should be camelCase. (at line 9)`,
+			`type-names: Type "p" should be PascalCase. (at line 11)`,
+			`field-names: Field "p.q_r" should be camelCase. (at line 11)`,
+		}},
+		// Comment characters within strings are not comments.
+		{`"# gql-lint-disable" type a {"""
+# gql-lint-disable \""" still a string
+""" b: Int}`, []string{
+			`type-names: Type "a" should be PascalCase. (at line 1)`,
+		}},
+		// Unknown directives and rules are ignored.
+		{`# gql-lint-ignore
+type a {b: Int} # gql-lint-disable-line unknown-rule`, []string{
+			`type-names: Type "a" should be PascalCase. (at line 2)`,
+		}},
+	} {
+		if actual := lint(t, l, test.source); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %v but got %v\n%s", test.source, test.expected, actual, pretty.Diff(test.expected, actual))
+		}
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	l := New(TypeNames)
+	issues, err := l.Lint("type a {b: Int}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues[0].Line = 0
+	if s := issues[0].String(); s != `type-names: Type "a" should be PascalCase. (at position 5)` {
+		t.Errorf("unexpected issue: %s", s)
+	}
+	if _, err := l.Lint("type {"); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,199 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// BuiltInRules are the rules checked by default.
+var BuiltInRules = []*Rule{TypeNames, FieldNames, EnumValueNames, Descriptions, DeprecationReasons, ConnectionPageInfo}
+
+var (
+	pascalCase    = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	camelCase     = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	screamingCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// TypeNames requires that type names are PascalCase, e.g. "UserProfile".
+var TypeNames = &Rule{
+	Name:        "type-names",
+	Description: "Type names must be PascalCase.",
+	Check: func(c *Context, n ast.Node) {
+		if name, loc, ok := typeName(n); ok && !pascalCase.MatchString(name) {
+			c.Reportf(loc, "Type %q should be PascalCase.", name)
+		}
+	},
+}
+
+// FieldNames requires that the names of fields and input fields are camelCase, e.g. "firstName".
+var FieldNames = &Rule{
+	Name:        "field-names",
+	Description: "Field names must be camelCase.",
+	Check: func(c *Context, n ast.Node) {
+		if name, loc, ok := fieldName(c, n); ok && !camelCase.MatchString(name) {
+			c.Reportf(loc, "Field %q should be camelCase.", path(c.Parent(), name))
+		}
+	},
+}
+
+// EnumValueNames requires that enum values are SCREAMING_CASE, e.g. "NOT_FOUND".
+var EnumValueNames = &Rule{
+	Name:        "enum-values",
+	Description: "Enum values must be SCREAMING_CASE.",
+	Check: func(c *Context, n ast.Node) {
+		if v, ok := n.(*ast.EnumValueDef); ok && !screamingCase.MatchString(v.Name.Value) {
+			c.Reportf(v.Loc, "Enum value %q should be SCREAMING_CASE.", path(c.Parent(), v.Name.Value))
+		}
+	},
+}
+
+// Descriptions requires that types, fields, and input fields have descriptions.
+var Descriptions = &Rule{
+	Name:        "descriptions",
+	Description: "Types and fields must have descriptions.",
+	Check: func(c *Context, n ast.Node) {
+		if name, loc, ok := typeName(n); ok {
+			if _, ext := n.(*ast.TypeExtDef); !ext && isEmpty(description(n)) {
+				c.Reportf(loc, "Type %q should have a description.", name)
+			}
+			return
+		}
+		if name, loc, ok := fieldName(c, n); ok && isEmpty(description(n)) {
+			c.Reportf(loc, "Field %q should have a description.", path(c.Parent(), name))
+		}
+	},
+}
+
+// DeprecationReasons requires that @deprecated directives give a reason.
+var DeprecationReasons = &Rule{
+	Name:        "deprecation-reasons",
+	Description: "Deprecations must have reasons.",
+	Check: func(c *Context, n ast.Node) {
+		var name string
+		var ds []ast.Directive
+		switch n := n.(type) {
+		case *ast.FieldDef:
+			name, ds = n.Name.Value, n.Directives
+		case *ast.EnumValueDef:
+			name, ds = n.Name.Value, n.Directives
+		default:
+			return
+		}
+		for _, d := range ds {
+			if d.Name.Value != "deprecated" {
+				continue
+			}
+			reason := ""
+			for _, a := range d.Arguments {
+				if s, ok := a.Value.(*ast.String); ok && a.Name.Value == "reason" {
+					reason = s.Value
+				}
+			}
+			if strings.TrimSpace(reason) == "" {
+				c.Reportf(d.Loc, "Deprecation of %q should have a reason.", path(c.Parent(), name))
+			}
+		}
+	},
+}
+
+// ConnectionPageInfo requires that connection types, whose names end with "Connection", have a pageInfo field, as
+// described by the Relay cursor connections specification.
+var ConnectionPageInfo = &Rule{
+	Name:        "connection-page-info",
+	Description: `Connection types must have a "pageInfo" field.`,
+	Check: func(c *Context, n ast.Node) {
+		var name ast.Name
+		var fds []ast.FieldDef
+		switch n := n.(type) {
+		case *ast.ObjTypeDef:
+			name, fds = n.Name, n.FieldDefs
+		case *ast.InterfaceTypeDef:
+			name, fds = n.Name, n.FieldDefs
+		default:
+			return
+		}
+		if !strings.HasSuffix(name.Value, "Connection") {
+			return
+		}
+		for _, fd := range fds {
+			if fd.Name.Value == "pageInfo" {
+				return
+			}
+		}
+		c.Reportf(name.Loc, "Connection type %q should have a \"pageInfo\" field.", name.Value)
+	},
+}
+
+// The typeName function returns the name and location of n, if it is a type definition.
+func typeName(n ast.Node) (string, ast.Loc, bool) {
+	switch n := n.(type) {
+	case *ast.ObjTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.TypeExtDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.InterfaceTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.UnionTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.ScalarTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.EnumTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.InputObjTypeDef:
+		return n.Name.Value, n.Name.Loc, true
+	}
+	return "", ast.Loc{}, false
+}
+
+// The fieldName function returns the name and location of n, if it is a field definition or input field, but not an
+// argument.
+func fieldName(c *Context, n ast.Node) (string, ast.Loc, bool) {
+	switch n := n.(type) {
+	case *ast.FieldDef:
+		return n.Name.Value, n.Name.Loc, true
+	case *ast.InputValueDef:
+		if _, ok := c.Parent().(*ast.InputObjTypeDef); ok {
+			return n.Name.Value, n.Name.Loc, true
+		}
+	}
+	return "", ast.Loc{}, false
+}
+
+// The description function returns the description of n, or nil if it has none.
+func description(n ast.Node) *ast.String {
+	switch n := n.(type) {
+	case *ast.ObjTypeDef:
+		return n.Description
+	case *ast.InterfaceTypeDef:
+		return n.Description
+	case *ast.UnionTypeDef:
+		return n.Description
+	case *ast.ScalarTypeDef:
+		return n.Description
+	case *ast.EnumTypeDef:
+		return n.Description
+	case *ast.InputObjTypeDef:
+		return n.Description
+	case *ast.FieldDef:
+		return n.Description
+	case *ast.InputValueDef:
+		return n.Description
+	case *ast.EnumValueDef:
+		return n.Description
+	}
+	return nil
+}
+
+func isEmpty(s *ast.String) bool {
+	return s == nil || strings.TrimSpace(s.Value) == ""
+}
+
+// The path function returns the name qualified by the name of its parent type, e.g. "User.email".
+func path(parent ast.Node, name string) string {
+	if typ, _, ok := typeName(parent); ok {
+		return typ + "." + name
+	}
+	return name
+}
@@ -0,0 +1,227 @@
+package schema
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+// The Document method returns a Document of the type system definitions of s, which the printer renders as SDL.
+// Built-in scalars, built-in directives, and introspection types are omitted. A SchemaDef is included only if the root
+// types could not otherwise be inferred from their names. Custom directive definitions follow it, before the types.
+//
+// Types are in definition order, unless sorted is true, in which case directives, types, and their fields, arguments,
+// input fields, enum values, interfaces, and union members, are sorted by name. Descriptions are included, and
+// deprecations are expressed as @deprecated directives.
+func (s *Schema) Document(sorted bool) *ast.Document {
+	d := &ast.Document{}
+	if sd := s.schemaDef(); sd != nil {
+		d.Definitions = append(d.Definitions, sd)
+	}
+	var directives []*Directive
+	for _, dir := range s.directives {
+		if !IsBuiltInDirective(dir.name) {
+			directives = append(directives, dir)
+		}
+	}
+	if sorted {
+		sort.SliceStable(directives, func(i, j int) bool { return directives[i].name < directives[j].name })
+	}
+	for _, dir := range directives {
+		d.Definitions = append(d.Definitions, directiveDefOf(dir, sorted))
+	}
+	types := s.userTypes()
+	if sorted {
+		sort.SliceStable(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+	}
+	for _, t := range types {
+		d.Definitions = append(d.Definitions, typeDefOf(t, sorted))
+	}
+	return d
+}
+
+// The Print function prints the type system definitions of s to w as SDL, in the style st. Definitions are separated
+// by a blank line in the Pretty style, and by a newline in the Compact style. See the Document method for details of
+// which definitions are printed, and in what order.
+func Print(w io.Writer, s *Schema, st printer.Style, sorted bool) error {
+	sep := "\n"
+	if st == printer.Pretty {
+		sep = "\n\n"
+	}
+	for i, def := range s.Document(sorted).Definitions {
+		if i > 0 {
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+		}
+		if err := st.Fprint(w, def); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// The userTypes method returns the types of s, excluding the built-in scalars and introspection types.
+func (s *Schema) userTypes() []NamedType {
+	var types []NamedType
+	for _, t := range s.typesOrder {
+		if strings.HasPrefix(t.Name(), "__") {
+			continue
+		}
+		if sc, ok := t.(*Scalar); ok && isBuiltInScalar(sc.name) {
+			continue
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// The schemaDef method returns a SchemaDef declaring the root types of s, or nil if Build would infer the same roots
+// from the type names alone.
+func (s *Schema) schemaDef() *ast.SchemaDef {
+	inferred := true
+	for _, root := range []struct {
+		o    *Object
+		name string
+	}{{s.query, "Query"}, {s.mutation, "Mutation"}, {s.subscription, "Subscription"}} {
+		if root.o != nil {
+			inferred = inferred && root.o.name == root.name
+		} else {
+			_, isObject := s.Type(root.name).(*Object)
+			inferred = inferred && !isObject
+		}
+	}
+	if inferred {
+		return nil
+	}
+	sd := &ast.SchemaDef{}
+	for _, root := range []struct {
+		ast.OpType
+		o *Object
+	}{{ast.Query, s.query}, {ast.Mutation, s.mutation}, {ast.Subscription, s.subscription}} {
+		if root.o != nil {
+			sd.OpTypeDefs = append(sd.OpTypeDefs, ast.OpTypeDef{OpType: root.OpType, NamedType: ast.NamedType{Value: root.o.name}})
+		}
+	}
+	return sd
+}
+
+// The directiveDefOf function returns the definition of d.
+func directiveDefOf(d *Directive, sorted bool) *ast.DirectiveDef {
+	locations := make([]ast.Name, len(d.locations))
+	for i, l := range d.locations {
+		locations[i] = ast.Name{Value: string(l)}
+	}
+	return &ast.DirectiveDef{
+		Description: descriptionOf(d.description),
+		Name:        ast.Name{Value: d.name},
+		Arguments:   inputValueDefsOfValues(d.args, sorted),
+		Repeatable:  d.repeatable,
+		Locations:   locations,
+	}
+}
+
+// The typeDefOf function returns the definition of t.
+func typeDefOf(t NamedType, sorted bool) ast.TypeDef {
+	name := ast.Name{Value: t.Name()}
+	description := descriptionOf(t.Description())
+	switch t := t.(type) {
+	case *Scalar:
+		return &ast.ScalarTypeDef{Description: description, Name: name, Directives: specifiedByDirectives(t.specifiedBy)}
+	case *Object:
+		var interfaces []string
+		for _, i := range t.interfaces {
+			interfaces = append(interfaces, i.name)
+		}
+		return &ast.ObjTypeDef{
+			Description: description,
+			Name:        name,
+			Interfaces:  namedTypes(sortedNames(interfaces, sorted)),
+			FieldDefs:   fieldDefsOfFields(t.fields, sorted),
+		}
+	case *Interface:
+		return &ast.InterfaceTypeDef{Description: description, Name: name, FieldDefs: fieldDefsOfFields(t.fields, sorted)}
+	case *Union:
+		var members []string
+		for _, o := range t.types {
+			members = append(members, o.name)
+		}
+		return &ast.UnionTypeDef{Description: description, Name: name, NamedTypes: namedTypes(sortedNames(members, sorted))}
+	case *Enum:
+		values := append([]*EnumValue(nil), t.values...)
+		if sorted {
+			sort.SliceStable(values, func(i, j int) bool { return values[i].name < values[j].name })
+		}
+		evds := make([]ast.EnumValueDef, len(values))
+		for i, v := range values {
+			evds[i] = ast.EnumValueDef{
+				Description: descriptionOf(v.description),
+				Name:        ast.Name{Value: v.name},
+				Directives:  deprecatedDirectivesOf(v.deprecationReason),
+			}
+		}
+		return &ast.EnumTypeDef{Description: description, Name: name, EnumValueDefs: evds}
+	case *InputObject:
+		return &ast.InputObjTypeDef{Description: description, Name: name, Fields: inputValueDefsOfValues(t.fields, sorted)}
+	}
+	return nil
+}
+
+// The sortedNames function returns names, sorted if sorted is true.
+func sortedNames(names []string, sorted bool) []string {
+	if sorted {
+		sort.Strings(names)
+	}
+	return names
+}
+
+func fieldDefsOfFields(fs []*Field, sorted bool) []ast.FieldDef {
+	fs = append([]*Field(nil), fs...)
+	if sorted {
+		sort.SliceStable(fs, func(i, j int) bool { return fs[i].name < fs[j].name })
+	}
+	fds := make([]ast.FieldDef, len(fs))
+	for i, f := range fs {
+		fds[i] = ast.FieldDef{
+			Description: descriptionOf(f.description),
+			Name:        ast.Name{Value: f.name},
+			Arguments:   inputValueDefsOfValues(f.args, sorted),
+			RefType:     refType(f.typ),
+			Directives:  deprecatedDirectivesOf(f.deprecationReason),
+		}
+	}
+	return fds
+}
+
+func inputValueDefsOfValues(vs []*InputValue, sorted bool) []ast.InputValueDef {
+	if len(vs) == 0 {
+		return nil
+	}
+	vs = append([]*InputValue(nil), vs...)
+	if sorted {
+		sort.SliceStable(vs, func(i, j int) bool { return vs[i].name < vs[j].name })
+	}
+	ivds := make([]ast.InputValueDef, len(vs))
+	for i, v := range vs {
+		ivds[i] = ast.InputValueDef{
+			Description:  descriptionOf(v.description),
+			Name:         ast.Name{Value: v.name},
+			RefType:      refType(v.typ),
+			DefaultValue: v.defaultValue,
+		}
+	}
+	return ivds
+}
+
+// The deprecatedDirectivesOf function returns a @deprecated directive with reason, or nil if reason is nil. The
+// reason argument is omitted if it is the DefaultDeprecationReason.
+func deprecatedDirectivesOf(reason *string) []ast.Directive {
+	if reason != nil && *reason == DefaultDeprecationReason {
+		return deprecatedDirectives(true, nil)
+	}
+	return deprecatedDirectives(reason != nil, reason)
+}
@@ -0,0 +1,169 @@
+package schema
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/lang/printer"
+)
+
+const printSDL = `
+schema {query: Root}
+"""
+The root type.
+Second line.
+"""
+type Root {
+	"Find a user."
+	user(id: ID!, "Max." limit: Int = 10): User
+	search(filter: Filter): [Result!]! @deprecated
+	node: Node
+}
+type User implements Node {id: ID!, email: String @deprecated(reason: "Use \"contact\".")}
+interface Node {id: ID!}
+union Result = User | Bot
+type Bot implements Node {id: ID!}
+"Roles." enum Role {USER, ADMIN @deprecated(reason: "Gone.")}
+input Filter {role: Role = USER, name: String}
+scalar Time
+extend type User {joined: Time, role: Role}
+`
+
+func TestPrint(t *testing.T) {
+	s := mustBuild(t, printSDL)
+	for _, test := range []struct {
+		style    printer.Style
+		sorted   bool
+		expected string
+	}{
+		{printer.Compact, false, `schema{query:Root}
+"The root type.\nSecond line." type Root{"Find a user." user(id:ID!,"Max." limit:Int=10):User,search(filter:Filter):[Result!]!@deprecated,node:Node}
+type User implements Node{id:ID!,email:String@deprecated(reason:"Use \"contact\"."),joined:Time,role:Role}
+interface Node{id:ID!}
+union Result=User|Bot
+type Bot implements Node{id:ID!}
+"Roles." enum Role{USER,ADMIN@deprecated(reason:"Gone.")}
+input Filter{role:Role=USER,name:String}
+scalar Time
+`},
+		{printer.Compact, true, `schema{query:Root}
+type Bot implements Node{id:ID!}
+input Filter{name:String,role:Role=USER}
+interface Node{id:ID!}
+union Result=Bot|User
+"Roles." enum Role{ADMIN@deprecated(reason:"Gone."),USER}
+"The root type.\nSecond line." type Root{node:Node,search(filter:Filter):[Result!]!@deprecated,"Find a user." user(id:ID!,"Max." limit:Int=10):User}
+scalar Time
+type User implements Node{email:String@deprecated(reason:"Use \"contact\"."),id:ID!,joined:Time,role:Role}
+`},
+		{printer.Pretty, true, `schema{
+	query:Root
+}
+
+type Bot implements Node{
+	id:ID!
+}
+
+input Filter{
+	name:String,
+	role:Role=USER
+}
+
+interface Node{
+	id:ID!
+}
+
+union Result=Bot|User
+
+"Roles."
+enum Role{
+	ADMIN
+	@deprecated(
+		reason:"Gone."
+	),
+	USER
+}
+
+"""
+The root type.
+Second line.
+"""
+type Root{
+	node:Node,
+	search(
+		filter:Filter
+	):[Result!]!
+	@deprecated,
+	"Find a user."
+	user(
+		id:ID!,
+		"Max."
+		limit:Int=10
+	):User
+}
+
+scalar Time
+
+type User implements Node{
+	email:String
+	@deprecated(
+		reason:"Use \"contact\"."
+	),
+	id:ID!,
+	joined:Time,
+	role:Role
+}
+`},
+	} {
+		var b bytes.Buffer
+		if err := Print(&b, s, test.style, test.sorted); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != test.expected {
+			t.Errorf("style %d sorted %t: expected:\n%s\nbut got:\n%s", test.style, test.sorted, test.expected, b.String())
+		}
+
+		// The printed schema must build to a schema which prints identically.
+		d, err := parser.ParseString(b.String())
+		if err != nil {
+			t.Fatalf("style %d sorted %t: failed to parse output: %s", test.style, test.sorted, err)
+		}
+		rebuilt, err := Build(d)
+		if err != nil {
+			t.Fatalf("style %d sorted %t: failed to build output: %s", test.style, test.sorted, err)
+		}
+		var again bytes.Buffer
+		if err := Print(&again, rebuilt, test.style, test.sorted); err != nil {
+			t.Fatal(err)
+		}
+		if again.String() != b.String() {
+			t.Errorf("style %d sorted %t: expected reprinted schema:\n%s\nbut got:\n%s", test.style, test.sorted, b.String(), again.String())
+		}
+	}
+}
+
+func TestPrintSchemaDef(t *testing.T) {
+	for _, test := range []struct {
+		sdl      string
+		expected string
+	}{
+		{"type Query {a: Int}", "type Query{a:Int}\n"},
+		{"type Query {a: Int} type Mutation {b: Int}", "type Query{a:Int}\ntype Mutation{b:Int}\n"},
+		{"schema {query: Q} type Q {a: Int}", "schema{query:Q}\ntype Q{a:Int}\n"},
+		// Mutation is not the mutation root, so it can not be inferred.
+		{"schema {query: Query} type Query {a: Int} type Mutation {b: Int}",
+			"schema{query:Query}\ntype Query{a:Int}\ntype Mutation{b:Int}\n"},
+		// Custom directives precede the types, and built-in directives are omitted.
+		{`type Query {a: Int} "D." directive @d(a: Int = 1) repeatable on FIELD | QUERY`,
+			"\"D.\" directive @d(a:Int=1) repeatable on FIELD|QUERY\ntype Query{a:Int}\n"},
+	} {
+		var b bytes.Buffer
+		if err := Print(&b, mustBuild(t, test.sdl), printer.Compact, false); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != test.expected {
+			t.Errorf("%s: expected:\n%s\nbut got:\n%s", test.sdl, test.expected, b.String())
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// A ResolveFunc returns the value of a field of source, given the field's coerced argument values.
+type ResolveFunc func(ctx context.Context, source interface{}, args map[string]interface{}, info *ResolveInfo) (interface{}, error)
+
+// A ResolveTypeFunc returns the Object which is the runtime type of value, a value of an Interface or Union.
+type ResolveTypeFunc func(ctx context.Context, value interface{}, info *ResolveInfo) *Object
+
+// A ResolveInfo describes the field being resolved, and the execution it is part of.
+type ResolveInfo struct {
+	// The name of the field being resolved.
+	FieldName string
+	// The fields being resolved. There may be more than one if fields were merged.
+	FieldASTs []*ast.Field
+	// The declared type of the field.
+	ReturnType Type
+	// The type containing the field.
+	ParentType *Object
+	// The response path to the field. Elements are response keys (string) or list indexes (int).
+	Path []interface{}
+
+	Schema         *Schema
+	Fragments      map[string]*ast.FragmentDef
+	RootValue      interface{}
+	Operation      *ast.OpDef
+	VariableValues map[string]interface{}
+}
@@ -0,0 +1,362 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// A Coercer coerces the values of a Scalar between their input, internal, and serialized representations.
+type Coercer interface {
+	// The ParseLiteral method returns the internal value of the input literal v, or an error if it is invalid.
+	// Variables must be replaced by their values before parsing.
+	ParseLiteral(v ast.Value) (interface{}, error)
+	// The ParseValue method returns the internal value of the input value v, e.g. a variable value decoded from JSON,
+	// or an error if it is invalid.
+	ParseValue(v interface{}) (interface{}, error)
+	// The Serialize method returns the result value of the internal value v, or an error if it can not be
+	// represented.
+	Serialize(v interface{}) (interface{}, error)
+}
+
+// The ParseLiteral method parses v with the Coercer of s. Scalars without a Coercer accept any literal, as returned by
+// the LiteralValue function.
+func (s *Scalar) ParseLiteral(v ast.Value) (interface{}, error) {
+	if s.coercer == nil {
+		return LiteralValue(v)
+	}
+	return s.coercer.ParseLiteral(v)
+}
+
+// The ParseValue method parses v with the Coercer of s. Scalars without a Coercer accept any value as is.
+func (s *Scalar) ParseValue(v interface{}) (interface{}, error) {
+	if s.coercer == nil {
+		return v, nil
+	}
+	return s.coercer.ParseValue(v)
+}
+
+// The Serialize method serializes v with the Coercer of s. Scalars without a Coercer serialize any value as is.
+func (s *Scalar) Serialize(v interface{}) (interface{}, error) {
+	if s.coercer == nil {
+		return v, nil
+	}
+	return s.coercer.Serialize(v)
+}
+
+// The Coercer method returns the Coercer of s, or nil if there is none.
+func (s *Scalar) Coercer() Coercer { return s.coercer }
+
+// The LiteralValue function returns the untyped value of the input literal v. Ints are returned as int64, Floats as
+// float64, Strings and Enums as string, Booleans as bool, Lists as []interface{}, and Objects as
+// map[string]interface{}. Variables are not allowed.
+func LiteralValue(v ast.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case *ast.Int:
+		return strconv.ParseInt(v.Value, 10, 64)
+	case *ast.Float:
+		return strconv.ParseFloat(v.Value, 64)
+	case *ast.String:
+		return v.Value, nil
+	case *ast.Boolean:
+		return v.Value, nil
+	case *ast.Enum:
+		return v.Value, nil
+	case *ast.List:
+		l := make([]interface{}, len(v.Values))
+		for i, e := range v.Values {
+			ev, err := LiteralValue(e)
+			if err != nil {
+				return nil, err
+			}
+			l[i] = ev
+		}
+		return l, nil
+	case *ast.Object:
+		m := make(map[string]interface{}, len(v.Fields))
+		for _, f := range v.Fields {
+			fv, err := LiteralValue(f.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[f.Name.Value] = fv
+		}
+		return m, nil
+	case *ast.Variable:
+		return nil, fmt.Errorf("unexpected variable $%s", v.Name.Value)
+	}
+	return nil, fmt.Errorf("unexpected %T", v)
+}
+
+var (
+	scalarsMu sync.RWMutex
+	scalars   = map[string]Coercer{
+		"DateTime": DateTimeCoercer,
+		"JSON":     JSONCoercer,
+		"UUID":     UUIDCoercer,
+		"BigInt":   BigIntCoercer,
+	}
+)
+
+// The RegisterScalar function registers c as the Coercer of custom Scalars named name, replacing any previously
+// registered Coercer. Scalars built afterwards by the Build function use c. DateTime, JSON, UUID, and BigInt are
+// registered by default. RegisterScalar panics if name is the name of a built-in scalar, or if c is nil.
+func RegisterScalar(name string, c Coercer) {
+	if isBuiltInScalar(name) {
+		panic("schema: RegisterScalar of built-in scalar " + name)
+	}
+	if c == nil {
+		panic("schema: RegisterScalar Coercer is nil for " + name)
+	}
+	scalarsMu.Lock()
+	defer scalarsMu.Unlock()
+	scalars[name] = c
+}
+
+// The RegisteredScalar function returns the Coercer registered for custom Scalars named name, or nil if there is none.
+func RegisteredScalar(name string) Coercer {
+	scalarsMu.RLock()
+	defer scalarsMu.RUnlock()
+	return scalars[name]
+}
+
+// The intOf function returns the integer value of v, if v is an integer, or a float with an integral value.
+func intOf(v interface{}) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch k := rv.Kind(); {
+	case k >= reflect.Int && k <= reflect.Int64:
+		return rv.Int(), true
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		u := rv.Uint()
+		return int64(u), u <= math.MaxInt64
+	case k == reflect.Float32 || k == reflect.Float64:
+		f := rv.Float()
+		return int64(f), f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+	}
+	return 0, false
+}
+
+// The floatOf function returns the finite float value of v, if v is an integer or a float.
+func floatOf(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch k := rv.Kind(); {
+	case k >= reflect.Int && k <= reflect.Int64:
+		return float64(rv.Int()), true
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case k == reflect.Float32 || k == reflect.Float64:
+		f := rv.Float()
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return 0, false
+}
+
+// The stringOf function returns the value of v, if v is a string.
+func stringOf(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+	return "", false
+}
+
+// The boolOf function returns the value of v, if v is a bool.
+func boolOf(v interface{}) (bool, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Bool {
+		return rv.Bool(), true
+	}
+	return false, false
+}
+
+// The int32Of function returns the value of v as an int, if v is an integer which fits in 32 bits.
+func int32Of(v interface{}) (int, error) {
+	i, ok := intOf(v)
+	if !ok {
+		return 0, fmt.Errorf("Int cannot represent non-integer value: %s", inspect(v))
+	}
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %s", inspect(v))
+	}
+	return int(i), nil
+}
+
+// The inspect function returns a representation of v for error messages.
+func inspect(v interface{}) string {
+	if s, ok := stringOf(v); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// The unexpectedLiteral function returns an error for a literal which can not represent a value of the scalar named
+// name.
+func unexpectedLiteral(name string, v ast.Value) error {
+	return fmt.Errorf("%s cannot represent a non-%s value: %s", name, name, v.Kind())
+}
+
+// The intCoercer coerces 32-bit signed integers.
+type intCoercer struct{}
+
+func (intCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	l, ok := v.(*ast.Int)
+	if !ok {
+		return nil, unexpectedLiteral("Int", v)
+	}
+	i, err := strconv.ParseInt(l.Value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %s", l.Value)
+	}
+	return int(i), nil
+}
+
+func (intCoercer) ParseValue(v interface{}) (interface{}, error) {
+	return int32Of(v)
+}
+
+func (intCoercer) Serialize(v interface{}) (interface{}, error) {
+	if b, ok := boolOf(v); ok {
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if s, ok := stringOf(v); ok && s != "" {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return int32Of(f)
+		}
+	}
+	return int32Of(v)
+}
+
+// The floatCoercer coerces double-precision floating point numbers.
+type floatCoercer struct{}
+
+func (floatCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	switch l := v.(type) {
+	case *ast.Int:
+		return strconv.ParseFloat(l.Value, 64)
+	case *ast.Float:
+		return strconv.ParseFloat(l.Value, 64)
+	}
+	return nil, unexpectedLiteral("Float", v)
+}
+
+func (floatCoercer) ParseValue(v interface{}) (interface{}, error) {
+	if f, ok := floatOf(v); ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("Float cannot represent non numeric value: %s", inspect(v))
+}
+
+func (c floatCoercer) Serialize(v interface{}) (interface{}, error) {
+	if b, ok := boolOf(v); ok {
+		if b {
+			return 1.0, nil
+		}
+		return 0.0, nil
+	}
+	if s, ok := stringOf(v); ok && s != "" {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return c.ParseValue(f)
+		}
+	}
+	return c.ParseValue(v)
+}
+
+// The stringCoercer coerces UTF-8 strings.
+type stringCoercer struct{}
+
+func (stringCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	if l, ok := v.(*ast.String); ok {
+		return l.Value, nil
+	}
+	return nil, unexpectedLiteral("String", v)
+}
+
+func (stringCoercer) ParseValue(v interface{}) (interface{}, error) {
+	if s, ok := stringOf(v); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("String cannot represent a non string value: %s", inspect(v))
+}
+
+func (stringCoercer) Serialize(v interface{}) (interface{}, error) {
+	if s, ok := stringOf(v); ok {
+		return s, nil
+	}
+	if b, ok := boolOf(v); ok {
+		return strconv.FormatBool(b), nil
+	}
+	if i, ok := intOf(v); ok {
+		return strconv.FormatInt(i, 10), nil
+	}
+	if f, ok := floatOf(v); ok {
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent value: %s", inspect(v))
+}
+
+// The booleanCoercer coerces true or false.
+type booleanCoercer struct{}
+
+func (booleanCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	if l, ok := v.(*ast.Boolean); ok {
+		return l.Value, nil
+	}
+	return nil, unexpectedLiteral("Boolean", v)
+}
+
+func (booleanCoercer) ParseValue(v interface{}) (interface{}, error) {
+	if b, ok := boolOf(v); ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", inspect(v))
+}
+
+func (booleanCoercer) Serialize(v interface{}) (interface{}, error) {
+	if b, ok := boolOf(v); ok {
+		return b, nil
+	}
+	if f, ok := floatOf(v); ok {
+		return f != 0, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", inspect(v))
+}
+
+// The idCoercer coerces unique identifiers, which are serialized as strings, but accepted as strings or integers.
+type idCoercer struct{}
+
+func (idCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	switch l := v.(type) {
+	case *ast.String:
+		return l.Value, nil
+	case *ast.Int:
+		return l.Value, nil
+	}
+	return nil, fmt.Errorf("ID cannot represent a non-string and non-integer value: %s", v.Kind())
+}
+
+func (idCoercer) ParseValue(v interface{}) (interface{}, error) {
+	if s, ok := stringOf(v); ok {
+		return s, nil
+	}
+	if i, ok := intOf(v); ok {
+		return strconv.FormatInt(i, 10), nil
+	}
+	return nil, fmt.Errorf("ID cannot represent value: %s", inspect(v))
+}
+
+func (c idCoercer) Serialize(v interface{}) (interface{}, error) {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return c.ParseValue(v)
+}
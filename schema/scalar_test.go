@@ -0,0 +1,269 @@
+package schema
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/kr/pretty"
+)
+
+// The parseValueLiteral function parses src as a Value.
+func parseValueLiteral(t *testing.T, src string) ast.Value {
+	d, err := parser.ParseString("{f(a: " + src + ")}")
+	if err != nil {
+		t.Fatalf("failed to parse %q: %s", src, err)
+	}
+	return d.Definitions[0].(*ast.OpDef).SelectionSet.Selections[0].(*ast.Field).Arguments[0].Value
+}
+
+type myInt int
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestScalarParseLiteral(t *testing.T) {
+	for _, test := range []struct {
+		scalar   *Scalar
+		literal  string
+		expected interface{}
+		err      string
+	}{
+		{Int, "7", 7, ""},
+		{Int, "-2147483648", math.MinInt32, ""},
+		{Int, "2147483648", nil, "Int cannot represent non 32-bit signed integer value: 2147483648"},
+		{Int, "1.0", nil, "Int cannot represent a non-Int value: FloatValue"},
+		{Int, `"1"`, nil, "Int cannot represent a non-Int value: StringValue"},
+		{Float, "1", 1.0, ""},
+		{Float, "1.5e3", 1500.0, ""},
+		{Float, "true", nil, "Float cannot represent a non-Float value: BooleanValue"},
+		{String, `"abc"`, "abc", ""},
+		{String, "abc", nil, "String cannot represent a non-String value: EnumValue"},
+		{Boolean, "false", false, ""},
+		{Boolean, "0", nil, "Boolean cannot represent a non-Boolean value: IntValue"},
+		{ID, `"a1"`, "a1", ""},
+		{ID, "123", "123", ""},
+		{ID, "1.5", nil, "ID cannot represent a non-string and non-integer value: FloatValue"},
+		{&Scalar{name: "Any"}, `{a: [1, 2.5, "b", C, true]}`,
+			map[string]interface{}{"a": []interface{}{int64(1), 2.5, "b", "C", true}}, ""},
+		{&Scalar{name: "Any"}, "$v", nil, "unexpected variable $v"},
+	} {
+		actual, err := test.scalar.ParseLiteral(parseValueLiteral(t, test.literal))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s %s: expected error %q but got %v", test.scalar, test.literal, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %s", test.scalar, test.literal, err)
+		} else if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s %s: expected %# v but got %# v", test.scalar, test.literal, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+func TestScalarParseValue(t *testing.T) {
+	for _, test := range []struct {
+		scalar   *Scalar
+		value    interface{}
+		expected interface{}
+		err      string
+	}{
+		{Int, 7, 7, ""},
+		{Int, float64(7), 7, ""},
+		{Int, myInt(7), 7, ""},
+		{Int, int64(math.MaxInt32 + 1), nil, "Int cannot represent non 32-bit signed integer value: 2147483648"},
+		{Int, 7.5, nil, "Int cannot represent non-integer value: 7.5"},
+		{Int, "7", nil, `Int cannot represent non-integer value: "7"`},
+		{Float, 7, 7.0, ""},
+		{Float, math.Inf(1), nil, "Float cannot represent non numeric value: +Inf"},
+		{Float, "7", nil, `Float cannot represent non numeric value: "7"`},
+		{String, "abc", "abc", ""},
+		{String, 1, nil, "String cannot represent a non string value: 1"},
+		{Boolean, true, true, ""},
+		{Boolean, 1, nil, "Boolean cannot represent a non boolean value: 1"},
+		{ID, "a1", "a1", ""},
+		{ID, 123, "123", ""},
+		{ID, float64(123), "123", ""},
+		{ID, true, nil, "ID cannot represent value: true"},
+		{&Scalar{name: "Any"}, []int{1}, []int{1}, ""},
+	} {
+		actual, err := test.scalar.ParseValue(test.value)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s %#v: expected error %q but got %v", test.scalar, test.value, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %#v: unexpected error: %s", test.scalar, test.value, err)
+		} else if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s %#v: expected %# v but got %# v", test.scalar, test.value, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+func TestScalarSerialize(t *testing.T) {
+	for _, test := range []struct {
+		scalar   *Scalar
+		value    interface{}
+		expected interface{}
+		err      string
+	}{
+		{Int, 7, 7, ""},
+		{Int, uint8(7), 7, ""},
+		{Int, 1e3, 1000, ""},
+		{Int, "-1", -1, ""},
+		{Int, true, 1, ""},
+		{Int, 1 << 40, nil, "Int cannot represent non 32-bit signed integer value: 1099511627776"},
+		{Int, 0.1, nil, "Int cannot represent non-integer value: 0.1"},
+		{Int, "", nil, `Int cannot represent non-integer value: ""`},
+		{Float, 7, 7.0, ""},
+		{Float, "1.5", 1.5, ""},
+		{Float, false, 0.0, ""},
+		{Float, math.NaN(), nil, "Float cannot represent non numeric value: NaN"},
+		{String, "abc", "abc", ""},
+		{String, 1.5, "1.5", ""},
+		{String, -3, "-3", ""},
+		{String, true, "true", ""},
+		{String, stringer("s"), "s", ""},
+		{String, []string{}, nil, "String cannot represent value: []"},
+		{Boolean, true, true, ""},
+		{Boolean, 0, false, ""},
+		{Boolean, "true", nil, `Boolean cannot represent a non boolean value: "true"`},
+		{ID, "a1", "a1", ""},
+		{ID, 1, "1", ""},
+		{ID, stringer("s"), "s", ""},
+		{ID, 1.5, nil, "ID cannot represent value: 1.5"},
+	} {
+		actual, err := test.scalar.Serialize(test.value)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s %#v: expected error %q but got %v", test.scalar, test.value, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %#v: unexpected error: %s", test.scalar, test.value, err)
+		} else if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s %#v: expected %# v but got %# v", test.scalar, test.value, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+func TestCustomScalars(t *testing.T) {
+	date := time.Date(2016, 10, 1, 12, 30, 0, 0, time.UTC)
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, test := range []struct {
+		coercer Coercer
+		// One of literal or value is set.
+		literal    string
+		value      interface{}
+		parsed     interface{}
+		serialized interface{}
+	}{
+		{DateTimeCoercer, `"2016-10-01T12:30:00Z"`, nil, date, "2016-10-01T12:30:00Z"},
+		{DateTimeCoercer, "", "2016-10-01T12:30:00Z", date, "2016-10-01T12:30:00Z"},
+		{JSONCoercer, `{a: [1, "b"], c: D}`, nil, map[string]interface{}{"a": []interface{}{int64(1), "b"}, "c": "D"},
+			map[string]interface{}{"a": []interface{}{int64(1), "b"}, "c": "D"}},
+		{JSONCoercer, "", map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 1.0}},
+		{UUIDCoercer, `"A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11"`, nil,
+			"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+		{UUIDCoercer, "", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+			"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+		{BigIntCoercer, "123456789012345678901234567890", nil, huge, "123456789012345678901234567890"},
+		{BigIntCoercer, `"123456789012345678901234567890"`, nil, huge, "123456789012345678901234567890"},
+		{BigIntCoercer, "", float64(42), big.NewInt(42), "42"},
+	} {
+		var parsed interface{}
+		var err error
+		if test.literal != "" {
+			parsed, err = test.coercer.ParseLiteral(parseValueLiteral(t, test.literal))
+		} else {
+			parsed, err = test.coercer.ParseValue(test.value)
+		}
+		if err != nil {
+			t.Errorf("%T %s%v: unexpected error: %s", test.coercer, test.literal, test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, test.parsed) {
+			t.Errorf("%T %s%v: expected %# v but got %# v", test.coercer, test.literal, test.value, pretty.Formatter(test.parsed), pretty.Formatter(parsed))
+		}
+		serialized, err := test.coercer.Serialize(parsed)
+		if err != nil {
+			t.Errorf("%T %s%v: unexpected error: %s", test.coercer, test.literal, test.value, err)
+		} else if !reflect.DeepEqual(serialized, test.serialized) {
+			t.Errorf("%T %s%v: expected serialized %# v but got %# v", test.coercer, test.literal, test.value, pretty.Formatter(test.serialized), pretty.Formatter(serialized))
+		}
+	}
+}
+
+func TestCustomScalarErrors(t *testing.T) {
+	for _, test := range []struct {
+		coercer Coercer
+		value   interface{}
+		err     string
+	}{
+		{DateTimeCoercer, "2016-10-01", `DateTime cannot represent an invalid date-time string: "2016-10-01"`},
+		{DateTimeCoercer, 1, "DateTime cannot represent a non string value: 1"},
+		{UUIDCoercer, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a1", `UUID cannot represent an invalid UUID string: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a1"`},
+		{UUIDCoercer, "a0eebc99x9c0b-4ef8-bb6d-6bb9bd380a11", `UUID cannot represent an invalid UUID string: "a0eebc99x9c0b-4ef8-bb6d-6bb9bd380a11"`},
+		{BigIntCoercer, "1.5", `BigInt cannot represent an invalid integer string: "1.5"`},
+		{BigIntCoercer, 1.5, "BigInt cannot represent non-integer value: 1.5"},
+	} {
+		if _, err := test.coercer.ParseValue(test.value); err == nil || err.Error() != test.err {
+			t.Errorf("%T %#v: expected error %q but got %v", test.coercer, test.value, test.err, err)
+		}
+	}
+}
+
+type upperCoercer struct{}
+
+func (upperCoercer) ParseLiteral(v ast.Value) (interface{}, error) { return v.(*ast.String).Value, nil }
+func (upperCoercer) ParseValue(v interface{}) (interface{}, error) { return v, nil }
+func (upperCoercer) Serialize(v interface{}) (interface{}, error)  { return v.(string) + "!", nil }
+
+func TestRegisterScalar(t *testing.T) {
+	RegisterScalar("Shout", upperCoercer{})
+	defer func() {
+		scalarsMu.Lock()
+		delete(scalars, "Shout")
+		scalarsMu.Unlock()
+	}()
+
+	s := mustBuild(t, "type Query {a: Shout, b: DateTime, c: Other} scalar Shout scalar DateTime scalar Other")
+	for name, expected := range map[string]Coercer{"Shout": upperCoercer{}, "DateTime": DateTimeCoercer, "Other": nil} {
+		if actual := s.Type(name).(*Scalar).Coercer(); actual != expected {
+			t.Errorf("%s: expected coercer %#v but got %#v", name, expected, actual)
+		}
+	}
+	if v, err := s.Type("Shout").(*Scalar).Serialize("hi"); err != nil || v != "hi!" {
+		t.Errorf("expected %q but got %#v, %v", "hi!", v, err)
+	}
+
+	s, err := NewSchema().
+		Query(NewObject("Query").Field("a", Ref("Shout"), nil)).
+		Types(NewScalar("Shout").Coercer(JSONCoercer)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := s.Type("Shout").(*Scalar).Coercer(); actual != JSONCoercer {
+		t.Errorf("expected builder coercer but got %#v", actual)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic registering built-in scalar")
+			}
+		}()
+		RegisterScalar("Int", upperCoercer{})
+	}()
+}
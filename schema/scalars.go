@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// Coercers of common custom scalars, registered by default.
+var (
+	// DateTimeCoercer coerces time.Time values, serialized as RFC 3339 strings.
+	DateTimeCoercer Coercer = dateTimeCoercer{}
+	// JSONCoercer coerces arbitrary JSON values. Literals are converted by the LiteralValue function.
+	JSONCoercer Coercer = jsonCoercer{}
+	// UUIDCoercer coerces UUIDs, represented internally and serialized as canonical lower case strings.
+	UUIDCoercer Coercer = uuidCoercer{}
+	// BigIntCoercer coerces arbitrary precision integers as *big.Int values, serialized as decimal strings. Int and
+	// String literals are accepted.
+	BigIntCoercer Coercer = bigIntCoercer{}
+)
+
+type dateTimeCoercer struct{}
+
+func (c dateTimeCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	if l, ok := v.(*ast.String); ok {
+		return c.ParseValue(l.Value)
+	}
+	return nil, unexpectedLiteral("DateTime", v)
+}
+
+func (dateTimeCoercer) ParseValue(v interface{}) (interface{}, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return nil, fmt.Errorf("DateTime cannot represent a non string value: %s", inspect(v))
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, fmt.Errorf("DateTime cannot represent an invalid date-time string: %q", s)
+	}
+	return t, nil
+}
+
+func (c dateTimeCoercer) Serialize(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(time.RFC3339Nano), nil
+	case *time.Time:
+		if t != nil {
+			return t.Format(time.RFC3339Nano), nil
+		}
+	case string:
+		if _, err := c.ParseValue(t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("DateTime cannot represent value: %s", inspect(v))
+}
+
+type jsonCoercer struct{}
+
+func (jsonCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	return LiteralValue(v)
+}
+
+func (jsonCoercer) ParseValue(v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+func (jsonCoercer) Serialize(v interface{}) (interface{}, error) {
+	if m, ok := v.(json.Marshaler); ok {
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(b), nil
+	}
+	return v, nil
+}
+
+type uuidCoercer struct{}
+
+func (c uuidCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	if l, ok := v.(*ast.String); ok {
+		return c.ParseValue(l.Value)
+	}
+	return nil, unexpectedLiteral("UUID", v)
+}
+
+func (uuidCoercer) ParseValue(v interface{}) (interface{}, error) {
+	s, ok := stringOf(v)
+	if !ok {
+		return nil, fmt.Errorf("UUID cannot represent a non string value: %s", inspect(v))
+	}
+	if !isUUID(s) {
+		return nil, fmt.Errorf("UUID cannot represent an invalid UUID string: %q", s)
+	}
+	return strings.ToLower(s), nil
+}
+
+func (c uuidCoercer) Serialize(v interface{}) (interface{}, error) {
+	if b, ok := v.([16]byte); ok {
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return c.ParseValue(s.String())
+	}
+	return c.ParseValue(v)
+}
+
+// The isUUID function returns true if s is a UUID in the canonical 8-4-4-4-12 hexadecimal form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type bigIntCoercer struct{}
+
+func (c bigIntCoercer) ParseLiteral(v ast.Value) (interface{}, error) {
+	switch l := v.(type) {
+	case *ast.Int:
+		return c.ParseValue(l.Value)
+	case *ast.String:
+		return c.ParseValue(l.Value)
+	}
+	return nil, unexpectedLiteral("BigInt", v)
+}
+
+func (bigIntCoercer) ParseValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case json.Number:
+		if i, ok := new(big.Int).SetString(string(v), 10); ok {
+			return i, nil
+		}
+	case string:
+		if i, ok := new(big.Int).SetString(v, 10); ok {
+			return i, nil
+		}
+		return nil, fmt.Errorf("BigInt cannot represent an invalid integer string: %q", v)
+	}
+	if i, ok := intOf(v); ok {
+		return big.NewInt(i), nil
+	}
+	return nil, fmt.Errorf("BigInt cannot represent non-integer value: %s", inspect(v))
+}
+
+func (c bigIntCoercer) Serialize(v interface{}) (interface{}, error) {
+	if b, ok := v.(big.Int); ok {
+		v = &b
+	}
+	i, err := c.ParseValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return i.(*big.Int).String(), nil
+}
@@ -0,0 +1,183 @@
+// Package schema implements GraphQL type systems.
+//
+// A Schema is built from type system definitions with the Build function. Schemas and their types are immutable.
+package schema
+
+import (
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// A Schema is a complete GraphQL type system.
+type Schema struct {
+	types      map[string]NamedType
+	typesOrder []NamedType
+
+	query        *Object
+	mutation     *Object
+	subscription *Object
+
+	directives []*Directive
+}
+
+// The Type method returns the type named name, or nil if there is none.
+func (s *Schema) Type(name string) NamedType {
+	return s.types[name]
+}
+
+// The Types method returns every type in s: the built-in scalars and introspection types, followed by the remaining
+// types in definition order.
+func (s *Schema) Types() []NamedType {
+	return s.typesOrder
+}
+
+// The QueryType method returns the query root type.
+func (s *Schema) QueryType() *Object {
+	return s.query
+}
+
+// The MutationType method returns the mutation root type, or nil if mutations are not supported.
+func (s *Schema) MutationType() *Object {
+	return s.mutation
+}
+
+// The SubscriptionType method returns the subscription root type, or nil if subscriptions are not supported.
+func (s *Schema) SubscriptionType() *Object {
+	return s.subscription
+}
+
+// The Directives method returns the directives supported by s.
+func (s *Schema) Directives() []*Directive {
+	return s.directives
+}
+
+// The Directive method returns the directive named name, or nil if there is none.
+func (s *Schema) Directive(name string) *Directive {
+	for _, d := range s.directives {
+		if d.name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// The TypeFromAST method returns the type referenced by rt, or nil if it references an unknown type.
+func (s *Schema) TypeFromAST(rt ast.RefType) Type {
+	switch t := rt.(type) {
+	case *ast.NamedType:
+		if nt := s.Type(t.Value); nt != nil {
+			return nt
+		}
+	case *ast.ListType:
+		if of := s.TypeFromAST(t.RefType); of != nil {
+			return List(of)
+		}
+	case *ast.NonNullType:
+		if of := s.TypeFromAST(t.RefType); of != nil {
+			return NonNull(of)
+		}
+	}
+	return nil
+}
+
+// The Field method returns the field named name of the composite type t, or nil if there is none. Unlike the Field
+// methods of Object and Interface, the introspection meta-fields are included: __typename on every composite type,
+// and __schema and __type on the query root type.
+func (s *Schema) Field(t NamedType, name string) *Field {
+	switch name {
+	case SchemaMetaField.name, TypeMetaField.name:
+		if t == s.query && s.query != nil {
+			if name == SchemaMetaField.name {
+				return SchemaMetaField
+			}
+			return TypeMetaField
+		}
+	case TypeNameMetaField.name:
+		if IsCompositeType(t) {
+			return TypeNameMetaField
+		}
+	}
+	switch t := t.(type) {
+	case *Object:
+		return t.Field(name)
+	case *Interface:
+		return t.Field(name)
+	}
+	return nil
+}
+
+// The PossibleTypes method returns the Objects which may be the runtime type of a value of type t.
+func (s *Schema) PossibleTypes(t NamedType) []*Object {
+	switch a := t.(type) {
+	case *Object:
+		return []*Object{a}
+	case *Interface:
+		return a.possibleTypes
+	case *Union:
+		return a.types
+	}
+	return nil
+}
+
+// The IsPossibleType method returns true if o may be the runtime type of a value of type t.
+func (s *Schema) IsPossibleType(t NamedType, o *Object) bool {
+	for _, p := range s.PossibleTypes(t) {
+		if p == o {
+			return true
+		}
+	}
+	return false
+}
+
+// The IsSubType method returns true if a value of type sub is always a valid value of type super. For example, a
+// "User!" is a "Node" if User implements Node.
+func (s *Schema) IsSubType(sub, super Type) bool {
+	if IsEqualType(sub, super) {
+		return true
+	}
+	switch sup := super.(type) {
+	case *NonNullType:
+		if sn, ok := sub.(*NonNullType); ok {
+			return s.IsSubType(sn.ofType, sup.ofType)
+		}
+		return false
+	case *ListType:
+		switch st := sub.(type) {
+		case *NonNullType:
+			return s.IsSubType(st.ofType, sup)
+		case *ListType:
+			return s.IsSubType(st.ofType, sup.ofType)
+		}
+		return false
+	}
+	switch st := sub.(type) {
+	case *NonNullType:
+		return s.IsSubType(st.ofType, super)
+	case *Object:
+		if abstract, ok := super.(NamedType); ok && IsAbstractType(abstract) {
+			return s.IsPossibleType(abstract, st)
+		}
+	}
+	return false
+}
+
+// The add method adds t to s, returning false if a type with the same name already exists.
+func (s *Schema) add(t NamedType) bool {
+	if _, ok := s.types[t.Name()]; ok {
+		return false
+	}
+	s.types[t.Name()] = t
+	s.typesOrder = append(s.typesOrder, t)
+	return true
+}
+
+// The newSchema function returns a Schema containing only the built-in scalars and introspection types.
+func newSchema() *Schema {
+	s := &Schema{types: make(map[string]NamedType), directives: append([]*Directive(nil), builtInDirectives...)}
+	for _, t := range builtInScalars {
+		s.add(t)
+	}
+	for _, t := range introspectionTypes {
+		s.add(t)
+	}
+	return s
+}
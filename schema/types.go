@@ -0,0 +1,406 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The Kind of a Type.
+type Kind int
+
+const (
+	ScalarKind Kind = iota
+	ObjectKind
+	InterfaceKind
+	UnionKind
+	EnumKind
+	InputObjectKind
+	ListKind
+	NonNullKind
+)
+
+var kindStrings = map[Kind]string{
+	ScalarKind:      "SCALAR",
+	ObjectKind:      "OBJECT",
+	InterfaceKind:   "INTERFACE",
+	UnionKind:       "UNION",
+	EnumKind:        "ENUM",
+	InputObjectKind: "INPUT_OBJECT",
+	ListKind:        "LIST",
+	NonNullKind:     "NON_NULL",
+}
+
+// The String method returns the introspection name of k, e.g. "INPUT_OBJECT".
+func (k Kind) String() string {
+	if s, ok := kindStrings[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// A Type is a NamedType, a *ListType, or a *NonNullType.
+type Type interface {
+	Kind() Kind
+	// The String method returns the type reference in SDL form, e.g. "[String!]".
+	String() string
+}
+
+// A NamedType is a *Scalar, *Object, *Interface, *Union, *Enum, or *InputObject.
+type NamedType interface {
+	Type
+	Name() string
+	Description() string
+	// The Loc method returns the location of the type's definition, or the zero Loc if it was not defined in a
+	// Document.
+	Loc() ast.Loc
+}
+
+// A Scalar is a leaf type. Values are coerced by the Scalar's Coercer. See the Coercer interface for details.
+type Scalar struct {
+	name        string
+	description string
+	loc         ast.Loc
+	coercer     Coercer
+	specifiedBy string
+}
+
+func (*Scalar) Kind() Kind            { return ScalarKind }
+func (s *Scalar) String() string      { return s.name }
+func (s *Scalar) Name() string        { return s.name }
+func (s *Scalar) Description() string { return s.description }
+func (s *Scalar) Loc() ast.Loc        { return s.loc }
+
+// The SpecifiedByURL method returns the URL of the specification of s, as declared by the @specifiedBy directive, or
+// "" if there is none.
+func (s *Scalar) SpecifiedByURL() string { return s.specifiedBy }
+
+// An Object is a composite output type with a set of fields.
+type Object struct {
+	name         string
+	description  string
+	loc          ast.Loc
+	fields       []*Field
+	fieldsByName map[string]*Field
+	interfaces   []*Interface
+}
+
+func (*Object) Kind() Kind            { return ObjectKind }
+func (o *Object) String() string      { return o.name }
+func (o *Object) Name() string        { return o.name }
+func (o *Object) Description() string { return o.description }
+func (o *Object) Loc() ast.Loc        { return o.loc }
+
+// The Fields method returns the fields of o, in definition order.
+func (o *Object) Fields() []*Field { return o.fields }
+
+// The Field method returns the field of o named name, or nil if there is none.
+func (o *Object) Field(name string) *Field { return o.fieldsByName[name] }
+
+// The Interfaces method returns the interfaces implemented by o, in definition order.
+func (o *Object) Interfaces() []*Interface { return o.interfaces }
+
+// An Interface is an abstract composite output type, implemented by Objects.
+type Interface struct {
+	name          string
+	description   string
+	loc           ast.Loc
+	fields        []*Field
+	fieldsByName  map[string]*Field
+	possibleTypes []*Object
+	resolveType   ResolveTypeFunc
+}
+
+func (*Interface) Kind() Kind            { return InterfaceKind }
+func (i *Interface) String() string      { return i.name }
+func (i *Interface) Name() string        { return i.name }
+func (i *Interface) Description() string { return i.description }
+func (i *Interface) Loc() ast.Loc        { return i.loc }
+
+// The Fields method returns the fields of i, in definition order.
+func (i *Interface) Fields() []*Field { return i.fields }
+
+// The Field method returns the field of i named name, or nil if there is none.
+func (i *Interface) Field(name string) *Field { return i.fieldsByName[name] }
+
+// The PossibleTypes method returns the Objects implementing i, in definition order.
+func (i *Interface) PossibleTypes() []*Object { return i.possibleTypes }
+
+// The ResolveType method returns the function resolving the runtime types of values of i, or nil if there is none.
+func (i *Interface) ResolveType() ResolveTypeFunc { return i.resolveType }
+
+// A Union is an abstract composite output type, whose members are Objects.
+type Union struct {
+	name        string
+	description string
+	loc         ast.Loc
+	types       []*Object
+	resolveType ResolveTypeFunc
+}
+
+func (*Union) Kind() Kind            { return UnionKind }
+func (u *Union) String() string      { return u.name }
+func (u *Union) Name() string        { return u.name }
+func (u *Union) Description() string { return u.description }
+func (u *Union) Loc() ast.Loc        { return u.loc }
+
+// The Types method returns the members of u, in definition order.
+func (u *Union) Types() []*Object { return u.types }
+
+// The ResolveType method returns the function resolving the runtime types of values of u, or nil if there is none.
+func (u *Union) ResolveType() ResolveTypeFunc { return u.resolveType }
+
+// An Enum is a leaf type with a finite set of values.
+type Enum struct {
+	name         string
+	description  string
+	loc          ast.Loc
+	values       []*EnumValue
+	valuesByName map[string]*EnumValue
+}
+
+func (*Enum) Kind() Kind            { return EnumKind }
+func (e *Enum) String() string      { return e.name }
+func (e *Enum) Name() string        { return e.name }
+func (e *Enum) Description() string { return e.description }
+func (e *Enum) Loc() ast.Loc        { return e.loc }
+
+// The Values method returns the values of e, in definition order.
+func (e *Enum) Values() []*EnumValue { return e.values }
+
+// The Value method returns the value of e named name, or nil if there is none.
+func (e *Enum) Value(name string) *EnumValue { return e.valuesByName[name] }
+
+// An EnumValue is a value of an Enum.
+type EnumValue struct {
+	name              string
+	description       string
+	loc               ast.Loc
+	deprecationReason *string
+	value             interface{}
+}
+
+func (v *EnumValue) Name() string        { return v.name }
+func (v *EnumValue) Description() string { return v.description }
+func (v *EnumValue) Loc() ast.Loc        { return v.loc }
+
+// The Value method returns the internal value represented by v. Unless otherwise specified, it is the name of v.
+func (v *EnumValue) Value() interface{} { return v.value }
+
+// The IsDeprecated method returns true if v is deprecated.
+func (v *EnumValue) IsDeprecated() bool { return v.deprecationReason != nil }
+
+// The DeprecationReason method returns the reason v is deprecated, if any.
+func (v *EnumValue) DeprecationReason() string {
+	if v.deprecationReason == nil {
+		return ""
+	}
+	return *v.deprecationReason
+}
+
+// An InputObject is a composite input type with a set of fields.
+type InputObject struct {
+	name         string
+	description  string
+	loc          ast.Loc
+	fields       []*InputValue
+	fieldsByName map[string]*InputValue
+}
+
+func (*InputObject) Kind() Kind            { return InputObjectKind }
+func (o *InputObject) String() string      { return o.name }
+func (o *InputObject) Name() string        { return o.name }
+func (o *InputObject) Description() string { return o.description }
+func (o *InputObject) Loc() ast.Loc        { return o.loc }
+
+// The Fields method returns the fields of o, in definition order.
+func (o *InputObject) Fields() []*InputValue { return o.fields }
+
+// The Field method returns the field of o named name, or nil if there is none.
+func (o *InputObject) Field(name string) *InputValue { return o.fieldsByName[name] }
+
+// A Field is a field of an Object or Interface.
+type Field struct {
+	name              string
+	description       string
+	loc               ast.Loc
+	args              []*InputValue
+	typ               Type
+	deprecationReason *string
+	directives        []ast.Directive
+	resolve           ResolveFunc
+}
+
+func (f *Field) Name() string        { return f.name }
+func (f *Field) Description() string { return f.description }
+func (f *Field) Loc() ast.Loc        { return f.loc }
+
+// The Type method returns the output type of f.
+func (f *Field) Type() Type { return f.typ }
+
+// The Resolve method returns the function resolving values of f, or nil if there is none.
+func (f *Field) Resolve() ResolveFunc { return f.resolve }
+
+// The Args method returns the arguments of f, in definition order.
+func (f *Field) Args() []*InputValue { return f.args }
+
+// The Arg method returns the argument of f named name, or nil if there is none.
+func (f *Field) Arg(name string) *InputValue {
+	for _, a := range f.args {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// The Directives method returns the directives applied to the definition of f, including any which are not known to
+// the Schema, such as a @cost directive used for complexity analysis.
+func (f *Field) Directives() []ast.Directive { return f.directives }
+
+// The IsDeprecated method returns true if f is deprecated.
+func (f *Field) IsDeprecated() bool { return f.deprecationReason != nil }
+
+// The DeprecationReason method returns the reason f is deprecated, if any.
+func (f *Field) DeprecationReason() string {
+	if f.deprecationReason == nil {
+		return ""
+	}
+	return *f.deprecationReason
+}
+
+// An InputValue is an argument of a Field, or a field of an InputObject.
+type InputValue struct {
+	name         string
+	description  string
+	loc          ast.Loc
+	typ          Type
+	defaultValue ast.Value
+}
+
+func (v *InputValue) Name() string        { return v.name }
+func (v *InputValue) Description() string { return v.description }
+func (v *InputValue) Loc() ast.Loc        { return v.loc }
+
+// The Type method returns the input type of v.
+func (v *InputValue) Type() Type { return v.typ }
+
+// The DefaultValue method returns the literal default value of v, or nil if there is none.
+func (v *InputValue) DefaultValue() ast.Value { return v.defaultValue }
+
+// A ListType is a list of values of another type.
+type ListType struct {
+	ofType Type
+}
+
+// The List function returns a ListType of t.
+func List(t Type) *ListType {
+	return &ListType{t}
+}
+
+func (*ListType) Kind() Kind       { return ListKind }
+func (l *ListType) String() string { return "[" + l.ofType.String() + "]" }
+
+// The OfType method returns the type of the elements of l.
+func (l *ListType) OfType() Type { return l.ofType }
+
+// A NonNullType is a type which excludes null.
+type NonNullType struct {
+	ofType Type
+}
+
+// The NonNull function returns a NonNullType of t. t must not be a NonNullType.
+func NonNull(t Type) *NonNullType {
+	return &NonNullType{t}
+}
+
+func (*NonNullType) Kind() Kind       { return NonNullKind }
+func (n *NonNullType) String() string { return n.ofType.String() + "!" }
+
+// The OfType method returns the nullable type wrapped by n.
+func (n *NonNullType) OfType() Type { return n.ofType }
+
+// The NamedTypeOf function returns the NamedType wrapped by t, removing any ListType and NonNullType wrappers.
+func NamedTypeOf(t Type) NamedType {
+	for {
+		switch w := t.(type) {
+		case *ListType:
+			t = w.ofType
+		case *NonNullType:
+			t = w.ofType
+		case NamedType:
+			return w
+		default:
+			return nil
+		}
+	}
+}
+
+// The IsInputType function returns true if t may be used as the type of an argument, variable, or input field.
+func IsInputType(t Type) bool {
+	switch NamedTypeOf(t).(type) {
+	case *Scalar, *Enum, *InputObject:
+		return true
+	}
+	return false
+}
+
+// The IsOutputType function returns true if t may be used as the type of a field.
+func IsOutputType(t Type) bool {
+	switch NamedTypeOf(t).(type) {
+	case *Scalar, *Object, *Interface, *Union, *Enum:
+		return true
+	}
+	return false
+}
+
+// The IsLeafType function returns true if t is a Scalar or Enum.
+func IsLeafType(t Type) bool {
+	switch t.(type) {
+	case *Scalar, *Enum:
+		return true
+	}
+	return false
+}
+
+// The IsCompositeType function returns true if t is an Object, Interface, or Union.
+func IsCompositeType(t Type) bool {
+	switch t.(type) {
+	case *Object, *Interface, *Union:
+		return true
+	}
+	return false
+}
+
+// The IsAbstractType function returns true if t is an Interface or Union.
+func IsAbstractType(t Type) bool {
+	switch t.(type) {
+	case *Interface, *Union:
+		return true
+	}
+	return false
+}
+
+// Built-in scalars, included in every Schema.
+var (
+	Int     = &Scalar{name: "Int", coercer: intCoercer{}}
+	Float   = &Scalar{name: "Float", coercer: floatCoercer{}}
+	String  = &Scalar{name: "String", coercer: stringCoercer{}}
+	Boolean = &Scalar{name: "Boolean", coercer: booleanCoercer{}}
+	ID      = &Scalar{name: "ID", coercer: idCoercer{}}
+)
+
+var builtInScalars = []*Scalar{Int, Float, String, Boolean, ID}
+
+// The IsEqualType function returns true if a and b are the same type.
+func IsEqualType(a, b Type) bool {
+	switch at := a.(type) {
+	case *ListType:
+		bt, ok := b.(*ListType)
+		return ok && IsEqualType(at.ofType, bt.ofType)
+	case *NonNullType:
+		bt, ok := b.(*NonNullType)
+		return ok && IsEqualType(at.ofType, bt.ofType)
+	}
+	return a == b
+}
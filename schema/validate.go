@@ -0,0 +1,232 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// The validate function checks that s satisfies the type system rules of the spec, returning every problem found.
+// Errors are associated with the locations of the offending definitions.
+func validate(s *Schema) errors.List {
+	v := validator{
+		schema:         s,
+		cycleVisited:   make(map[*InputObject]bool),
+		cyclePathIndex: make(map[*InputObject]int),
+	}
+	v.roots()
+	for _, t := range s.typesOrder {
+		if IsIntrospectionType(t) {
+			continue
+		}
+		v.name(t.Name(), t.Loc())
+		switch t := t.(type) {
+		case *Object:
+			v.object(t)
+		case *Interface:
+			v.iface(t)
+		case *Union:
+			v.union(t)
+		case *Enum:
+			v.enum(t)
+		case *InputObject:
+			v.inputObject(t)
+			v.inputObjectCycles(t)
+		}
+	}
+	for _, d := range s.directives {
+		if !IsBuiltInDirective(d.name) {
+			v.directive(d)
+		}
+	}
+	return v.errs
+}
+
+// A validator holds the state for validating a single Schema.
+type validator struct {
+	schema *Schema
+	errs   errors.List
+
+	// Input objects which have been checked for non-null cycles, the non-null fields currently being followed, and
+	// the index in cyclePath at which each input object along it was entered.
+	cycleVisited   map[*InputObject]bool
+	cyclePath      []*InputValue
+	cyclePathIndex map[*InputObject]int
+}
+
+func (v *validator) errorf(locs []ast.Loc, format string, args ...interface{}) {
+	v.errs = append(v.errs, errors.New(fmt.Sprintf(format, args...), locs...))
+}
+
+// The roots method checks the root operation types.
+func (v *validator) roots() {
+	if v.schema.query == nil {
+		v.errorf(nil, "query root type must be provided")
+	}
+}
+
+// The name method checks that name is not reserved for introspection.
+func (v *validator) name(name string, loc ast.Loc) {
+	if strings.HasPrefix(name, "__") {
+		v.errorf([]ast.Loc{loc}, "name %q must not begin with \"__\", which is reserved by GraphQL introspection", name)
+	}
+}
+
+func (v *validator) object(o *Object) {
+	v.fields(o.name, o.loc, o.fields)
+
+	implemented := make(map[*Interface]bool)
+	for _, i := range o.interfaces {
+		if implemented[i] {
+			v.errorf([]ast.Loc{o.loc}, "type %q can only implement %q once", o.name, i.name)
+			continue
+		}
+		implemented[i] = true
+		v.implements(o, i)
+	}
+}
+
+func (v *validator) iface(i *Interface) {
+	v.fields(i.name, i.loc, i.fields)
+}
+
+// The fields method checks the output fields of the type named typeName.
+func (v *validator) fields(typeName string, loc ast.Loc, fields []*Field) {
+	if len(fields) == 0 {
+		v.errorf([]ast.Loc{loc}, "type %q must define one or more fields", typeName)
+	}
+	for _, f := range fields {
+		v.name(f.name, f.loc)
+		if !IsOutputType(f.typ) {
+			v.errorf([]ast.Loc{f.loc}, "the type of \"%s.%s\" must be an output type but got %q", typeName, f.name, f.typ)
+		}
+		for _, a := range f.args {
+			v.name(a.name, a.loc)
+			if !IsInputType(a.typ) {
+				v.errorf([]ast.Loc{a.loc}, "the type of \"%s.%s(%s:)\" must be an input type but got %q",
+					typeName, f.name, a.name, a.typ)
+			}
+		}
+	}
+}
+
+// The directive method checks the name and arguments of a custom directive.
+func (v *validator) directive(d *Directive) {
+	v.name(d.name, d.loc)
+	for _, a := range d.args {
+		v.name(a.name, a.loc)
+		if !IsInputType(a.typ) {
+			v.errorf([]ast.Loc{a.loc}, "the type of \"@%s(%s:)\" must be an input type but got %q", d.name, a.name, a.typ)
+		}
+	}
+}
+
+// The implements method checks that o correctly implements i.
+func (v *validator) implements(o *Object, i *Interface) {
+	for _, iField := range i.fields {
+		oField := o.Field(iField.name)
+		if oField == nil {
+			v.errorf([]ast.Loc{o.loc, iField.loc}, "interface field \"%s.%s\" expected but %q does not provide it",
+				i.name, iField.name, o.name)
+			continue
+		}
+		if !v.schema.IsSubType(oField.typ, iField.typ) {
+			v.errorf([]ast.Loc{oField.loc, iField.loc}, "interface field \"%s.%s\" expects type %q but \"%s.%s\" is type %q",
+				i.name, iField.name, iField.typ, o.name, oField.name, oField.typ)
+		}
+
+		for _, iArg := range iField.args {
+			oArg := oField.Arg(iArg.name)
+			if oArg == nil {
+				v.errorf([]ast.Loc{oField.loc, iArg.loc}, "interface field argument \"%s.%s(%s:)\" expected but \"%s.%s\" does not provide it",
+					i.name, iField.name, iArg.name, o.name, oField.name)
+				continue
+			}
+			if !IsEqualType(oArg.typ, iArg.typ) {
+				v.errorf([]ast.Loc{oArg.loc, iArg.loc}, "interface field argument \"%s.%s(%s:)\" expects type %q but \"%s.%s(%s:)\" is type %q",
+					i.name, iField.name, iArg.name, iArg.typ, o.name, oField.name, oArg.name, oArg.typ)
+			}
+		}
+		for _, oArg := range oField.args {
+			if iField.Arg(oArg.name) == nil && oArg.typ.Kind() == NonNullKind {
+				v.errorf([]ast.Loc{oArg.loc}, "object field argument \"%s.%s(%s:)\" is of required type %q but is not also provided by the interface \"%s.%s\"",
+					o.name, oField.name, oArg.name, oArg.typ, i.name, iField.name)
+			}
+		}
+	}
+}
+
+func (v *validator) union(u *Union) {
+	if len(u.types) == 0 {
+		v.errorf([]ast.Loc{u.loc}, "union type %q must define one or more member types", u.name)
+	}
+	members := make(map[*Object]bool)
+	for _, o := range u.types {
+		if members[o] {
+			v.errorf([]ast.Loc{u.loc}, "union type %q can only include type %q once", u.name, o.name)
+		}
+		members[o] = true
+	}
+}
+
+func (v *validator) enum(e *Enum) {
+	if len(e.values) == 0 {
+		v.errorf([]ast.Loc{e.loc}, "enum type %q must define one or more values", e.name)
+	}
+	for _, ev := range e.values {
+		v.name(ev.name, ev.loc)
+		switch ev.name {
+		case "true", "false", "null":
+			v.errorf([]ast.Loc{ev.loc}, "enum type %q cannot include value %q", e.name, ev.name)
+		}
+	}
+}
+
+func (v *validator) inputObject(o *InputObject) {
+	if len(o.fields) == 0 {
+		v.errorf([]ast.Loc{o.loc}, "input object type %q must define one or more fields", o.name)
+	}
+	for _, f := range o.fields {
+		v.name(f.name, f.loc)
+		if !IsInputType(f.typ) {
+			v.errorf([]ast.Loc{f.loc}, "the type of \"%s.%s\" must be an input type but got %q", o.name, f.name, f.typ)
+		}
+	}
+}
+
+// The inputObjectCycles method checks that o can not reference itself through a chain of non-null fields, since
+// such a value could never be provided. Each cycle is reported once.
+func (v *validator) inputObjectCycles(o *InputObject) {
+	if v.cycleVisited[o] {
+		return
+	}
+	v.cycleVisited[o] = true
+	v.cyclePathIndex[o] = len(v.cyclePath)
+	for _, f := range o.fields {
+		nn, ok := f.typ.(*NonNullType)
+		if !ok {
+			continue
+		}
+		next, ok := nn.ofType.(*InputObject)
+		if !ok {
+			continue
+		}
+		v.cyclePath = append(v.cyclePath, f)
+		if i, ok := v.cyclePathIndex[next]; ok {
+			cycle := v.cyclePath[i:]
+			names := make([]string, len(cycle))
+			locs := make([]ast.Loc, len(cycle))
+			for j, f := range cycle {
+				names[j], locs[j] = f.name, f.loc
+			}
+			v.errorf(locs, "cannot reference input object %q within itself through a series of non-null fields: %q",
+				next.name, strings.Join(names, "."))
+		} else {
+			v.inputObjectCycles(next)
+		}
+		v.cyclePath = v.cyclePath[:len(v.cyclePath)-1]
+	}
+	delete(v.cyclePathIndex, o)
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+)
+
+func TestValidate(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected []string
+	}{
+		// Valid.
+		{`type Query {a: Int}`, nil},
+		{`type Query {n: Node} interface Node {id: ID, n(a: Int): Node}
+			type User implements Node {id: ID, n(a: Int, b: Int): User!}`, nil},
+		{`type Query {n: [Node]} interface Node {n: [Node]} union U = A type A implements Node {n: [A!]!}`, nil},
+		{`type Query {a(i: I): Int} input I {a: I, b: [I!]!}`, nil},
+
+		// Root types.
+		{`type Q {a: Int}`, []string{`query root type must be provided`}},
+
+		// Reserved names.
+		{`type Query {__a: Int}`, []string{`name "__a" must not begin with "__", which is reserved by GraphQL introspection`}},
+		{`type Query {a(__b: Int): Int}`, []string{`name "__b" must not begin with "__", which is reserved by GraphQL introspection`}},
+		{`type Query {a: __T} type __T {a: Int}`, []string{`name "__T" must not begin with "__", which is reserved by GraphQL introspection`}},
+		{`type Query {a: E} enum E {__A}`, []string{`name "__A" must not begin with "__", which is reserved by GraphQL introspection`}},
+		{`type Query {a(i: I): Int} input I {__a: Int}`, []string{`name "__a" must not begin with "__", which is reserved by GraphQL introspection`}},
+		{`type Query {a: Int} directive @__d(__a: Int) on FIELD`, []string{
+			`name "__d" must not begin with "__", which is reserved by GraphQL introspection`,
+			`name "__a" must not begin with "__", which is reserved by GraphQL introspection`,
+		}},
+
+		// Input and output types.
+		{`type Query {a: I} input I {a: Int}`, []string{`the type of "Query.a" must be an output type but got "I"`}},
+		{`type Query {a(q: [Query]): Int}`, []string{`the type of "Query.a(q:)" must be an input type but got "[Query]"`}},
+		{`type Query {a(i: I): Int} input I {q: Query!}`, []string{`the type of "I.q" must be an input type but got "Query!"`}},
+		{`type Query {a: Int} directive @d(q: Query) on FIELD`, []string{`the type of "@d(q:)" must be an input type but got "Query"`}},
+
+		// Enums.
+		{`type Query {a: E} enum E {A, null}`, []string{`enum type "E" cannot include value "null"`}},
+
+		// Unions.
+		{`type Query {u: U} union U = Query | Query`, []string{`union type "U" can only include type "Query" once`}},
+
+		// Interfaces.
+		{`type Query {a: Int} interface I {b: Int} type T implements I {c: Int}`,
+			[]string{`interface field "I.b" expected but "T" does not provide it`}},
+		{`type Query implements I, I {a: Int} interface I {a: Int}`,
+			[]string{`type "Query" can only implement "I" once`}},
+		{`type Query {a: Int} interface I {b: Int!} type T implements I {b: Int}`,
+			[]string{`interface field "I.b" expects type "Int!" but "T.b" is type "Int"`}},
+		{`type Query {a: Int} interface I {b: [I]} type T implements I {b: T}`,
+			[]string{`interface field "I.b" expects type "[I]" but "T.b" is type "T"`}},
+		{`type Query {a: Int} interface I {b: I} type T implements I {b: Query}`,
+			[]string{`interface field "I.b" expects type "I" but "T.b" is type "Query"`}},
+		{`type Query {a: Int} interface I {b(c: Int): Int} type T implements I {b: Int}`,
+			[]string{`interface field argument "I.b(c:)" expected but "T.b" does not provide it`}},
+		{`type Query {a: Int} interface I {b(c: Int): Int} type T implements I {b(c: Int!): Int}`,
+			[]string{`interface field argument "I.b(c:)" expects type "Int" but "T.b(c:)" is type "Int!"`}},
+		{`type Query {a: Int} interface I {b: Int} type T implements I {b(c: Int!): Int}`,
+			[]string{`object field argument "T.b(c:)" is of required type "Int!" but is not also provided by the interface "I.b"`}},
+
+		// Input object cycles.
+		{`type Query {a(i: I): Int} input I {a: I!}`,
+			[]string{`cannot reference input object "I" within itself through a series of non-null fields: "a"`}},
+		{`type Query {a(i: A): Int} input A {b: B!} input B {c: C!, a: Int} input C {a: A!}`,
+			[]string{`cannot reference input object "A" within itself through a series of non-null fields: "b.c.a"`}},
+
+		// Multiple errors.
+		{`type Query {__a: I} input I {a: Query}`, []string{
+			`name "__a" must not begin with "__", which is reserved by GraphQL introspection`,
+			`the type of "Query.__a" must be an output type but got "I"`,
+			`the type of "I.a" must be an input type but got "Query"`,
+		}},
+	} {
+		_, err := Build(mustParse(t, test.input))
+		var messages []string
+		if err != nil {
+			list, ok := err.(errors.List)
+			if !ok {
+				t.Errorf("input %q: expected errors.List but got %T", test.input, err)
+				continue
+			}
+			for _, e := range list {
+				messages = append(messages, e.Message)
+			}
+		}
+		if !reflect.DeepEqual(messages, test.expected) {
+			t.Errorf("input %q: expected errors %q but got %q", test.input, test.expected, messages)
+		}
+	}
+}
+
+func TestValidateLocations(t *testing.T) {
+	_, err := Build(mustParse(t, "type Query {a: Int} interface I {b: Int!} type T implements I {b: Int}"))
+	list, ok := err.(errors.List)
+	if !ok || len(list) != 1 {
+		t.Fatalf("expected a single error but got %v", err)
+	}
+	// The offending field, followed by the interface field.
+	expected := []ast.Loc{{Start: 63, End: 68}, {Start: 33, End: 40}}
+	if !reflect.DeepEqual(list[0].Locations, expected) {
+		t.Errorf("expected locations %v but got %v", expected, list[0].Locations)
+	}
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// KnownArgumentNames requires that the arguments of fields and directives are defined by them.
+var KnownArgumentNames Rule = RuleFunc(knownArgumentNames)
+
+func knownArgumentNames(c *Context) *Visitor {
+	inDirective := false
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.Directive:
+				inDirective = true
+			case *ast.Argument:
+				if c.Argument() != nil {
+					return
+				}
+				if inDirective {
+					// Unknown directives are reported by KnownDirectives.
+					if d := c.Directive(); d != nil {
+						c.Errorf(n.Name.Loc, "Unknown argument %q on directive \"@%s\".", n.Name.Value, d.Name())
+					}
+				} else if f, parent := c.FieldDef(), c.ParentType(); f != nil && parent != nil {
+					c.Errorf(n.Name.Loc, "Unknown argument %q on field \"%s.%s\".", n.Name.Value, parent.Name(), f.Name())
+				}
+			}
+		},
+		Leave: func(n ast.Node) {
+			if _, ok := n.(*ast.Directive); ok {
+				inDirective = false
+			}
+		},
+	}
+}
+
+// UniqueArgumentNames requires that each field and directive is passed each argument at most once.
+var UniqueArgumentNames Rule = RuleFunc(uniqueArgumentNames)
+
+func uniqueArgumentNames(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			var args []ast.Argument
+			switch n := n.(type) {
+			case *ast.Field:
+				args = n.Arguments
+			case *ast.Directive:
+				args = n.Arguments
+			default:
+				return
+			}
+			known := make(map[string]*ast.Argument, len(args))
+			for i := range args {
+				a := &args[i]
+				if first, ok := known[a.Name.Value]; ok {
+					c.Report(errors.New(fmt.Sprintf("There can be only one argument named %q.", a.Name.Value), first.Name.Loc, a.Name.Loc))
+					continue
+				}
+				known[a.Name.Value] = a
+			}
+		},
+	}
+}
+
+// ProvidedRequiredArguments requires that fields and directives are passed each of their required arguments: those
+// with non-null types and no default value.
+var ProvidedRequiredArguments Rule = RuleFunc(providedRequiredArguments)
+
+func providedRequiredArguments(c *Context) *Visitor {
+	return &Visitor{
+		Leave: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.Field:
+				f := c.FieldDef()
+				if f == nil {
+					return
+				}
+				for _, a := range missingArguments(f.Args(), n.Arguments) {
+					c.Errorf(n.Loc, "Field %q argument %q of type %q is required, but it was not provided.", f.Name(), a.Name(), a.Type().String())
+				}
+			case *ast.Directive:
+				d := c.Directive()
+				if d == nil {
+					return
+				}
+				for _, a := range missingArguments(d.Args(), n.Arguments) {
+					c.Errorf(n.Loc, "Directive \"@%s\" argument %q of type %q is required, but it was not provided.", d.Name(), a.Name(), a.Type().String())
+				}
+			}
+		},
+	}
+}
+
+// The missingArguments function returns the required arguments of defs which are not provided by args.
+func missingArguments(defs []*schema.InputValue, args []ast.Argument) []*schema.InputValue {
+	var missing []*schema.InputValue
+	for _, def := range defs {
+		if _, ok := def.Type().(*schema.NonNullType); !ok || def.DefaultValue() != nil {
+			continue
+		}
+		provided := false
+		for _, a := range args {
+			if a.Name.Value == def.Name() {
+				provided = true
+				break
+			}
+		}
+		if !provided {
+			missing = append(missing, def)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestKnownArgumentNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), KnownArgumentNames, []ruleTest{
+		{"{dog(name: \"a\") {doesKnowCommand(command: SIT) @skip(if: true), owner {name(surname: true)}}}", nil},
+		// Unknown directives and fields are reported by other rules.
+		{"{dog @unknown(a: 1) {unknown(b: 2)}}", nil},
+		{"{dog(nam: \"a\") {doesKnowCommand(cmd: SIT), name @include(iff: true)}}", []string{
+			`Unknown argument "nam" on field "QueryRoot.dog". (at position 5)`,
+			`Unknown argument "cmd" on field "Dog.doesKnowCommand". (at position 32)`,
+			`Unknown argument "iff" on directive "@include". (at position 57)`,
+		}},
+		{"{dog {name @skip(name: true)}}", []string{`Unknown argument "name" on directive "@skip". (at position 17)`}},
+	})
+}
+
+func TestUniqueArgumentNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), UniqueArgumentNames, []ruleTest{
+		{"{dog(name: \"a\") {name @skip(if: true) @include(if: true)} d: dog(name: \"b\") {name}}", nil},
+		{"{dog(name: \"a\", name: \"b\", name: \"c\") {name @skip(if: true, if: false)}}", []string{
+			`There can be only one argument named "name". (at positions 5, 16)`,
+			`There can be only one argument named "name". (at positions 5, 27)`,
+			`There can be only one argument named "if". (at positions 50, 60)`,
+		}},
+	})
+}
+
+func TestProvidedRequiredArguments(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), ProvidedRequiredArguments, []ruleTest{
+		{"{dog {doesKnowCommand(command: SIT), name @skip(if: true)} human(id: 1) {name} unknown @unknown}", nil},
+		{"{dog {doesKnowCommand, name @include} human {name}}", []string{
+			`Field "doesKnowCommand" argument "command" of type "DogCommand!" is required, but it was not provided. (at position 6)`,
+			`Directive "@include" argument "if" of type "Boolean!" is required, but it was not provided. (at position 28)`,
+			`Field "human" argument "id" of type "ID!" is required, but it was not provided. (at position 38)`,
+		}},
+	})
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+)
+
+// A Cache is a concurrency-safe, least recently used cache of parsed and validated documents, so that frequently
+// repeated queries are parsed and validated only once per schema version.
+//
+// The zero value is an empty Cache holding at most defaultCacheSize results. The exported fields must not be modified
+// once the Cache is in use.
+type Cache struct {
+	// The rules used to validate documents. If empty, SpecifiedRules are used.
+	Rules []Rule
+	// If non-nil, Hit and Miss are called upon each cache hit and miss, e.g. to count them.
+	Hit, Miss func()
+
+	mu      sync.Mutex
+	size    int
+	entries *list.List
+	byKey   map[cacheKey]*list.Element
+}
+
+// The defaultCacheSize is the number of results held by a zero value Cache.
+const defaultCacheSize = 1000
+
+// A cacheKey identifies a document by either its source or its persisted query id, and the version of the schema it
+// was validated against.
+type cacheKey struct {
+	schemaVersion string
+	persisted     bool
+	query         string
+}
+
+// A CacheResult is the result of parsing and validating a document.
+type CacheResult struct {
+	// The parsed document, or nil if it could not be parsed.
+	Document *ast.Document
+	// The syntax error, if the document could not be parsed.
+	ParseErr error
+	// The validation errors.
+	Errors []*errors.GraphQLError
+}
+
+type cacheEntry struct {
+	key    cacheKey
+	result *CacheResult
+}
+
+// The NewCache function returns a Cache holding at most size results.
+func NewCache(size int) *Cache {
+	if size < 1 {
+		size = 1
+	}
+	return &Cache{size: size, entries: list.New(), byKey: make(map[cacheKey]*list.Element)}
+}
+
+// The Validate method returns the result of parsing source and validating it against s, whose version is
+// schemaVersion. The result is cached, and must not be modified.
+func (c *Cache) Validate(s *schema.Schema, schemaVersion, source string) *CacheResult {
+	key := cacheKey{schemaVersion: schemaVersion, query: source}
+	if r := c.get(key); r != nil {
+		return r
+	}
+	return c.add(key, c.validate(s, source))
+}
+
+// The ValidatePersisted method is like Validate, but identifies the document by a persisted query id. The source is
+// loaded by load only upon a miss. Errors from load are returned, and not cached.
+func (c *Cache) ValidatePersisted(s *schema.Schema, schemaVersion, id string, load func(id string) (string, error)) (*CacheResult, error) {
+	key := cacheKey{schemaVersion: schemaVersion, persisted: true, query: id}
+	if r := c.get(key); r != nil {
+		return r, nil
+	}
+	source, err := load(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.add(key, c.validate(s, source)), nil
+}
+
+// The Len method returns the number of cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	return c.entries.Len()
+}
+
+// The init method initializes a zero value Cache. It must be called with mu held.
+func (c *Cache) init() {
+	if c.entries != nil {
+		return
+	}
+	if c.size < 1 {
+		c.size = defaultCacheSize
+	}
+	c.entries = list.New()
+	c.byKey = make(map[cacheKey]*list.Element)
+}
+
+// The get method returns the cached result for key, or nil if there is none, and notifies the hooks.
+func (c *Cache) get(key cacheKey) *CacheResult {
+	c.mu.Lock()
+	c.init()
+	e, ok := c.byKey[key]
+	if ok {
+		c.entries.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if !ok {
+		if c.Miss != nil {
+			c.Miss()
+		}
+		return nil
+	}
+	if c.Hit != nil {
+		c.Hit()
+	}
+	return e.Value.(*cacheEntry).result
+}
+
+// The add method caches r for key, evicting the least recently used result if the Cache is full, and returns r. If
+// a result for key was added concurrently, that result is kept and returned instead.
+func (c *Cache) add(key cacheKey, r *CacheResult) *CacheResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	if e, ok := c.byKey[key]; ok {
+		c.entries.MoveToFront(e)
+		return e.Value.(*cacheEntry).result
+	}
+	c.byKey[key] = c.entries.PushFront(&cacheEntry{key: key, result: r})
+	if c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.byKey, oldest.Value.(*cacheEntry).key)
+	}
+	return r
+}
+
+// The validate method parses source and validates it against s.
+func (c *Cache) validate(s *schema.Schema, source string) *CacheResult {
+	d, err := parser.ParseString(source)
+	if err != nil {
+		return &CacheResult{ParseErr: err}
+	}
+	return &CacheResult{Document: d, Errors: Validate(s, d, c.Rules...)}
+}
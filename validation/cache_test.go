@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	var hits, misses int
+	c := NewCache(2)
+	c.Hit = func() { hits++ }
+	c.Miss = func() { misses++ }
+	check := func(expectedHits, expectedMisses, expectedLen int) {
+		t.Helper()
+		if hits != expectedHits || misses != expectedMisses || c.Len() != expectedLen {
+			t.Errorf("expected %d hits, %d misses and %d entries but got %d, %d and %d",
+				expectedHits, expectedMisses, expectedLen, hits, misses, c.Len())
+		}
+	}
+
+	r := c.Validate(s, "v1", "{dog {name}}")
+	if r.Document == nil || r.ParseErr != nil || len(r.Errors) > 0 {
+		t.Fatalf("unexpected result: %#v", r)
+	}
+	check(0, 1, 1)
+	if c.Validate(s, "v1", "{dog {name}}") != r {
+		t.Error("expected cached result")
+	}
+	check(1, 1, 1)
+
+	// Another schema version is cached separately.
+	if c.Validate(s, "v2", "{dog {name}}") == r {
+		t.Error("expected new result for v2")
+	}
+	check(1, 2, 2)
+
+	// The least recently used result, for v2, is evicted.
+	c.Validate(s, "v1", "{dog {name}}")
+	invalid := c.Validate(s, "v1", "{dog {unknown}}")
+	if len(invalid.Errors) != 1 {
+		t.Errorf("expected one validation error but got %v", invalid.Errors)
+	}
+	check(2, 3, 2)
+	c.Validate(s, "v2", "{dog {name}}")
+	check(2, 4, 2)
+	c.Validate(s, "v1", "{dog {unknown}}")
+	check(3, 4, 2)
+
+	// Syntax errors are cached too.
+	if r := c.Validate(s, "v1", "{dog {"); r.ParseErr == nil || r.Document != nil {
+		t.Errorf("expected parse error but got %#v", r)
+	}
+	c.Validate(s, "v1", "{dog {")
+	check(4, 5, 2)
+}
+
+func TestCacheZero(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	var c Cache
+	if l := c.Len(); l != 0 {
+		t.Errorf("expected 0 entries but got %d", l)
+	}
+	r := c.Validate(s, "v1", "{dog {name}}")
+	if r.Document == nil || r.ParseErr != nil || len(r.Errors) > 0 {
+		t.Fatalf("unexpected result: %#v", r)
+	}
+	if c.Validate(s, "v1", "{dog {name}}") != r {
+		t.Error("expected cached result")
+	}
+	for i := 0; i < defaultCacheSize; i++ {
+		c.Validate(s, "v1", fmt.Sprintf("{dog {name%d: name}}", i))
+	}
+	if l := c.Len(); l != defaultCacheSize {
+		t.Errorf("expected %d entries but got %d", defaultCacheSize, l)
+	}
+}
+
+func TestCacheValidatePersisted(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	c := NewCache(10)
+	loads := 0
+	load := func(id string) (string, error) {
+		loads++
+		switch id {
+		case "dog":
+			return "{dog {name}}", nil
+		case "bad":
+			return "{dog {barks {name}}}", nil
+		}
+		return "", fmt.Errorf("unknown query %q", id)
+	}
+	for i := 0; i < 3; i++ {
+		r, err := c.ValidatePersisted(s, "v1", "dog", load)
+		if err != nil || r.Document == nil || len(r.Errors) > 0 {
+			t.Fatalf("unexpected result: %#v, %v", r, err)
+		}
+	}
+	if r, err := c.ValidatePersisted(s, "v1", "bad", load); err != nil || len(r.Errors) != 1 {
+		t.Errorf("expected one validation error but got %#v, %v", r, err)
+	}
+	// Load errors are not cached.
+	for i := 0; i < 2; i++ {
+		if _, err := c.ValidatePersisted(s, "v1", "unknown", load); err == nil {
+			t.Error("expected load error")
+		}
+	}
+	// A persisted id does not collide with an identical source.
+	if r := c.Validate(s, "v1", "dog"); r.ParseErr == nil {
+		t.Errorf("expected parse error but got %#v", r)
+	}
+	if loads != 4 {
+		t.Errorf("expected 4 loads but got %d", loads)
+	}
+	if c.Len() != 3 {
+		t.Errorf("expected 3 entries but got %d", c.Len())
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	var hits, misses int64
+	c := NewCache(4)
+	c.Hit = func() { atomic.AddInt64(&hits, 1) }
+	c.Miss = func() { atomic.AddInt64(&misses, 1) }
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				query := fmt.Sprintf("{dog(name: \"%d\") {name}}", (i+j)%6)
+				if r := c.Validate(s, "v1", query); r.Document == nil || len(r.Errors) > 0 {
+					t.Errorf("unexpected result: %#v", r)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if hits+misses != 800 {
+		t.Errorf("expected 800 lookups but got %d", hits+misses)
+	}
+	if c.Len() != 4 {
+		t.Errorf("expected 4 entries but got %d", c.Len())
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	s := mustBuild(&testing.T{}, testSDL)
+	c := NewCache(100)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Validate(s, "v1", "query Q($name: String) {dog(name: $name) {...F}} fragment F on Dog {name, owner {name}}")
+		}
+	})
+}
@@ -0,0 +1,423 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// A FieldCost configures the cost of a field: its own complexity, and the names of the arguments whose values
+// multiply the cost of the field and its selections. A list argument multiplies by its length. A negative complexity
+// is invalid, and the default of 1 is used instead.
+//
+// In SDL, a FieldCost may be given by a @cost directive on the field definition:
+//
+//	directive @cost(complexity: Int, multipliers: [String!]) on FIELD_DEFINITION
+//
+//	type Query {
+//		users(first: Int = 10): [User] @cost(complexity: 2, multipliers: ["first"])
+//	}
+type FieldCost struct {
+	Complexity  int
+	Multipliers []string
+}
+
+// A Complexity describes the size of an operation, with the fields of fragments counted at each of their spreads.
+//
+// Each value saturates at math.MaxInt32. When ComplexityLimits enforces a limit, analysis stops as soon as any limit is
+// exceeded, so the values are only lower bounds.
+type Complexity struct {
+	// The deepest nesting of fields, with root fields at depth 1.
+	Depth int
+	// The number of fields selected.
+	Fields int
+	// The sum of the costs of the fields. A field's cost is its complexity plus the cost of its selections,
+	// multiplied by the values of its multiplier arguments.
+	Cost int
+}
+
+// A ComplexityConfig configures complexity analysis, and the limits enforced by ComplexityLimits.
+type ComplexityConfig struct {
+	// The maximum depth, number of fields, and cost of an operation. Zero means no limit.
+	MaxDepth, MaxFields, MaxCost int
+	// The costs of fields keyed by "Type.field", overriding @cost directives. Fields with neither have a complexity of
+	// 1 and no multipliers.
+	Costs map[string]FieldCost
+	// The values of variables, which may be used as multipliers or by @skip and @include. Otherwise the default values
+	// of the variable definitions are used.
+	Variables map[string]interface{}
+}
+
+// The ComplexityLimits function returns a Rule requiring that each operation is within the limits of config.
+func ComplexityLimits(config ComplexityConfig) Rule {
+	return RuleFunc(func(c *Context) *Visitor {
+		return &Visitor{
+			Enter: func(n ast.Node) {
+				op, ok := n.(*ast.OpDef)
+				if !ok {
+					return
+				}
+				limits := Complexity{
+					Depth:  limitOf(config.MaxDepth),
+					Fields: limitOf(config.MaxFields),
+					Cost:   limitOf(config.MaxCost),
+				}
+				cx := newComplexityAnalyzer(c.Schema(), c.Fragment, op, &config, limits).analyze()
+				name := "Anonymous operation"
+				if op.Name.Value != "" {
+					name = fmt.Sprintf("Operation %q", op.Name.Value)
+				}
+				if config.MaxDepth > 0 && cx.Depth > config.MaxDepth {
+					c.Errorf(op.Loc, "%s has a depth of %d, which exceeds the maximum of %d.", name, cx.Depth, config.MaxDepth)
+				}
+				if config.MaxFields > 0 && cx.Fields > config.MaxFields {
+					c.Errorf(op.Loc, "%s selects %d fields, which exceeds the maximum of %d.", name, cx.Fields, config.MaxFields)
+				}
+				if config.MaxCost > 0 && cx.Cost > config.MaxCost {
+					c.Errorf(op.Loc, "%s has a cost of %d, which exceeds the maximum of %d.", name, cx.Cost, config.MaxCost)
+				}
+			},
+		}
+	})
+}
+
+// The limitOf function returns the value at which analysis stops for the maximum max, where zero means no limit.
+func limitOf(max int) int {
+	if max > 0 && max < math.MaxInt32 {
+		return max + 1
+	}
+	return math.MaxInt32
+}
+
+// The AnalyzeComplexity function returns the Complexity of op, an operation of d. Limits in config are ignored.
+//
+// The document is assumed to be valid, but unknown fields and fragments are tolerated: unknown fields have the default
+// cost, and unknown or cyclic fragment spreads are ignored. Selections on abstract types are counted for every type
+// condition, so the result is an upper bound. Each fragment is analyzed once per parent type, so the time taken is
+// proportional to the size of the document, rather than to the number of fields counted.
+func AnalyzeComplexity(s *schema.Schema, d *ast.Document, op *ast.OpDef, config ComplexityConfig) Complexity {
+	fragments := make(map[string]*ast.FragmentDef)
+	for _, def := range d.Definitions {
+		if f, ok := def.(*ast.FragmentDef); ok {
+			if _, ok := fragments[f.Name.Value]; !ok {
+				fragments[f.Name.Value] = f
+			}
+		}
+	}
+	fragment := func(name string) *ast.FragmentDef { return fragments[name] }
+	limits := Complexity{Depth: math.MaxInt32, Fields: math.MaxInt32, Cost: math.MaxInt32}
+	return newComplexityAnalyzer(s, fragment, op, &config, limits).analyze()
+}
+
+// A complexityAnalyzer computes the Complexity of a single operation.
+type complexityAnalyzer struct {
+	schema    *schema.Schema
+	fragment  func(name string) *ast.FragmentDef
+	op        *ast.OpDef
+	config    *ComplexityConfig
+	spreading map[string]bool
+	// The Complexity of each fragment, keyed by its name and parent type, with depths relative to the spread.
+	fragments map[fragmentKey]Complexity
+	// The values at which analysis stops.
+	limits Complexity
+	// The number of fields counted so far.
+	fields int
+	// Set once a limit is reached.
+	exceeded bool
+}
+
+// A fragmentKey identifies a fragment spread on a parent type, which is empty if unknown.
+type fragmentKey struct {
+	name, parent string
+}
+
+func newComplexityAnalyzer(s *schema.Schema, fragment func(string) *ast.FragmentDef, op *ast.OpDef, config *ComplexityConfig, limits Complexity) *complexityAnalyzer {
+	return &complexityAnalyzer{schema: s, fragment: fragment, op: op, config: config, spreading: make(map[string]bool),
+		fragments: make(map[fragmentKey]Complexity), limits: limits}
+}
+
+func (a *complexityAnalyzer) analyze() Complexity {
+	var root *schema.Object
+	switch a.op.OpType {
+	case ast.Query:
+		root = a.schema.QueryType()
+	case ast.Mutation:
+		root = a.schema.MutationType()
+	case ast.Subscription:
+		root = a.schema.SubscriptionType()
+	}
+	var parent schema.NamedType
+	if root != nil {
+		parent = root
+	}
+	return a.selectionSet(parent, &a.op.SelectionSet, 1)
+}
+
+// The selectionSet method returns the Complexity of ss, whose parent type is parent (or nil if unknown) and whose
+// fields are at depth. The Depth of the result is relative to ss, so that its fields are at depth 1. The remaining
+// selections are skipped once a limit is reached.
+func (a *complexityAnalyzer) selectionSet(parent schema.NamedType, ss *ast.SelectionSet, depth int) Complexity {
+	var cx Complexity
+	for _, sel := range ss.Selections {
+		if a.exceeded {
+			break
+		}
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if !a.include(sel.Directives) {
+				continue
+			}
+			a.countFields(1)
+			if depth >= a.limits.Depth {
+				a.exceeded = true
+			}
+			var def *schema.Field
+			if parent != nil {
+				def = a.schema.Field(parent, sel.Name.Value)
+			}
+			fc := FieldCost{Complexity: 1}
+			var child schema.NamedType
+			if def != nil {
+				fc = a.fieldCost(parent, def)
+				child = schema.NamedTypeOf(def.Type())
+			}
+			field := Complexity{Depth: 1, Fields: 1, Cost: fc.Complexity}
+			if len(sel.SelectionSet.Selections) > 0 && !a.exceeded {
+				sub := a.selectionSet(child, &sel.SelectionSet, depth+1)
+				field.Depth = add(field.Depth, sub.Depth, a.limits.Depth)
+				field.Fields = add(field.Fields, sub.Fields, a.limits.Fields)
+				field.Cost = add(field.Cost, sub.Cost, a.limits.Cost)
+			}
+			for _, m := range fc.Multipliers {
+				field.Cost = mul(field.Cost, a.multiplier(def, sel.Arguments, m), a.limits.Cost)
+			}
+			cx = a.merge(cx, field)
+
+		case *ast.InlineFragment:
+			if !a.include(sel.Directives) {
+				continue
+			}
+			t := parent
+			if sel.NamedType.Value != "" {
+				t = a.schema.Type(sel.NamedType.Value)
+			}
+			cx = a.merge(cx, a.selectionSet(t, &sel.SelectionSet, depth))
+
+		case *ast.FragmentSpread:
+			if !a.include(sel.Directives) {
+				continue
+			}
+			cx = a.merge(cx, a.fragmentSpread(sel.Name.Value, depth))
+		}
+		if cx.Cost >= a.limits.Cost {
+			a.exceeded = true
+		}
+	}
+	return cx
+}
+
+// The fragmentSpread method returns the Complexity of the fragment named name, spread at depth. The result for each
+// parent type is remembered, and only counted again at later spreads. Unknown and cyclic spreads are ignored.
+func (a *complexityAnalyzer) fragmentSpread(name string, depth int) Complexity {
+	f := a.fragment(name)
+	if f == nil || a.spreading[name] {
+		return Complexity{}
+	}
+	parent := a.schema.Type(f.TypeCondition.Value)
+	key := fragmentKey{name: name}
+	if parent != nil {
+		key.parent = parent.Name()
+	}
+	if cx, ok := a.fragments[key]; ok {
+		a.countFields(cx.Fields)
+		if depth-1+cx.Depth >= a.limits.Depth {
+			a.exceeded = true
+		}
+		return cx
+	}
+	a.spreading[name] = true
+	cx := a.selectionSet(parent, &f.SelectionSet, depth)
+	delete(a.spreading, name)
+	if !a.exceeded {
+		a.fragments[key] = cx
+	}
+	return cx
+}
+
+// The countFields method adds n to the number of fields counted so far, and notes if the limit is reached.
+func (a *complexityAnalyzer) countFields(n int) {
+	a.fields = add(a.fields, n, a.limits.Fields)
+	if a.fields >= a.limits.Fields {
+		a.exceeded = true
+	}
+}
+
+// The merge method returns the Complexity of the selections of both x and y.
+func (a *complexityAnalyzer) merge(x, y Complexity) Complexity {
+	if y.Depth > x.Depth {
+		x.Depth = y.Depth
+	}
+	x.Fields = add(x.Fields, y.Fields, a.limits.Fields)
+	x.Cost = add(x.Cost, y.Cost, a.limits.Cost)
+	return x
+}
+
+// The add function returns x+y, saturated at limit.
+func add(x, y, limit int) int {
+	if y > 0 && x > limit-y {
+		return limit
+	}
+	return x + y
+}
+
+// The mul function returns x*y, saturated at limit.
+func mul(x, y, limit int) int {
+	if x > 0 && y > 0 && x > limit/y {
+		return limit
+	}
+	return x * y
+}
+
+// The fieldCost method returns the configured cost of def, a field of parent.
+func (a *complexityAnalyzer) fieldCost(parent schema.NamedType, def *schema.Field) FieldCost {
+	if fc, ok := a.config.Costs[parent.Name()+"."+def.Name()]; ok {
+		if fc.Complexity < 0 {
+			fc.Complexity = 1
+		}
+		return fc
+	}
+	fc := FieldCost{Complexity: 1}
+	for _, d := range def.Directives() {
+		if d.Name.Value != "cost" {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			switch arg.Name.Value {
+			case "complexity":
+				if i, ok := arg.Value.(*ast.Int); ok {
+					if n, err := strconv.Atoi(i.Value); err == nil && n >= 0 {
+						fc.Complexity = n
+					}
+				}
+			case "multipliers":
+				if l, ok := arg.Value.(*ast.List); ok {
+					for _, v := range l.Values {
+						if s, ok := v.(*ast.String); ok {
+							fc.Multipliers = append(fc.Multipliers, s.Value)
+						}
+					}
+				}
+			}
+		}
+	}
+	return fc
+}
+
+// The multiplier method returns the value of the argument named name, given by args or defaulted by def, as a
+// multiplier. Lists multiply by their length. Missing, unknown, and values less than 1 multiply by 1.
+func (a *complexityAnalyzer) multiplier(def *schema.Field, args []ast.Argument, name string) int {
+	var v ast.Value
+	for _, arg := range args {
+		if arg.Name.Value == name {
+			v = arg.Value
+			break
+		}
+	}
+	if v == nil && def != nil {
+		if arg := def.Arg(name); arg != nil {
+			v = arg.DefaultValue()
+		}
+	}
+	n := 1
+	switch v := v.(type) {
+	case *ast.Int:
+		if i, err := strconv.Atoi(v.Value); err == nil {
+			n = i
+		}
+	case *ast.List:
+		n = len(v.Values)
+	case *ast.Variable:
+		if value, ok := a.variable(v.Name.Value); ok {
+			n = multiplierOf(value)
+		}
+	}
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// The multiplierOf function returns the integer value of a number, or the length of a slice, or 1.
+func multiplierOf(v interface{}) int {
+	switch v := v.(type) {
+	case *ast.Int:
+		if i, err := strconv.Atoi(v.Value); err == nil {
+			return i
+		}
+		return 1
+	case *ast.List:
+		return len(v.Values)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return int(rv.Float())
+	case reflect.Slice, reflect.Array:
+		return rv.Len()
+	}
+	return 1
+}
+
+// The variable method returns the value of the variable named name: either the configured value, or the default value
+// literal of its definition. It returns false if neither is known.
+func (a *complexityAnalyzer) variable(name string) (interface{}, bool) {
+	if v, ok := a.config.Variables[name]; ok {
+		return v, true
+	}
+	for _, vd := range a.op.VarDefs {
+		if vd.Variable.Name.Value == name && vd.DefaultValue != nil {
+			return vd.DefaultValue, true
+		}
+	}
+	return nil, false
+}
+
+// The include method returns false if ds includes @skip(if: true) or @include(if: false). Conditions on unknown
+// variables are assumed to include the selection.
+func (a *complexityAnalyzer) include(ds []ast.Directive) bool {
+	for _, d := range ds {
+		var skipIf bool
+		switch d.Name.Value {
+		case schema.SkipDirective.Name():
+			skipIf = true
+		case schema.IncludeDirective.Name():
+			skipIf = false
+		default:
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if arg.Name.Value != "if" {
+				continue
+			}
+			var value interface{} = arg.Value
+			if v, ok := arg.Value.(*ast.Variable); ok {
+				value, _ = a.variable(v.Name.Value)
+			}
+			if b, ok := value.(*ast.Boolean); ok {
+				value = b.Value
+			}
+			if b, ok := value.(bool); ok && b == skipIf {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/kr/pretty"
+)
+
+const complexitySDL = `
+directive @cost(complexity: Int, multipliers: [String!]) on FIELD_DEFINITION
+type Query {
+	users(first: Int = 10, ids: [ID]): [User] @cost(complexity: 2, multipliers: ["first", "ids"])
+	user(id: ID): User
+	node: Node
+}
+interface Node {id: ID}
+type User implements Node {id: ID, name: String, friends(first: Int): [User] @cost(multipliers: ["first"])}
+type Post implements Node {id: ID, title: String @cost(complexity: -3)}
+`
+
+// The fanOut function returns a query spreading 2^n copies of a fragment, through n fragments spreading the next
+// one twice.
+func fanOut(n int) string {
+	var b bytes.Buffer
+	b.WriteString("{user {...F0}}")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, " fragment F%d on User {...F%d ...F%d}", i, i+1, i+1)
+	}
+	fmt.Fprintf(&b, " fragment F%d on User {friends {id}}", n)
+	return b.String()
+}
+
+func TestAnalyzeComplexity(t *testing.T) {
+	s := mustBuild(t, complexitySDL)
+	for _, test := range []struct {
+		query    string
+		config   ComplexityConfig
+		expected Complexity
+	}{
+		{"{__typename}", ComplexityConfig{}, Complexity{Depth: 1, Fields: 1, Cost: 1}},
+		{"{user(id: 1) {name}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		// The default value of first multiplies the cost.
+		{"{users {name}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 30}},
+		{"{users(first: 3, ids: [1, 2]) {name friends(first: 5) {name}}}", ComplexityConfig{}, Complexity{Depth: 3, Fields: 4, Cost: 78}},
+		{"query ($n: Int = 4) {users(first: $n) {id}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 12}},
+		{"query ($n: Int = 4) {users(first: $n) {id}}", ComplexityConfig{Variables: map[string]interface{}{"n": 2}}, Complexity{Depth: 2, Fields: 2, Cost: 6}},
+		{"query ($ids: [ID]) {users(first: 1, ids: $ids) {id}}", ComplexityConfig{Variables: map[string]interface{}{"ids": []interface{}{1, 2, 3}}}, Complexity{Depth: 2, Fields: 2, Cost: 9}},
+		// Fragments are counted at each spread.
+		{"{user {...F} node {... on User {...F} ... on Post {title}}} fragment F on User {id name}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 7, Cost: 7}},
+		{"query ($s: Boolean = true) {user {name @skip(if: $s) id @include(if: false)} node @skip(if: false) {id}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 3, Cost: 3}},
+		{"query ($s: Boolean) {user {name @skip(if: $s)}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		{"query ($s: Boolean) {user {name @skip(if: $s)}}", ComplexityConfig{Variables: map[string]interface{}{"s": true}}, Complexity{Depth: 1, Fields: 1, Cost: 1}},
+		{"{user {name}}", ComplexityConfig{Costs: map[string]FieldCost{"User.name": {Complexity: 5}}}, Complexity{Depth: 2, Fields: 2, Cost: 6}},
+		{"{users {id}}", ComplexityConfig{Costs: map[string]FieldCost{"Query.users": {Complexity: 1}}}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		// Negative complexities are replaced by the default.
+		{"{node {... on Post {title}}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		{"{user {name}}", ComplexityConfig{Costs: map[string]FieldCost{"User.name": {Complexity: -5}}}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		// Fragments are analyzed once per parent type, and the results saturate.
+		{fanOut(3), ComplexityConfig{}, Complexity{Depth: 3, Fields: 17, Cost: 17}},
+		{fanOut(40), ComplexityConfig{}, Complexity{Depth: 3, Fields: math.MaxInt32, Cost: math.MaxInt32}},
+		// Cyclic and unknown fragments, and unknown fields, are tolerated.
+		{"{user {...A ...B}} fragment A on User {friends {...A}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+		{"{unknown {a}}", ComplexityConfig{}, Complexity{Depth: 2, Fields: 2, Cost: 2}},
+	} {
+		d := mustParse(t, test.query)
+		actual := AnalyzeComplexity(s, d, d.Definitions[0].(*ast.OpDef), test.config)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %v but got %v\n%s", test.query, test.expected, actual, pretty.Diff(test.expected, actual))
+		}
+	}
+}
+
+func TestComplexityLimits(t *testing.T) {
+	checkRule(t, mustBuild(t, complexitySDL), ComplexityLimits(ComplexityConfig{MaxDepth: 2, MaxFields: 3, MaxCost: 20}), []ruleTest{
+		{"{user {name}} query Q {users(first: 2) {id}}", nil},
+		{"query Q {users {friends {name}}}", []string{
+			`Operation "Q" has a depth of 3, which exceeds the maximum of 2. (at position 0)`,
+			`Operation "Q" has a cost of 21, which exceeds the maximum of 20. (at position 0)`,
+		}},
+		{"{user {id name} node {id}}", []string{
+			`Anonymous operation selects 4 fields, which exceeds the maximum of 3. (at position 0)`,
+		}},
+	})
+	checkRule(t, mustBuild(t, complexitySDL), ComplexityLimits(ComplexityConfig{MaxFields: 100, MaxDepth: 5}), []ruleTest{
+		{fanOut(40), []string{
+			`Anonymous operation selects 101 fields, which exceeds the maximum of 100. (at position 0)`,
+		}},
+	})
+	checkRule(t, mustBuild(t, complexitySDL), ComplexityLimits(ComplexityConfig{MaxCost: 1000}), []ruleTest{
+		{"{users(first: 2000000){friends(first: 2000000){friends(first: 2000000){id}}}}", []string{
+			`Anonymous operation has a cost of 1001, which exceeds the maximum of 1000. (at position 0)`,
+		}},
+	})
+}
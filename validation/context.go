@@ -0,0 +1,389 @@
+package validation
+
+import (
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// A Context holds the state shared by the rules validating a Document: the Schema, the Document, the errors reported
+// so far, and the type information of the node currently being visited.
+type Context struct {
+	schema    *schema.Schema
+	document  *ast.Document
+	fragments map[string]*ast.FragmentDef
+	errs      []*errors.GraphQLError
+
+	spreads    map[*ast.SelectionSet][]*ast.FragmentSpread
+	referenced map[*ast.OpDef][]*ast.FragmentDef
+	usages     map[ast.Node][]VariableUsage
+	recursive  map[*ast.OpDef][]VariableUsage
+
+	typeStack         []schema.Type
+	parentTypeStack   []schema.NamedType
+	fieldDefStack     []*schema.Field
+	inputTypeStack    []schema.Type
+	defaultValueStack []ast.Value
+	inDirective       bool
+	directive         *schema.Directive
+	argument          *schema.InputValue
+}
+
+// A VariableUsage is a variable used as a value, along with the type and default value expected at its position. Type
+// is nil if it is unknown, and DefaultValue is nil if the position has none.
+type VariableUsage struct {
+	Node         *ast.Variable
+	Type         schema.Type
+	DefaultValue ast.Value
+}
+
+func newContext(s *schema.Schema, d *ast.Document) *Context {
+	c := &Context{
+		schema:     s,
+		document:   d,
+		fragments:  make(map[string]*ast.FragmentDef),
+		spreads:    make(map[*ast.SelectionSet][]*ast.FragmentSpread),
+		referenced: make(map[*ast.OpDef][]*ast.FragmentDef),
+		usages:     make(map[ast.Node][]VariableUsage),
+		recursive:  make(map[*ast.OpDef][]VariableUsage),
+	}
+	for _, def := range d.Definitions {
+		if f, ok := def.(*ast.FragmentDef); ok {
+			if _, ok := c.fragments[f.Name.Value]; !ok {
+				c.fragments[f.Name.Value] = f
+			}
+		}
+	}
+	return c
+}
+
+// The Schema method returns the Schema being validated against.
+func (c *Context) Schema() *schema.Schema { return c.schema }
+
+// The Document method returns the Document being validated.
+func (c *Context) Document() *ast.Document { return c.document }
+
+// The Report method reports err.
+func (c *Context) Report(err *errors.GraphQLError) {
+	c.errs = append(c.errs, err)
+}
+
+// The Errorf method reports an error associated with the node at loc, with a message formatted by fmt.Sprintf.
+func (c *Context) Errorf(loc ast.Loc, format string, args ...interface{}) {
+	c.Report(errors.Newf(loc, format, args...))
+}
+
+// The Fragment method returns the first fragment definition named name, or nil if there is none.
+func (c *Context) Fragment(name string) *ast.FragmentDef { return c.fragments[name] }
+
+// The FragmentSpreads method returns the fragment spreads within ss, including those nested in fields and inline
+// fragments, but not those within the spread fragments.
+func (c *Context) FragmentSpreads(ss *ast.SelectionSet) []*ast.FragmentSpread {
+	if spreads, ok := c.spreads[ss]; ok {
+		return spreads
+	}
+	var spreads []*ast.FragmentSpread
+	sets := []*ast.SelectionSet{ss}
+	for len(sets) > 0 {
+		set := sets[len(sets)-1]
+		sets = sets[:len(sets)-1]
+		for _, sel := range set.Selections {
+			switch sel := sel.(type) {
+			case *ast.FragmentSpread:
+				spreads = append(spreads, sel)
+			case *ast.Field:
+				sets = append(sets, &sel.SelectionSet)
+			case *ast.InlineFragment:
+				sets = append(sets, &sel.SelectionSet)
+			}
+		}
+	}
+	c.spreads[ss] = spreads
+	return spreads
+}
+
+// The RecursivelyReferencedFragments method returns the known fragments spread by op, directly or through other
+// fragments.
+func (c *Context) RecursivelyReferencedFragments(op *ast.OpDef) []*ast.FragmentDef {
+	if fragments, ok := c.referenced[op]; ok {
+		return fragments
+	}
+	var fragments []*ast.FragmentDef
+	collected := make(map[string]bool)
+	sets := []*ast.SelectionSet{&op.SelectionSet}
+	for len(sets) > 0 {
+		set := sets[len(sets)-1]
+		sets = sets[:len(sets)-1]
+		for _, spread := range c.FragmentSpreads(set) {
+			name := spread.Name.Value
+			if collected[name] {
+				continue
+			}
+			collected[name] = true
+			if f := c.Fragment(name); f != nil {
+				fragments = append(fragments, f)
+				sets = append(sets, &f.SelectionSet)
+			}
+		}
+	}
+	c.referenced[op] = fragments
+	return fragments
+}
+
+// The VariableUsages method returns the variables used as values within n, an operation or fragment definition, but
+// not within the fragments it spreads. The variables of an operation's own variable definitions are not usages.
+func (c *Context) VariableUsages(n ast.Node) []VariableUsage {
+	if usages, ok := c.usages[n]; ok {
+		return usages
+	}
+	// Traverse n with a fresh copy of the type information, so that each usage has the type of its position.
+	sub := &Context{
+		schema:     c.schema,
+		document:   c.document,
+		fragments:  c.fragments,
+		spreads:    c.spreads,
+		referenced: c.referenced,
+		usages:     c.usages,
+		recursive:  c.recursive,
+	}
+	var usages []VariableUsage
+	inVarDef := false
+	w := walker{context: sub, visitors: []*Visitor{{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.VarDef:
+				inVarDef = true
+			case *ast.Variable:
+				if !inVarDef {
+					usages = append(usages, VariableUsage{Node: n, Type: sub.InputType(), DefaultValue: sub.DefaultValue()})
+				}
+			}
+		},
+		Leave: func(n ast.Node) {
+			if _, ok := n.(*ast.VarDef); ok {
+				inVarDef = false
+			}
+		},
+	}}}
+	switch n := n.(type) {
+	case *ast.OpDef:
+		w.opDef(n)
+	case *ast.FragmentDef:
+		w.fragmentDef(n)
+	}
+	c.usages[n] = usages
+	return usages
+}
+
+// The RecursiveVariableUsages method returns the variables used as values within op, including those within the
+// fragments it spreads, directly or through other fragments.
+func (c *Context) RecursiveVariableUsages(op *ast.OpDef) []VariableUsage {
+	if usages, ok := c.recursive[op]; ok {
+		return usages
+	}
+	usages := c.VariableUsages(op)
+	for _, f := range c.RecursivelyReferencedFragments(op) {
+		usages = append(usages[:len(usages):len(usages)], c.VariableUsages(f)...)
+	}
+	c.recursive[op] = usages
+	return usages
+}
+
+// The Type method returns the output type of the current field, operation, or fragment, or nil if it is unknown.
+func (c *Context) Type() schema.Type {
+	if len(c.typeStack) == 0 {
+		return nil
+	}
+	return c.typeStack[len(c.typeStack)-1]
+}
+
+// The ParentType method returns the composite type of the current selection set, or nil if it is unknown.
+func (c *Context) ParentType() schema.NamedType {
+	if len(c.parentTypeStack) == 0 {
+		return nil
+	}
+	return c.parentTypeStack[len(c.parentTypeStack)-1]
+}
+
+// The FieldDef method returns the definition of the current field, or nil if it is unknown.
+func (c *Context) FieldDef() *schema.Field {
+	if len(c.fieldDefStack) == 0 {
+		return nil
+	}
+	return c.fieldDefStack[len(c.fieldDefStack)-1]
+}
+
+// The InputType method returns the expected type of the current value, variable definition, or argument, or nil if it
+// is unknown.
+func (c *Context) InputType() schema.Type {
+	if len(c.inputTypeStack) == 0 {
+		return nil
+	}
+	return c.inputTypeStack[len(c.inputTypeStack)-1]
+}
+
+// The ParentInputType method returns the expected type of the list or input object containing the current value, or
+// nil if it is unknown.
+func (c *Context) ParentInputType() schema.Type {
+	if len(c.inputTypeStack) < 2 {
+		return nil
+	}
+	return c.inputTypeStack[len(c.inputTypeStack)-2]
+}
+
+// The DefaultValue method returns the default value of the current argument or input object field, or nil if it has
+// none or is unknown.
+func (c *Context) DefaultValue() ast.Value {
+	if len(c.defaultValueStack) == 0 {
+		return nil
+	}
+	return c.defaultValueStack[len(c.defaultValueStack)-1]
+}
+
+// The Directive method returns the definition of the current directive, or nil if it is unknown.
+func (c *Context) Directive() *schema.Directive { return c.directive }
+
+// The Argument method returns the definition of the current argument, or nil if it is unknown.
+func (c *Context) Argument() *schema.InputValue { return c.argument }
+
+// The enter method updates the type information upon entering n.
+func (c *Context) enter(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.SelectionSet:
+		var parent schema.NamedType
+		if t := schema.NamedTypeOf(c.Type()); schema.IsCompositeType(t) {
+			parent = t
+		}
+		c.parentTypeStack = append(c.parentTypeStack, parent)
+
+	case *ast.Field:
+		var fieldDef *schema.Field
+		var t schema.Type
+		if parent := c.ParentType(); parent != nil {
+			fieldDef = c.schema.Field(parent, n.Name.Value)
+		}
+		if fieldDef != nil {
+			t = fieldDef.Type()
+		}
+		c.fieldDefStack = append(c.fieldDefStack, fieldDef)
+		c.typeStack = append(c.typeStack, t)
+
+	case *ast.Directive:
+		c.inDirective = true
+		c.directive = c.schema.Directive(n.Name.Value)
+
+	case *ast.OpDef:
+		var root *schema.Object
+		switch n.OpType {
+		case ast.Query:
+			root = c.schema.QueryType()
+		case ast.Mutation:
+			root = c.schema.MutationType()
+		case ast.Subscription:
+			root = c.schema.SubscriptionType()
+		}
+		var t schema.Type
+		if root != nil {
+			t = root
+		}
+		c.typeStack = append(c.typeStack, t)
+
+	case *ast.InlineFragment:
+		c.typeStack = append(c.typeStack, c.typeCondition(&n.NamedType))
+
+	case *ast.FragmentDef:
+		c.typeStack = append(c.typeStack, c.typeCondition(&n.TypeCondition))
+
+	case *ast.VarDef:
+		var t schema.Type
+		if vt := c.schema.TypeFromAST(n.RefType); schema.IsInputType(vt) {
+			t = vt
+		}
+		c.inputTypeStack = append(c.inputTypeStack, t)
+
+	case *ast.Argument:
+		var arg *schema.InputValue
+		var t schema.Type
+		var def ast.Value
+		if c.inDirective {
+			if c.directive != nil {
+				arg = c.directive.Arg(n.Name.Value)
+			}
+		} else if fieldDef := c.FieldDef(); fieldDef != nil {
+			arg = fieldDef.Arg(n.Name.Value)
+		}
+		if arg != nil {
+			t = arg.Type()
+			def = arg.DefaultValue()
+		}
+		c.argument = arg
+		c.inputTypeStack = append(c.inputTypeStack, t)
+		c.defaultValueStack = append(c.defaultValueStack, def)
+
+	case *ast.List:
+		var item schema.Type
+		t := c.InputType()
+		if nn, ok := t.(*schema.NonNullType); ok {
+			t = nn.OfType()
+		}
+		if l, ok := t.(*schema.ListType); ok {
+			item = l.OfType()
+		} else if t != nil {
+			item = t
+		}
+		c.inputTypeStack = append(c.inputTypeStack, item)
+		c.defaultValueStack = append(c.defaultValueStack, nil)
+
+	case *ast.ObjectField:
+		var t schema.Type
+		var def ast.Value
+		if o, ok := schema.NamedTypeOf(c.InputType()).(*schema.InputObject); ok {
+			if f := o.Field(n.Name.Value); f != nil {
+				t = f.Type()
+				def = f.DefaultValue()
+			}
+		}
+		c.inputTypeStack = append(c.inputTypeStack, t)
+		c.defaultValueStack = append(c.defaultValueStack, def)
+	}
+}
+
+// The leave method restores the type information upon leaving n.
+func (c *Context) leave(n ast.Node) {
+	switch n.(type) {
+	case *ast.SelectionSet:
+		c.parentTypeStack = c.parentTypeStack[:len(c.parentTypeStack)-1]
+	case *ast.Field:
+		c.fieldDefStack = c.fieldDefStack[:len(c.fieldDefStack)-1]
+		c.typeStack = c.typeStack[:len(c.typeStack)-1]
+	case *ast.Directive:
+		c.inDirective = false
+		c.directive = nil
+	case *ast.OpDef, *ast.InlineFragment, *ast.FragmentDef:
+		c.typeStack = c.typeStack[:len(c.typeStack)-1]
+	case *ast.Argument:
+		c.argument = nil
+		c.inputTypeStack = c.inputTypeStack[:len(c.inputTypeStack)-1]
+		c.defaultValueStack = c.defaultValueStack[:len(c.defaultValueStack)-1]
+	case *ast.List, *ast.ObjectField:
+		c.inputTypeStack = c.inputTypeStack[:len(c.inputTypeStack)-1]
+		c.defaultValueStack = c.defaultValueStack[:len(c.defaultValueStack)-1]
+	case *ast.VarDef:
+		c.inputTypeStack = c.inputTypeStack[:len(c.inputTypeStack)-1]
+	}
+}
+
+// The typeCondition method returns the output type named by the type condition nt, or the current type if nt is
+// empty, or nil if it is unknown.
+func (c *Context) typeCondition(nt *ast.NamedType) schema.Type {
+	var t schema.NamedType
+	if nt.Value != "" {
+		t = c.schema.Type(nt.Value)
+	} else {
+		t = schema.NamedTypeOf(c.Type())
+	}
+	if t == nil || !schema.IsOutputType(t) {
+		return nil
+	}
+	return t
+}
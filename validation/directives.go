@@ -0,0 +1,187 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// KnownDirectives requires that directives are defined by the Schema, and are used only in the locations they allow.
+var KnownDirectives Rule = RuleFunc(knownDirectives)
+
+func knownDirectives(c *Context) *Visitor {
+	// The nodes with directives which are currently being visited.
+	var owners []ast.Node
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			ds := directivesOf(n)
+			if ds == nil {
+				return
+			}
+			var parent ast.Node
+			if len(owners) > 0 {
+				parent = owners[len(owners)-1]
+			}
+			owners = append(owners, n)
+			loc, ok := directiveLocation(n, parent)
+			if !ok {
+				return
+			}
+			for i := range ds {
+				d := &ds[i]
+				def := c.Schema().Directive(d.Name.Value)
+				if def == nil {
+					c.Errorf(d.Loc, "Unknown directive \"@%s\".", d.Name.Value)
+					continue
+				}
+				if !allowsLocation(def, loc) {
+					c.Errorf(d.Loc, "Directive \"@%s\" may not be used on %s.", d.Name.Value, loc)
+				}
+			}
+		},
+		Leave: func(n ast.Node) {
+			if directivesOf(n) != nil {
+				owners = owners[:len(owners)-1]
+			}
+		},
+	}
+}
+
+// The allowsLocation function returns true if d may be used at loc.
+func allowsLocation(d *schema.Directive, loc schema.DirectiveLocation) bool {
+	for _, l := range d.Locations() {
+		if l == loc {
+			return true
+		}
+	}
+	return false
+}
+
+// The directiveLocation function returns the location of the directives of n, whose nearest ancestor with directives
+// is parent, or false if n has none.
+func directiveLocation(n, parent ast.Node) (schema.DirectiveLocation, bool) {
+	switch n := n.(type) {
+	case *ast.OpDef:
+		switch n.OpType {
+		case ast.Query:
+			return schema.LocationQuery, true
+		case ast.Mutation:
+			return schema.LocationMutation, true
+		case ast.Subscription:
+			return schema.LocationSubscription, true
+		}
+	case *ast.Field:
+		return schema.LocationField, true
+	case *ast.FragmentSpread:
+		return schema.LocationFragmentSpread, true
+	case *ast.InlineFragment:
+		return schema.LocationInlineFragment, true
+	case *ast.FragmentDef:
+		return schema.LocationFragmentDefinition, true
+	case *ast.VarDef:
+		return schema.LocationVariableDefinition, true
+	case *ast.SchemaDef:
+		return schema.LocationSchema, true
+	case *ast.ScalarTypeDef:
+		return schema.LocationScalar, true
+	case *ast.ObjTypeDef, *ast.TypeExtDef:
+		return schema.LocationObject, true
+	case *ast.FieldDef:
+		return schema.LocationFieldDefinition, true
+	case *ast.InterfaceTypeDef:
+		return schema.LocationInterface, true
+	case *ast.UnionTypeDef:
+		return schema.LocationUnion, true
+	case *ast.EnumTypeDef:
+		return schema.LocationEnum, true
+	case *ast.EnumValueDef:
+		return schema.LocationEnumValue, true
+	case *ast.InputObjTypeDef:
+		return schema.LocationInputObject, true
+	case *ast.InputValueDef:
+		if _, ok := parent.(*ast.InputObjTypeDef); ok {
+			return schema.LocationInputFieldDefinition, true
+		}
+		return schema.LocationArgumentDefinition, true
+	}
+	return "", false
+}
+
+// The directivesOf function returns the directives of n, or nil if n is not a kind of node which may have directives.
+// The result is non-nil, but may be empty, for nodes which may.
+func directivesOf(n ast.Node) []ast.Directive {
+	var ds []ast.Directive
+	switch n := n.(type) {
+	case *ast.OpDef:
+		ds = n.Directives
+	case *ast.Field:
+		ds = n.Directives
+	case *ast.FragmentSpread:
+		ds = n.Directives
+	case *ast.InlineFragment:
+		ds = n.Directives
+	case *ast.FragmentDef:
+		ds = n.Directives
+	case *ast.VarDef:
+		ds = n.Directives
+	case *ast.SchemaDef:
+		ds = n.Directives
+	case *ast.ScalarTypeDef:
+		ds = n.Directives
+	case *ast.ObjTypeDef:
+		ds = n.Directives
+	case *ast.TypeExtDef:
+		ds = n.Directives
+	case *ast.FieldDef:
+		ds = n.Directives
+	case *ast.InputValueDef:
+		ds = n.Directives
+	case *ast.InterfaceTypeDef:
+		ds = n.Directives
+	case *ast.UnionTypeDef:
+		ds = n.Directives
+	case *ast.EnumTypeDef:
+		ds = n.Directives
+	case *ast.EnumValueDef:
+		ds = n.Directives
+	case *ast.InputObjTypeDef:
+		ds = n.Directives
+	default:
+		return nil
+	}
+	if ds == nil {
+		return []ast.Directive{}
+	}
+	return ds
+}
+
+// UniqueDirectivesPerLocation requires that directives which are not repeatable are used at most once in each
+// location.
+var UniqueDirectivesPerLocation Rule = RuleFunc(uniqueDirectivesPerLocation)
+
+func uniqueDirectivesPerLocation(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			ds := directivesOf(n)
+			if len(ds) < 2 {
+				return
+			}
+			seen := make(map[string]*ast.Directive, len(ds))
+			for i := range ds {
+				d := &ds[i]
+				// Unknown directives are reported by KnownDirectives.
+				def := c.Schema().Directive(d.Name.Value)
+				if def == nil || def.IsRepeatable() {
+					continue
+				}
+				if first, ok := seen[d.Name.Value]; ok {
+					c.Report(errors.New(fmt.Sprintf("The directive \"@%s\" can only be used once at this location.", d.Name.Value), first.Loc, d.Loc))
+					continue
+				}
+				seen[d.Name.Value] = d
+			}
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestKnownDirectives(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), KnownDirectives, []ruleTest{
+		{"query ($a: Boolean!) {dog @include(if: $a) {name @skip(if: true) ...F @skip(if: $a) ... on Dog @include(if: true) {barks}}} fragment F on Dog {name}", nil},
+		{"{dog @unknown {name @Skip(if: true)}}", []string{
+			`Unknown directive "@unknown". (at position 5)`,
+			`Unknown directive "@Skip". (at position 20)`,
+		}},
+		{"query @skip(if: true) {dog {...F}} mutation @include(if: true) {adopt(names: []) {name}} fragment F on Dog @skip(if: true) {name @deprecated}", []string{
+			`Directive "@skip" may not be used on QUERY. (at position 6)`,
+			`Directive "@include" may not be used on MUTATION. (at position 44)`,
+			`Directive "@skip" may not be used on FRAGMENT_DEFINITION. (at position 107)`,
+			`Directive "@deprecated" may not be used on FIELD. (at position 129)`,
+		}},
+		{"query ($a: Boolean @skip(if: true)) {dog @tag {name}}", []string{
+			`Directive "@skip" may not be used on VARIABLE_DEFINITION. (at position 19)`,
+			`Unknown directive "@tag". (at position 41)`,
+		}},
+		// Type system definitions are checked too.
+		{`scalar S @specifiedBy(url: "u") @deprecated type T {f(a: Int @deprecated): Int @deprecated} enum E {A @deprecated} input I {f: Int @skip(if: true)}`, []string{
+			`Directive "@deprecated" may not be used on SCALAR. (at position 32)`,
+			`Directive "@deprecated" may not be used on ARGUMENT_DEFINITION. (at position 61)`,
+			`Directive "@skip" may not be used on INPUT_FIELD_DEFINITION. (at position 131)`,
+		}},
+	})
+
+	// Custom directives.
+	s := mustBuild(t, testSDL+"directive @tag on VARIABLE_DEFINITION directive @cost(weight: Int) on FIELD_DEFINITION")
+	checkRule(t, s, KnownDirectives, []ruleTest{
+		{"query ($a: Boolean! @tag) {dog @include(if: $a) {name}}", nil},
+		{"{dog @tag {name}}", []string{`Directive "@tag" may not be used on FIELD. (at position 5)`}},
+		{"type T {f: Int @cost(weight: 2)} directive @d(a: Int @cost) on FIELD", []string{
+			`Directive "@cost" may not be used on ARGUMENT_DEFINITION. (at position 53)`,
+		}},
+	})
+}
+
+func TestUniqueDirectivesPerLocation(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), UniqueDirectivesPerLocation, []ruleTest{
+		{"{dog @skip(if: false) @include(if: true) {name @skip(if: true) n: name @skip(if: true)} pet @unknown @unknown {name}}", nil},
+		{"{dog @skip(if: false) @skip(if: true) @skip(if: false) {...F @include(if: true) @include(if: true)}} fragment F on Dog {name}", []string{
+			`The directive "@skip" can only be used once at this location. (at positions 5, 22)`,
+			`The directive "@skip" can only be used once at this location. (at positions 5, 38)`,
+			`The directive "@include" can only be used once at this location. (at positions 61, 80)`,
+		}},
+		{"type T {f: Int @deprecated @deprecated}", []string{
+			`The directive "@deprecated" can only be used once at this location. (at positions 15, 27)`,
+		}},
+	})
+
+	// Repeatable directives may be used more than once.
+	s := mustBuild(t, testSDL+"directive @tag repeatable on FIELD | VARIABLE_DEFINITION directive @once on VARIABLE_DEFINITION")
+	checkRule(t, s, UniqueDirectivesPerLocation, []ruleTest{
+		{"query ($a: Int @tag @tag) {dog @tag @tag {name}}", nil},
+		{"query ($a: Int @once @once) {dog {name}}", []string{
+			`The directive "@once" can only be used once at this location. (at positions 15, 21)`,
+		}},
+	})
+}
@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"sort"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// FieldsOnCorrectType requires that selected fields are defined by the parent type. Unknown fields are reported with
+// suggestions of the types which do define them, or of similarly named fields.
+var FieldsOnCorrectType Rule = RuleFunc(fieldsOnCorrectType)
+
+func fieldsOnCorrectType(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			f, ok := n.(*ast.Field)
+			if !ok {
+				return
+			}
+			parent := c.ParentType()
+			if parent == nil || c.FieldDef() != nil {
+				return
+			}
+			name := f.Name.Value
+			suggestion := didYouMean("to use an inline fragment on", suggestedTypeNames(c.Schema(), parent, name))
+			if suggestion == "" {
+				suggestion = didYouMean("", suggestedFieldNames(parent, name))
+			}
+			c.Errorf(f.Loc, "Cannot query field %q on type %q.%s", name, parent.Name(), suggestion)
+		},
+	}
+}
+
+// The suggestedTypeNames function returns the names of the possible types of the abstract type t which define a field
+// named name. Interfaces are listed first, ordered by how many of the object types implement them, followed by the
+// object types.
+func suggestedTypeNames(s *schema.Schema, t schema.NamedType, name string) []string {
+	if !schema.IsAbstractType(t) {
+		return nil
+	}
+	var objects, interfaces []string
+	usage := make(map[string]int)
+	for _, o := range s.PossibleTypes(t) {
+		if o.Field(name) == nil {
+			continue
+		}
+		objects = append(objects, o.Name())
+		for _, i := range o.Interfaces() {
+			if i.Field(name) == nil {
+				continue
+			}
+			if usage[i.Name()] == 0 {
+				interfaces = append(interfaces, i.Name())
+			}
+			usage[i.Name()]++
+		}
+	}
+	sort.SliceStable(interfaces, func(i, j int) bool { return usage[interfaces[i]] > usage[interfaces[j]] })
+	return append(interfaces, objects...)
+}
+
+// The suggestedFieldNames function returns the names of the fields of t which are similar to name.
+func suggestedFieldNames(t schema.NamedType, name string) []string {
+	var fields []*schema.Field
+	switch t := t.(type) {
+	case *schema.Object:
+		fields = t.Fields()
+	case *schema.Interface:
+		fields = t.Fields()
+	default:
+		return nil
+	}
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name()
+	}
+	return suggestionList(name, names)
+}
+
+// ScalarLeafs requires that fields of leaf types (scalars and enums) have no selections, and that fields of composite
+// types have some.
+var ScalarLeafs Rule = RuleFunc(scalarLeafs)
+
+func scalarLeafs(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			f, ok := n.(*ast.Field)
+			if !ok {
+				return
+			}
+			t := c.Type()
+			if t == nil {
+				return
+			}
+			hasSelections := len(f.SelectionSet.Selections) > 0
+			if schema.IsLeafType(schema.NamedTypeOf(t)) {
+				if hasSelections {
+					c.Errorf(f.SelectionSet.Loc, "Field %q must not have a selection since type %q has no subfields.", f.Name.Value, t.String())
+				}
+			} else if !hasSelections {
+				c.Errorf(f.Loc, "Field %q of type %q must have a selection of subfields. Did you mean \"%s { ... }\"?", f.Name.Value, t.String(), f.Name.Value)
+			}
+		},
+	}
+}
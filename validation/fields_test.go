@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestFieldsOnCorrectType(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), FieldsOnCorrectType, []ruleTest{
+		{"{dog {name, barks, __typename, owner {name}} pet {name, ... on Dog {barks}} __schema {types {name}}}", nil},
+		{"fragment F on Dog {nam, barks {unknown}, owner {nme, pets {... on Cat {meow}}}}", []string{
+			`Cannot query field "nam" on type "Dog". Did you mean "name"? (at position 19)`,
+			`Cannot query field "nme" on type "Human". Did you mean "name"? (at position 48)`,
+			`Cannot query field "meow" on type "Cat". Did you mean "meows"? (at position 71)`,
+		}},
+		{"fragment F on Pet {barks} fragment G on CatOrDog {name, unknown}", []string{
+			`Cannot query field "barks" on type "Pet". Did you mean to use an inline fragment on "Dog"? (at position 19)`,
+			`Cannot query field "name" on type "CatOrDog". Did you mean to use an inline fragment on "Pet", "Cat", or "Dog"? (at position 50)`,
+			`Cannot query field "unknown" on type "CatOrDog". (at position 56)`,
+		}},
+	})
+}
+
+func TestScalarLeafs(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), ScalarLeafs, []ruleTest{
+		{"{dog {name, owner {name}} pets {name} unknown}", nil},
+		{"{dog, pets, dog {name {length}, doesKnowCommand(command: SIT) {name}}}", []string{
+			`Field "dog" of type "Dog" must have a selection of subfields. Did you mean "dog { ... }"? (at position 1)`,
+			`Field "pets" of type "[Pet]" must have a selection of subfields. Did you mean "pets { ... }"? (at position 6)`,
+			`Field "name" must not have a selection since type "String" has no subfields. (at position 22)`,
+			`Field "doesKnowCommand" must not have a selection since type "Boolean" has no subfields. (at position 62)`,
+		}},
+	})
+}
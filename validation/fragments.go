@@ -0,0 +1,228 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// KnownFragmentNames requires that fragment spreads refer to fragments defined in the Document.
+var KnownFragmentNames Rule = RuleFunc(knownFragmentNames)
+
+func knownFragmentNames(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			if s, ok := n.(*ast.FragmentSpread); ok && c.Fragment(s.Name.Value) == nil {
+				c.Errorf(s.Name.Loc, "Unknown fragment %q.", s.Name.Value)
+			}
+		},
+	}
+}
+
+// UniqueFragmentNames requires that fragment definitions have unique names.
+var UniqueFragmentNames Rule = RuleFunc(uniqueFragmentNames)
+
+func uniqueFragmentNames(c *Context) *Visitor {
+	known := make(map[string]*ast.FragmentDef)
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			f, ok := n.(*ast.FragmentDef)
+			if !ok {
+				return
+			}
+			if first, ok := known[f.Name.Value]; ok {
+				c.Report(errors.New(fmt.Sprintf("There can be only one fragment named %q.", f.Name.Value), first.Name.Loc, f.Name.Loc))
+				return
+			}
+			known[f.Name.Value] = f
+		},
+	}
+}
+
+// NoUnusedFragments requires that every fragment definition is spread by an operation, directly or through other
+// fragments.
+var NoUnusedFragments Rule = RuleFunc(noUnusedFragments)
+
+func noUnusedFragments(c *Context) *Visitor {
+	var ops []*ast.OpDef
+	var fragments []*ast.FragmentDef
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.OpDef:
+				ops = append(ops, n)
+			case *ast.FragmentDef:
+				fragments = append(fragments, n)
+			}
+		},
+		Leave: func(n ast.Node) {
+			if _, ok := n.(*ast.Document); !ok {
+				return
+			}
+			used := make(map[string]bool)
+			for _, op := range ops {
+				for _, f := range c.RecursivelyReferencedFragments(op) {
+					used[f.Name.Value] = true
+				}
+			}
+			for _, f := range fragments {
+				if !used[f.Name.Value] {
+					c.Errorf(f.Loc, "Fragment %q is never used.", f.Name.Value)
+				}
+			}
+		},
+	}
+}
+
+// NoFragmentCycles requires that fragments do not spread themselves, directly or through other fragments, which
+// would result in infinite selections.
+var NoFragmentCycles Rule = RuleFunc(noFragmentCycles)
+
+func noFragmentCycles(c *Context) *Visitor {
+	d := &cycleDetector{
+		context:   c,
+		visited:   make(map[string]bool),
+		pathIndex: make(map[string]int),
+	}
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			if f, ok := n.(*ast.FragmentDef); ok {
+				d.detect(f)
+			}
+		},
+	}
+}
+
+// A cycleDetector finds fragment cycles by depth first search, visiting each fragment once, so that each cycle is
+// reported only once.
+type cycleDetector struct {
+	context *Context
+	visited map[string]bool
+	// The spreads currently being followed, and the index in path at which each fragment along it was entered.
+	path      []*ast.FragmentSpread
+	pathIndex map[string]int
+}
+
+func (d *cycleDetector) detect(f *ast.FragmentDef) {
+	name := f.Name.Value
+	if d.visited[name] {
+		return
+	}
+	d.visited[name] = true
+
+	spreads := d.context.FragmentSpreads(&f.SelectionSet)
+	if len(spreads) == 0 {
+		return
+	}
+	d.pathIndex[name] = len(d.path)
+	for _, s := range spreads {
+		spreadName := s.Name.Value
+		index, inPath := d.pathIndex[spreadName]
+		d.path = append(d.path, s)
+		if !inPath {
+			if spread := d.context.Fragment(spreadName); spread != nil {
+				d.detect(spread)
+			}
+		} else {
+			cycle := d.path[index:]
+			var via bytes.Buffer
+			locs := make([]ast.Loc, len(cycle))
+			for i, s := range cycle {
+				locs[i] = s.Loc
+				if i < len(cycle)-1 {
+					if i == 0 {
+						via.WriteString(" via ")
+					} else {
+						via.WriteString(", ")
+					}
+					via.WriteString(s.Name.Value)
+				}
+			}
+			d.context.Report(errors.New(fmt.Sprintf("Cannot spread fragment %q within itself%s.", spreadName, via.String()), locs...))
+		}
+		d.path = d.path[:len(d.path)-1]
+	}
+	delete(d.pathIndex, name)
+}
+
+// FragmentsOnCompositeTypes requires that fragments condition on composite types.
+var FragmentsOnCompositeTypes Rule = RuleFunc(fragmentsOnCompositeTypes)
+
+func fragmentsOnCompositeTypes(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.InlineFragment:
+				if n.NamedType.Value == "" {
+					return
+				}
+				if t := c.Schema().Type(n.NamedType.Value); t != nil && !schema.IsCompositeType(t) {
+					c.Errorf(n.NamedType.Loc, "Fragment cannot condition on non composite type %q.", t.Name())
+				}
+			case *ast.FragmentDef:
+				if t := c.Schema().Type(n.TypeCondition.Value); t != nil && !schema.IsCompositeType(t) {
+					c.Errorf(n.TypeCondition.Loc, "Fragment %q cannot condition on non composite type %q.", n.Name.Value, t.Name())
+				}
+			}
+		},
+	}
+}
+
+// KnownTypeNames requires that the types named by fragment type conditions and variable definitions are defined in
+// the Schema.
+var KnownTypeNames Rule = RuleFunc(knownTypeNames)
+
+func knownTypeNames(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			if nt, ok := n.(*ast.NamedType); ok && c.Schema().Type(nt.Value) == nil {
+				c.Errorf(nt.Loc, "Unknown type %q.", nt.Value)
+			}
+		},
+	}
+}
+
+// PossibleFragmentSpreads requires that fragments are only spread where they could apply: some object type must be
+// possible for both the fragment's type condition and the parent type.
+var PossibleFragmentSpreads Rule = RuleFunc(possibleFragmentSpreads)
+
+func possibleFragmentSpreads(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.InlineFragment:
+				fragType := schema.NamedTypeOf(c.Type())
+				parentType := c.ParentType()
+				if schema.IsCompositeType(fragType) && schema.IsCompositeType(parentType) && !doTypesOverlap(c.Schema(), fragType, parentType) {
+					c.Errorf(n.Loc, "Fragment cannot be spread here as objects of type %q can never be of type %q.", parentType.Name(), fragType.Name())
+				}
+			case *ast.FragmentSpread:
+				f := c.Fragment(n.Name.Value)
+				if f == nil {
+					return
+				}
+				fragType := c.Schema().Type(f.TypeCondition.Value)
+				parentType := c.ParentType()
+				if schema.IsCompositeType(fragType) && schema.IsCompositeType(parentType) && !doTypesOverlap(c.Schema(), fragType, parentType) {
+					c.Errorf(n.Loc, "Fragment %q cannot be spread here as objects of type %q can never be of type %q.", n.Name.Value, parentType.Name(), fragType.Name())
+				}
+			}
+		},
+	}
+}
+
+// The doTypesOverlap function returns true if some object type is possible for both of the composite types a and b.
+func doTypesOverlap(s *schema.Schema, a, b schema.NamedType) bool {
+	if a == b {
+		return true
+	}
+	for _, o := range s.PossibleTypes(a) {
+		if s.IsPossibleType(b, o) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestKnownFragmentNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), KnownFragmentNames, []ruleTest{
+		{"{dog {...A, ... on Dog {...B}}} fragment A on Dog {name} fragment B on Dog {barks, owner {...A}}", nil},
+		{"{dog {...A, ... on Dog {...Unknown}}} fragment A on Dog {owner {...Other}}", []string{
+			`Unknown fragment "Unknown". (at position 27)`,
+			`Unknown fragment "Other". (at position 67)`,
+		}},
+	})
+}
+
+func TestUniqueFragmentNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), UniqueFragmentNames, []ruleTest{
+		{"{dog {...A, ...B}} fragment A on Dog {name} fragment B on Dog {name}", nil},
+		{"{dog {...A}} fragment A on Dog {name} fragment A on Dog {barks} fragment A on Dog {owner {name}}", []string{
+			`There can be only one fragment named "A". (at positions 22, 47)`,
+			`There can be only one fragment named "A". (at positions 22, 73)`,
+		}},
+	})
+}
+
+func TestNoUnusedFragments(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), NoUnusedFragments, []ruleTest{
+		{"{dog {...A}} {pet {...C}} fragment A on Dog {...B} fragment B on Dog {name} fragment C on Pet {name}", nil},
+		{"{dog {...A}} fragment A on Dog {name} fragment B on Dog {...C} fragment C on Dog {name, ...B}", []string{
+			`Fragment "B" is never used. (at position 38)`,
+			`Fragment "C" is never used. (at position 63)`,
+		}},
+		{"fragment A on Dog {name}", []string{`Fragment "A" is never used. (at position 0)`}},
+	})
+}
+
+func TestNoFragmentCycles(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), NoFragmentCycles, []ruleTest{
+		{"fragment A on Dog {...B, ...B} fragment B on Dog {name}", nil},
+		{"fragment A on Dog {...B, owner {...C}} fragment B on Dog {...C} fragment C on Dog {name}", nil},
+		{"fragment A on Dog {...A}", []string{`Cannot spread fragment "A" within itself. (at position 19)`}},
+		{"fragment A on Dog {owner {pets {... on Dog {...A}}}}", []string{`Cannot spread fragment "A" within itself. (at position 44)`}},
+		{"fragment A on Dog {...B} fragment B on Dog {...C} fragment C on Dog {...A}", []string{
+			`Cannot spread fragment "A" within itself via B, C. (at positions 19, 44, 69)`,
+		}},
+		{"fragment A on Dog {...B, ...C} fragment B on Dog {...A} fragment C on Dog {...A}", []string{
+			`Cannot spread fragment "A" within itself via B. (at positions 19, 50)`,
+			`Cannot spread fragment "A" within itself via C. (at positions 25, 75)`,
+		}},
+		// Only the cycle is reported, not the fragments leading into it.
+		{"fragment A on Dog {...B} fragment B on Dog {...C} fragment C on Dog {...B}", []string{
+			`Cannot spread fragment "B" within itself via C. (at positions 44, 69)`,
+		}},
+	})
+}
+
+func TestFragmentsOnCompositeTypes(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), FragmentsOnCompositeTypes, []ruleTest{
+		{"fragment A on Dog {... on Pet {name}, ... {name}} fragment B on CatOrDog {__typename} fragment C on Unknown {a}", nil},
+		{"fragment A on Boolean {name} fragment B on Dog {... on DogCommand {name}} fragment C on PetFilter {name}", []string{
+			`Fragment "A" cannot condition on non composite type "Boolean". (at position 14)`,
+			`Fragment cannot condition on non composite type "DogCommand". (at position 55)`,
+			`Fragment "C" cannot condition on non composite type "PetFilter". (at position 88)`,
+		}},
+	})
+}
+
+func TestKnownTypeNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), KnownTypeNames, []ruleTest{
+		{"query ($a: String, $b: [PetFilter!]) {dog {... on Pet {name}}} fragment F on Dog {name}", nil},
+		{"query ($a: Str, $b: [Filter!]) {dog {... on Animal {name}}} fragment F on Doggo {name}", []string{
+			`Unknown type "Str". (at position 11)`,
+			`Unknown type "Filter". (at position 21)`,
+			`Unknown type "Animal". (at position 44)`,
+			`Unknown type "Doggo". (at position 74)`,
+		}},
+	})
+}
+
+func TestPossibleFragmentSpreads(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), PossibleFragmentSpreads, []ruleTest{
+		{`fragment A on Dog {...B, ... on Pet {name}, ... on CatOrDog {__typename}}
+		fragment B on Pet {... on Dog {name}, ... on Cat {name}, ...C}
+		fragment C on CatOrDog {... on Pet {name}, ... on Dog {name}}`, nil},
+		{"fragment A on Dog {... on Cat {name}, ...B} fragment B on Cat {name}", []string{
+			`Fragment cannot be spread here as objects of type "Dog" can never be of type "Cat". (at position 19)`,
+			`Fragment "B" cannot be spread here as objects of type "Dog" can never be of type "Cat". (at position 38)`,
+		}},
+		{"fragment A on Human {... on Pet {name}, ... on CatOrDog {__typename}} fragment B on CatOrDog {... on Human {name}}", []string{
+			`Fragment cannot be spread here as objects of type "Human" can never be of type "Pet". (at position 21)`,
+			`Fragment cannot be spread here as objects of type "Human" can never be of type "CatOrDog". (at position 40)`,
+			`Fragment cannot be spread here as objects of type "CatOrDog" can never be of type "Human". (at position 94)`,
+		}},
+	})
+}
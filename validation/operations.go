@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+)
+
+// ExecutableDefinitions requires that a Document contains only operations and fragments, and no type system
+// definitions.
+var ExecutableDefinitions Rule = RuleFunc(executableDefinitions)
+
+func executableDefinitions(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			d, ok := n.(*ast.Document)
+			if !ok {
+				return
+			}
+			for _, def := range d.Definitions {
+				var name string
+				var loc ast.Loc
+				switch def := def.(type) {
+				case *ast.OpDef, *ast.FragmentDef:
+					continue
+				case *ast.SchemaDef:
+					name, loc = "schema", def.Loc
+				case *ast.ObjTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.InterfaceTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.UnionTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.ScalarTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.EnumTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.InputObjTypeDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.TypeExtDef:
+					name, loc = def.Name.Value, def.Loc
+				case *ast.DirectiveDef:
+					name, loc = def.Name.Value, def.Loc
+				default:
+					name = def.Kind()
+				}
+				c.Errorf(loc, "The %q definition is not executable.", name)
+			}
+		},
+	}
+}
+
+// UniqueOperationNames requires that named operations have unique names.
+var UniqueOperationNames Rule = RuleFunc(uniqueOperationNames)
+
+func uniqueOperationNames(c *Context) *Visitor {
+	known := make(map[string]*ast.OpDef)
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			op, ok := n.(*ast.OpDef)
+			if !ok || op.Name.Value == "" {
+				return
+			}
+			if first, ok := known[op.Name.Value]; ok {
+				c.Report(errors.New(fmt.Sprintf("There can be only one operation named %q.", op.Name.Value), first.Name.Loc, op.Name.Loc))
+				return
+			}
+			known[op.Name.Value] = op
+		},
+	}
+}
+
+// LoneAnonymousOperation requires that an anonymous operation is the only operation in its Document.
+var LoneAnonymousOperation Rule = RuleFunc(loneAnonymousOperation)
+
+func loneAnonymousOperation(c *Context) *Visitor {
+	ops := 0
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.Document:
+				for _, def := range n.Definitions {
+					if _, ok := def.(*ast.OpDef); ok {
+						ops++
+					}
+				}
+			case *ast.OpDef:
+				if n.Name.Value == "" && ops > 1 {
+					c.Errorf(n.Loc, "This anonymous operation must be the only defined operation.")
+				}
+			}
+		},
+	}
+}
+
+// SingleFieldSubscriptions requires that subscriptions select exactly one root field, which is not an introspection
+// field. Fields selected through fragments are included, regardless of @skip and @include, since their variables are
+// not known until execution.
+var SingleFieldSubscriptions Rule = RuleFunc(singleFieldSubscriptions)
+
+func singleFieldSubscriptions(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			op, ok := n.(*ast.OpDef)
+			if !ok || op.OpType != ast.Subscription {
+				return
+			}
+			name := "Anonymous Subscription"
+			if op.Name.Value != "" {
+				name = fmt.Sprintf("Subscription %q", op.Name.Value)
+			}
+			var keys []string
+			fields := make(map[string][]*ast.Field)
+			collectRootFields(c, &op.SelectionSet, make(map[string]bool), func(f *ast.Field) {
+				key := f.Name.Value
+				if f.Alias.Value != "" {
+					key = f.Alias.Value
+				}
+				if _, ok := fields[key]; !ok {
+					keys = append(keys, key)
+				}
+				fields[key] = append(fields[key], f)
+			})
+			if len(keys) > 1 {
+				var locs []ast.Loc
+				for _, key := range keys[1:] {
+					for _, f := range fields[key] {
+						locs = append(locs, f.Loc)
+					}
+				}
+				c.Report(errors.New(name+" must select only one top level field.", locs...))
+			}
+			for _, key := range keys {
+				var locs []ast.Loc
+				for _, f := range fields[key] {
+					if strings.HasPrefix(f.Name.Value, "__") {
+						locs = append(locs, f.Loc)
+					}
+				}
+				if len(locs) > 0 {
+					c.Report(errors.New(name+" must not select an introspection top level field.", locs...))
+				}
+			}
+		},
+	}
+}
+
+// The collectRootFields function calls fn with each field of ss, including those of its inline fragments and spread
+// fragments, but not their sub-selections. Fragments in visited are skipped.
+func collectRootFields(c *Context, ss *ast.SelectionSet, visited map[string]bool, fn func(*ast.Field)) {
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			fn(sel)
+		case *ast.InlineFragment:
+			collectRootFields(c, &sel.SelectionSet, visited, fn)
+		case *ast.FragmentSpread:
+			if visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			if f := c.Fragment(sel.Name.Value); f != nil {
+				collectRootFields(c, &f.SelectionSet, visited, fn)
+			}
+		}
+	}
+}
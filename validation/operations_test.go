@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestExecutableDefinitions(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), ExecutableDefinitions, []ruleTest{
+		{"query Q {dog {...F}} fragment F on Dog {name} {pet {name}}", nil},
+		{"{dog {name}} schema {query: QueryRoot} type Cow {name: String} extend type Dog {color: String} enum E {A} directive @d on FIELD", []string{
+			`The "schema" definition is not executable. (at position 13)`,
+			`The "Cow" definition is not executable. (at position 39)`,
+			`The "Dog" definition is not executable. (at position 63)`,
+			`The "E" definition is not executable. (at position 95)`,
+			`The "d" definition is not executable. (at position 106)`,
+		}},
+	})
+}
+
+func TestUniqueOperationNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), UniqueOperationNames, []ruleTest{
+		{"query A {dog {name}} query B {dog {name}} {pet {name}} fragment A on Dog {name}", nil},
+		{"query A {dog {name}} mutation A {adopt(names: []) {name}} query A {pet {name}}", []string{
+			`There can be only one operation named "A". (at positions 6, 30)`,
+			`There can be only one operation named "A". (at positions 6, 64)`,
+		}},
+	})
+}
+
+func TestLoneAnonymousOperation(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), LoneAnonymousOperation, []ruleTest{
+		{"{dog {name}} fragment F on Dog {name}", nil},
+		{"query A {dog {name}} query B {dog {name}}", nil},
+		{"{dog {name}} query A {pet {name}}", []string{`This anonymous operation must be the only defined operation. (at position 0)`}},
+		{"{dog {name}} mutation {adopt(names: []) {name}}", []string{
+			`This anonymous operation must be the only defined operation. (at position 0)`,
+			`This anonymous operation must be the only defined operation. (at position 13)`,
+		}},
+	})
+}
+
+func TestSingleFieldSubscriptions(t *testing.T) {
+	s := mustBuild(t, `
+schema {query: Query, subscription: Subscription}
+type Query {a: Int}
+type Subscription {a: Int, b: Int, c: Int}
+`)
+	checkRule(t, s, SingleFieldSubscriptions, []ruleTest{
+		{"subscription S {a} {a, b}", nil},
+		{"subscription S {a, a} subscription T {...F} fragment F on Subscription {... on Subscription {x: a}}", nil},
+		{"subscription S {a, b, ...F} fragment F on Subscription {c, ... on Subscription {b}}", []string{
+			`Subscription "S" must select only one top level field. (at positions 19, 80, 56)`,
+		}},
+		{"subscription {__typename}", []string{
+			`Anonymous Subscription must not select an introspection top level field. (at position 14)`,
+		}},
+	})
+}
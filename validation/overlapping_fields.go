@@ -0,0 +1,445 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// OverlappingFieldsCanBeMerged requires that fields selected with the same response name, including through
+// fragments, select the same field with the same arguments, and have compatible return types, so that they can be
+// merged into a single response value. Fields of mutually exclusive object types may select different fields or
+// arguments, but must still return compatible shapes.
+//
+// The fields and fragment names of each selection set are computed once, and each pair of fragments is compared at
+// most once, so that reused fragments are not compared again. The time taken is still quadratic in the number of
+// fields sharing a response name, and in the number of fragments spread by a selection set, since each pair must be
+// compared.
+var OverlappingFieldsCanBeMerged Rule = RuleFunc(overlappingFieldsCanBeMerged)
+
+func overlappingFieldsCanBeMerged(c *Context) *Visitor {
+	o := &overlapChecker{
+		context:              c,
+		cache:                make(map[*ast.SelectionSet]*fieldsAndFragmentNames),
+		comparedFragments:    make(map[fragmentPair]bool),
+		comparedWithFragment: make(map[fieldsFragmentPair]bool),
+	}
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			ss, ok := n.(*ast.SelectionSet)
+			if !ok {
+				return
+			}
+			for _, cf := range o.findConflictsWithin(c.ParentType(), ss) {
+				locs := make([]ast.Loc, 0, len(cf.fields1)+len(cf.fields2))
+				for _, f := range cf.fields1 {
+					locs = append(locs, f.Loc)
+				}
+				for _, f := range cf.fields2 {
+					locs = append(locs, f.Loc)
+				}
+				c.Report(errors.New(fmt.Sprintf("Fields %q conflict because %s. Use different aliases on the fields to fetch both if this was intentional.",
+					cf.reason.name, cf.reason.message()), locs...))
+			}
+		},
+	}
+}
+
+// A conflictReason explains why the fields with a response name conflict: either a message, or the conflicts of their
+// subfields.
+type conflictReason struct {
+	name      string
+	msg       string
+	subfields []conflictReason
+}
+
+func (r *conflictReason) message() string {
+	if len(r.subfields) == 0 {
+		return r.msg
+	}
+	var b bytes.Buffer
+	for i := range r.subfields {
+		if i > 0 {
+			b.WriteString(" and ")
+		}
+		fmt.Fprintf(&b, "subfields %q conflict because %s", r.subfields[i].name, r.subfields[i].message())
+	}
+	return b.String()
+}
+
+// A conflict is a reason and the fields involved on each side.
+type conflict struct {
+	reason           conflictReason
+	fields1, fields2 []*ast.Field
+}
+
+// A fieldAndDef is a selected field, its parent type, and its definition, if known.
+type fieldAndDef struct {
+	parentType schema.NamedType
+	field      *ast.Field
+	def        *schema.Field
+}
+
+// A fieldMap is an ordered map of response names to the fields selected with them.
+type fieldMap struct {
+	names  []string
+	fields map[string][]fieldAndDef
+}
+
+func (m *fieldMap) add(name string, f fieldAndDef) {
+	if _, ok := m.fields[name]; !ok {
+		m.names = append(m.names, name)
+	}
+	m.fields[name] = append(m.fields[name], f)
+}
+
+// The fieldsAndFragmentNames type holds the fields selected directly by a selection set, including through inline
+// fragments, and the names of the fragments it spreads.
+type fieldsAndFragmentNames struct {
+	fieldMap      *fieldMap
+	fragmentNames []string
+}
+
+// A fragmentPair is an unordered pair of fragment names, which have been compared with each other.
+type fragmentPair struct {
+	a, b string
+}
+
+// A fieldsFragmentPair is a fieldMap and a fragment name, which have been compared with each other.
+type fieldsFragmentPair struct {
+	fieldMap             *fieldMap
+	fragmentName         string
+	areMutuallyExclusive bool
+}
+
+// An overlapChecker finds conflicts between the fields of a Document, caching intermediate results across selection
+// sets.
+type overlapChecker struct {
+	context *Context
+	cache   map[*ast.SelectionSet]*fieldsAndFragmentNames
+	// Pairs of compared fragments, mapped to whether they were compared as mutually exclusive.
+	comparedFragments map[fragmentPair]bool
+	// Field maps compared with fragments, which prevents repeated work and infinite recursion on fragment cycles.
+	comparedWithFragment map[fieldsFragmentPair]bool
+}
+
+// The findConflictsWithin method returns the conflicts among the fields selected by ss, including through fragments.
+func (o *overlapChecker) findConflictsWithin(parentType schema.NamedType, ss *ast.SelectionSet) []conflict {
+	var conflicts []conflict
+	ff := o.fieldsAndFragmentNames(parentType, ss)
+
+	// Conflicts between the fields selected directly.
+	for _, name := range ff.fieldMap.names {
+		fields := ff.fieldMap.fields[name]
+		for i := range fields {
+			for j := i + 1; j < len(fields); j++ {
+				if cf := o.findConflict(false, name, &fields[i], &fields[j]); cf != nil {
+					conflicts = append(conflicts, *cf)
+				}
+			}
+		}
+	}
+	// Conflicts between the fields selected directly and through fragments, and between fragments.
+	for i, name := range ff.fragmentNames {
+		conflicts = o.collectBetweenFieldsAndFragment(conflicts, false, ff.fieldMap, name)
+		for _, other := range ff.fragmentNames[i+1:] {
+			conflicts = o.collectBetweenFragments(conflicts, false, name, other)
+		}
+	}
+	return conflicts
+}
+
+// The collectBetweenFieldsAndFragment method appends the conflicts between the fields of fm and the fields selected
+// by the fragment named fragmentName, including through the fragments it spreads.
+func (o *overlapChecker) collectBetweenFieldsAndFragment(conflicts []conflict, areMutuallyExclusive bool, fm *fieldMap, fragmentName string) []conflict {
+	key := fieldsFragmentPair{fm, fragmentName, areMutuallyExclusive}
+	if o.comparedWithFragment[key] {
+		return conflicts
+	}
+	o.comparedWithFragment[key] = true
+
+	ff := o.fragmentFieldsAndFragmentNames(fragmentName)
+	if ff == nil || ff.fieldMap == fm {
+		return conflicts
+	}
+	conflicts = o.collectBetween(conflicts, areMutuallyExclusive, fm, ff.fieldMap)
+	for _, name := range ff.fragmentNames {
+		conflicts = o.collectBetweenFieldsAndFragment(conflicts, areMutuallyExclusive, fm, name)
+	}
+	return conflicts
+}
+
+// The collectBetweenFragments method appends the conflicts between the fields selected by the fragments named name1
+// and name2, including through the fragments they spread.
+func (o *overlapChecker) collectBetweenFragments(conflicts []conflict, areMutuallyExclusive bool, name1, name2 string) []conflict {
+	if name1 == name2 {
+		return conflicts
+	}
+	pair := fragmentPair{name1, name2}
+	if name2 < name1 {
+		pair = fragmentPair{name2, name1}
+	}
+	// A comparison which was not mutually exclusive covers both cases.
+	if exclusive, ok := o.comparedFragments[pair]; ok && (areMutuallyExclusive || !exclusive) {
+		return conflicts
+	}
+	o.comparedFragments[pair] = areMutuallyExclusive
+
+	ff1 := o.fragmentFieldsAndFragmentNames(name1)
+	ff2 := o.fragmentFieldsAndFragmentNames(name2)
+	if ff1 == nil || ff2 == nil {
+		return conflicts
+	}
+	conflicts = o.collectBetween(conflicts, areMutuallyExclusive, ff1.fieldMap, ff2.fieldMap)
+	for _, name := range ff2.fragmentNames {
+		conflicts = o.collectBetweenFragments(conflicts, areMutuallyExclusive, name1, name)
+	}
+	for _, name := range ff1.fragmentNames {
+		conflicts = o.collectBetweenFragments(conflicts, areMutuallyExclusive, name, name2)
+	}
+	return conflicts
+}
+
+// The findConflictsBetweenSubSelectionSets method returns the conflicts between the fields selected by ss1 and ss2,
+// the selection sets of two fields with the same response name.
+func (o *overlapChecker) findConflictsBetweenSubSelectionSets(areMutuallyExclusive bool, parentType1 schema.NamedType, ss1 *ast.SelectionSet, parentType2 schema.NamedType, ss2 *ast.SelectionSet) []conflict {
+	var conflicts []conflict
+	ff1 := o.fieldsAndFragmentNames(parentType1, ss1)
+	ff2 := o.fieldsAndFragmentNames(parentType2, ss2)
+
+	conflicts = o.collectBetween(conflicts, areMutuallyExclusive, ff1.fieldMap, ff2.fieldMap)
+	for _, name := range ff2.fragmentNames {
+		conflicts = o.collectBetweenFieldsAndFragment(conflicts, areMutuallyExclusive, ff1.fieldMap, name)
+	}
+	for _, name := range ff1.fragmentNames {
+		conflicts = o.collectBetweenFieldsAndFragment(conflicts, areMutuallyExclusive, ff2.fieldMap, name)
+	}
+	for _, name1 := range ff1.fragmentNames {
+		for _, name2 := range ff2.fragmentNames {
+			conflicts = o.collectBetweenFragments(conflicts, areMutuallyExclusive, name1, name2)
+		}
+	}
+	return conflicts
+}
+
+// The collectBetween method appends the conflicts between the fields of fm1 and fm2 with the same response names.
+func (o *overlapChecker) collectBetween(conflicts []conflict, areMutuallyExclusive bool, fm1, fm2 *fieldMap) []conflict {
+	for _, name := range fm1.names {
+		fields2, ok := fm2.fields[name]
+		if !ok {
+			continue
+		}
+		fields1 := fm1.fields[name]
+		for i := range fields1 {
+			for j := range fields2 {
+				if cf := o.findConflict(areMutuallyExclusive, name, &fields1[i], &fields2[j]); cf != nil {
+					conflicts = append(conflicts, *cf)
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// The findConflict method returns the conflict between f1 and f2, selected with the response name name, or nil if
+// they can be merged.
+func (o *overlapChecker) findConflict(parentFieldsAreMutuallyExclusive bool, name string, f1, f2 *fieldAndDef) *conflict {
+	// Fields of different object types are never both in a response, so only their shapes must be compatible.
+	_, isObject1 := f1.parentType.(*schema.Object)
+	_, isObject2 := f2.parentType.(*schema.Object)
+	areMutuallyExclusive := parentFieldsAreMutuallyExclusive ||
+		(f1.parentType != f2.parentType && isObject1 && isObject2)
+
+	if !areMutuallyExclusive {
+		if name1, name2 := f1.field.Name.Value, f2.field.Name.Value; name1 != name2 {
+			return newConflict(name, fmt.Sprintf("%q and %q are different fields", name1, name2), f1.field, f2.field)
+		}
+		if !sameArguments(f1.field.Arguments, f2.field.Arguments) {
+			return newConflict(name, "they have differing arguments", f1.field, f2.field)
+		}
+	}
+
+	var type1, type2 schema.Type
+	if f1.def != nil {
+		type1 = f1.def.Type()
+	}
+	if f2.def != nil {
+		type2 = f2.def.Type()
+	}
+	if type1 != nil && type2 != nil && doTypesConflict(type1, type2) {
+		return newConflict(name, fmt.Sprintf("they return conflicting types %q and %q", type1, type2), f1.field, f2.field)
+	}
+
+	if len(f1.field.Selections) > 0 && len(f2.field.Selections) > 0 {
+		subConflicts := o.findConflictsBetweenSubSelectionSets(areMutuallyExclusive,
+			schema.NamedTypeOf(type1), &f1.field.SelectionSet, schema.NamedTypeOf(type2), &f2.field.SelectionSet)
+		if len(subConflicts) > 0 {
+			cf := &conflict{
+				reason:  conflictReason{name: name},
+				fields1: []*ast.Field{f1.field},
+				fields2: []*ast.Field{f2.field},
+			}
+			for _, sub := range subConflicts {
+				cf.reason.subfields = append(cf.reason.subfields, sub.reason)
+				cf.fields1 = append(cf.fields1, sub.fields1...)
+				cf.fields2 = append(cf.fields2, sub.fields2...)
+			}
+			return cf
+		}
+	}
+	return nil
+}
+
+func newConflict(name, msg string, f1, f2 *ast.Field) *conflict {
+	return &conflict{
+		reason:  conflictReason{name: name, msg: msg},
+		fields1: []*ast.Field{f1},
+		fields2: []*ast.Field{f2},
+	}
+}
+
+// The doTypesConflict function returns true if values of types t1 and t2 could not be merged: if they have different
+// list or non-null wrappers, or if either is a different leaf type.
+func doTypesConflict(t1, t2 schema.Type) bool {
+	if l1, ok := t1.(*schema.ListType); ok {
+		l2, ok := t2.(*schema.ListType)
+		return !ok || doTypesConflict(l1.OfType(), l2.OfType())
+	}
+	if _, ok := t2.(*schema.ListType); ok {
+		return true
+	}
+	if n1, ok := t1.(*schema.NonNullType); ok {
+		n2, ok := t2.(*schema.NonNullType)
+		return !ok || doTypesConflict(n1.OfType(), n2.OfType())
+	}
+	if _, ok := t2.(*schema.NonNullType); ok {
+		return true
+	}
+	if schema.IsLeafType(t1) || schema.IsLeafType(t2) {
+		return t1 != t2
+	}
+	return false
+}
+
+// The sameArguments function returns true if as1 and as2 have the same arguments, with the same values, in any
+// order.
+func sameArguments(as1, as2 []ast.Argument) bool {
+	if len(as1) != len(as2) {
+		return false
+	}
+	for i := range as1 {
+		found := false
+		for j := range as2 {
+			if as1[i].Name.Value == as2[j].Name.Value {
+				found = sameValue(as1[i].Value, as2[j].Value)
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// The sameValue function returns true if v1 and v2 are the same literal, ignoring locations.
+func sameValue(v1, v2 ast.Value) bool {
+	switch v1 := v1.(type) {
+	case *ast.Variable:
+		v2, ok := v2.(*ast.Variable)
+		return ok && v1.Name.Value == v2.Name.Value
+	case *ast.Int:
+		v2, ok := v2.(*ast.Int)
+		return ok && v1.Value == v2.Value
+	case *ast.Float:
+		v2, ok := v2.(*ast.Float)
+		return ok && v1.Value == v2.Value
+	case *ast.String:
+		v2, ok := v2.(*ast.String)
+		return ok && v1.Value == v2.Value
+	case *ast.Boolean:
+		v2, ok := v2.(*ast.Boolean)
+		return ok && v1.Value == v2.Value
+	case *ast.Enum:
+		v2, ok := v2.(*ast.Enum)
+		return ok && v1.Value == v2.Value
+	case *ast.List:
+		v2, ok := v2.(*ast.List)
+		if !ok || len(v1.Values) != len(v2.Values) {
+			return false
+		}
+		for i := range v1.Values {
+			if !sameValue(v1.Values[i], v2.Values[i]) {
+				return false
+			}
+		}
+		return true
+	case *ast.Object:
+		v2, ok := v2.(*ast.Object)
+		if !ok || len(v1.Fields) != len(v2.Fields) {
+			return false
+		}
+		for i := range v1.Fields {
+			if v1.Fields[i].Name.Value != v2.Fields[i].Name.Value || !sameValue(v1.Fields[i].Value, v2.Fields[i].Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// The fieldsAndFragmentNames method returns the fields and fragment names of ss, a selection set of parentType.
+func (o *overlapChecker) fieldsAndFragmentNames(parentType schema.NamedType, ss *ast.SelectionSet) *fieldsAndFragmentNames {
+	if ff, ok := o.cache[ss]; ok {
+		return ff
+	}
+	ff := &fieldsAndFragmentNames{fieldMap: &fieldMap{fields: make(map[string][]fieldAndDef)}}
+	o.collectFieldsAndFragmentNames(parentType, ss, ff, make(map[string]bool))
+	o.cache[ss] = ff
+	return ff
+}
+
+// The fragmentFieldsAndFragmentNames method returns the fields and fragment names of the fragment named name, or nil
+// if there is no such fragment.
+func (o *overlapChecker) fragmentFieldsAndFragmentNames(name string) *fieldsAndFragmentNames {
+	f := o.context.Fragment(name)
+	if f == nil {
+		return nil
+	}
+	if ff, ok := o.cache[&f.SelectionSet]; ok {
+		return ff
+	}
+	return o.fieldsAndFragmentNames(o.context.Schema().Type(f.TypeCondition.Value), &f.SelectionSet)
+}
+
+func (o *overlapChecker) collectFieldsAndFragmentNames(parentType schema.NamedType, ss *ast.SelectionSet, ff *fieldsAndFragmentNames, fragmentNames map[string]bool) {
+	for _, sel := range ss.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			var def *schema.Field
+			if parentType != nil {
+				def = o.context.Schema().Field(parentType, sel.Name.Value)
+			}
+			name := sel.Name.Value
+			if sel.Alias.Value != "" {
+				name = sel.Alias.Value
+			}
+			ff.fieldMap.add(name, fieldAndDef{parentType: parentType, field: sel, def: def})
+		case *ast.FragmentSpread:
+			if !fragmentNames[sel.Name.Value] {
+				fragmentNames[sel.Name.Value] = true
+				ff.fragmentNames = append(ff.fragmentNames, sel.Name.Value)
+			}
+		case *ast.InlineFragment:
+			t := parentType
+			if sel.NamedType.Value != "" {
+				t = o.context.Schema().Type(sel.NamedType.Value)
+			}
+			o.collectFieldsAndFragmentNames(t, &sel.SelectionSet, ff, fragmentNames)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestOverlappingFieldsCanBeMerged(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), OverlappingFieldsCanBeMerged, []ruleTest{
+		{"fragment F on Dog {name, name}", nil},
+		{"fragment F on Dog {otherName: name, otherName: name}", nil},
+		{"fragment F on Dog {doesKnowCommand(command: SIT), doesKnowCommand(command: SIT)}", nil},
+		{"query ($c: DogCommand!) {dog {doesKnowCommand(command: $c), doesKnowCommand(command: $c)}}", nil},
+		{"fragment F on Dog {name @include(if: true), name @skip(if: false)}", nil},
+		{"fragment F on Dog {fido: name, fido: barks}", []string{
+			`Fields "fido" conflict because "name" and "barks" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 19, 31)`,
+		}},
+		{"fragment F on Dog {name: barks, name}", []string{
+			`Fields "name" conflict because "barks" and "name" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 19, 32)`,
+		}},
+		{"fragment F on Dog {doesKnowCommand(command: SIT), doesKnowCommand(command: HEEL)}", []string{
+			`Fields "doesKnowCommand" conflict because they have differing arguments. Use different aliases on the fields to fetch both if this was intentional. (at positions 19, 50)`,
+		}},
+		{"fragment F on Dog {doesKnowCommand, doesKnowCommand(command: SIT)}", []string{
+			`Fields "doesKnowCommand" conflict because they have differing arguments. Use different aliases on the fields to fetch both if this was intentional. (at positions 19, 36)`,
+		}},
+		{"{dog {...A, ...B}} fragment A on Dog {x: name} fragment B on Dog {x: barks}", []string{
+			`Fields "x" conflict because "name" and "barks" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 38, 66)`,
+		}},
+		{"{dog {x: name, ...A}} fragment A on Dog {...B} fragment B on Dog {x: barks}", []string{
+			`Fields "x" conflict because "name" and "barks" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 6, 66)`,
+		}},
+		// Fragments spreading each other do not recurse forever.
+		{"{dog {...A}} fragment A on Dog {name, ...B} fragment B on Dog {barks, ...A}", nil},
+		{"{dog {...A, ...A}} fragment A on Dog {name, ...A}", nil},
+		{`{catOrDog {... on Dog {name: barks}, ... on Cat {name: meows}}}`, nil},
+		{`{pet {... on Dog {name: barks}, ... on Cat {name}}}`, []string{
+			`Fields "name" conflict because they return conflicting types "Boolean" and "String". Use different aliases on the fields to fetch both if this was intentional. (at positions 18, 44)`,
+		}},
+		{`{pet {... on Dog {name: barks}, name}}`, []string{
+			`Fields "name" conflict because "barks" and "name" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 18, 32)`,
+		}},
+		{`{dog {owner {name}}, dog {owner {name: pets {name}}}}`, []string{
+			`Fields "dog" conflict because subfields "owner" conflict because subfields "name" conflict because "name" and "pets" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 1, 6, 13, 21, 26, 33)`,
+		}},
+		{`{dog {x: name, y: barks}, dog {x: barks, y: name}}`, []string{
+			`Fields "dog" conflict because subfields "x" conflict because "name" and "barks" are different fields and subfields "y" conflict because "barks" and "name" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 1, 6, 15, 26, 31, 41)`,
+		}},
+		{`{dog {...F}, dog {name: barks}} fragment F on Dog {name}`, []string{
+			`Fields "dog" conflict because subfields "name" conflict because "barks" and "name" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 1, 18, 13, 51)`,
+		}},
+	})
+}
+
+func TestOverlappingFieldsReturnTypes(t *testing.T) {
+	s := mustBuild(t, `
+type Query {someBox: SomeBox, connection: Connection}
+interface SomeBox {deepBox: SomeBox, unrelatedField: String}
+type StringBox implements SomeBox {scalar: String, deepBox: StringBox, unrelatedField: String, listStringBox: [StringBox], stringBox: StringBox, intBox: IntBox}
+type IntBox implements SomeBox {scalar: Int, deepBox: IntBox, unrelatedField: String, listStringBox: [StringBox], stringBox: StringBox, intBox: IntBox}
+interface NonNullStringBox1 {scalar: String!}
+type NonNullStringBox1Impl implements SomeBox NonNullStringBox1 {scalar: String!, unrelatedField: String, deepBox: SomeBox}
+interface NonNullStringBox2 {scalar: String!}
+type NonNullStringBox2Impl implements SomeBox NonNullStringBox2 {scalar: String!, unrelatedField: String, deepBox: SomeBox}
+type Connection {edges: [Edge]}
+type Edge {node: Node}
+type Node {id: ID, name: String}
+`)
+	checkRule(t, s, OverlappingFieldsCanBeMerged, []ruleTest{
+		{`{someBox {... on IntBox {scalar}, ... on StringBox {scalar}}}`, []string{
+			`Fields "scalar" conflict because they return conflicting types "Int" and "String". Use different aliases on the fields to fetch both if this was intentional. (at positions 25, 52)`,
+		}},
+		{`{someBox {... on IntBox {deepBox {unrelatedField}}, ... on StringBox {deepBox {unrelatedField}}}}`, nil},
+		{`{someBox {... on NonNullStringBox1 {scalar}, ... on StringBox {scalar}}}`, []string{
+			`Fields "scalar" conflict because they return conflicting types "String!" and "String". Use different aliases on the fields to fetch both if this was intentional. (at positions 36, 63)`,
+		}},
+		{`{someBox {... on IntBox {box: listStringBox {scalar}}, ... on StringBox {box: stringBox {scalar}}}}`, []string{
+			`Fields "box" conflict because they return conflicting types "[StringBox]" and "StringBox". Use different aliases on the fields to fetch both if this was intentional. (at positions 25, 73)`,
+		}},
+		{`{someBox {... on IntBox {box: stringBox {val: scalar, val: unrelatedField}}, ... on StringBox {box: stringBox {val: scalar}}}}`, []string{
+			`Fields "val" conflict because "scalar" and "unrelatedField" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 41, 54)`,
+		}},
+		{`{someBox {... on IntBox {box: stringBox {scalar}}, ... on StringBox {box: intBox {scalar}}}}`, []string{
+			`Fields "box" conflict because subfields "scalar" conflict because they return conflicting types "String" and "Int". Use different aliases on the fields to fetch both if this was intentional. (at positions 25, 41, 69, 82)`,
+		}},
+		{`{someBox {... on NonNullStringBox1 {scalar}, ... on NonNullStringBox2 {scalar}}}`, nil},
+		{`{connection {...edgeID, edges {node {id: name}}}} fragment edgeID on Connection {edges {node {id}}}`, []string{
+			`Fields "edges" conflict because subfields "node" conflict because subfields "id" conflict because "name" and "id" are different fields. Use different aliases on the fields to fetch both if this was intentional. (at positions 24, 31, 37, 81, 88, 94)`,
+		}},
+		{`{someBox {... on IntBox {deepBox {...X}}, ... on StringBox {deepBox {...Y}}}} fragment X on SomeBox {scalar: deepBox {unrelatedField}} fragment Y on SomeBox {scalar: unrelatedField}`, []string{
+			`Fields "deepBox" conflict because subfields "scalar" conflict because they return conflicting types "SomeBox" and "String". Use different aliases on the fields to fetch both if this was intentional. (at positions 25, 101, 60, 158)`,
+		}},
+	})
+}
+
+// The generatedQuery function returns a query with n fragments, each spreading every previous fragment.
+func generatedQuery(n int) string {
+	var b bytes.Buffer
+	b.WriteString("{dog {...F0}}")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Dog {name, owner {name}", i)
+		for j := i + 1; j < n; j++ {
+			fmt.Fprintf(&b, ", ...F%d", j)
+		}
+		b.WriteString("}")
+	}
+	return b.String()
+}
+
+func TestOverlappingFieldsManyFragments(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	d := mustParse(t, generatedQuery(100))
+	if errs := Validate(s, d, OverlappingFieldsCanBeMerged); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func BenchmarkOverlappingFieldsCanBeMerged(b *testing.B) {
+	s := mustBuild(&testing.T{}, testSDL)
+	d := mustParse(&testing.T{}, generatedQuery(100))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Validate(s, d, OverlappingFieldsCanBeMerged)
+	}
+}
+
+// The siblingFragmentsQuery function returns a query spreading n fragments in the same selection set.
+func siblingFragmentsQuery(n int) string {
+	var b bytes.Buffer
+	b.WriteString("{dog {")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "...F%d ", i)
+	}
+	b.WriteString("}}")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Dog {name barks owner {name}}", i)
+	}
+	return b.String()
+}
+
+// The repeatedFieldsQuery function returns a query selecting the same field n times.
+func repeatedFieldsQuery(n int) string {
+	var b bytes.Buffer
+	b.WriteString("{dog {")
+	for i := 0; i < n; i++ {
+		b.WriteString("name ")
+	}
+	b.WriteString("}}")
+	return b.String()
+}
+
+// The time taken by these benchmarks grows quadratically with n.
+func BenchmarkOverlappingFieldsBounds(b *testing.B) {
+	s := mustBuild(&testing.T{}, testSDL)
+	for _, bench := range []struct {
+		name  string
+		query func(int) string
+		sizes []int
+	}{
+		{"SiblingFragments", siblingFragmentsQuery, []int{100, 200, 400}},
+		{"RepeatedFields", repeatedFieldsQuery, []int{1000, 2000, 4000}},
+	} {
+		for _, n := range bench.sizes {
+			d := mustParse(&testing.T{}, bench.query(n))
+			b.Run(fmt.Sprintf("%s%d", bench.name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Validate(s, d, OverlappingFieldsCanBeMerged)
+				}
+			})
+		}
+	}
+}
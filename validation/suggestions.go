@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The maximum number of suggestions included in a message.
+const maxSuggestions = 5
+
+// The didYouMean function returns a sentence suggesting the first few of suggestions, optionally preceded by
+// subMessage, or "" if there are none. For example:
+//
+//	Did you mean "a", "b", or "c"?
+func didYouMean(subMessage string, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	var b bytes.Buffer
+	b.WriteString(" Did you mean ")
+	if subMessage != "" {
+		b.WriteString(subMessage)
+		b.WriteByte(' ')
+	}
+	for i, s := range suggestions {
+		if i > 0 {
+			if len(suggestions) > 2 {
+				b.WriteByte(',')
+			}
+			if i == len(suggestions)-1 {
+				b.WriteString(" or")
+			}
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%q", s)
+	}
+	b.WriteByte('?')
+	return b.String()
+}
+
+// The suggestionList function returns the options which are similar to input, ordered from most to least similar.
+// Options are similar if their lexical distance from input is at most 40% of its length.
+func suggestionList(input string, options []string) []string {
+	threshold := len(input)*4/10 + 1
+	distances := make(map[string]int)
+	var list []string
+	for _, o := range options {
+		if d := lexicalDistance(input, o); d <= threshold {
+			if _, ok := distances[o]; !ok {
+				list = append(list, o)
+			}
+			distances[o] = d
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if di, dj := distances[list[i]], distances[list[j]]; di != dj {
+			return di < dj
+		}
+		return list[i] < list[j]
+	})
+	return list
+}
+
+// The lexicalDistance function returns the Damerau-Levenshtein (optimal string alignment) distance between a and b:
+// the number of single character insertions, deletions, substitutions and adjacent transpositions needed to transform
+// one into the other. Strings which differ only by case have a distance of 1.
+func lexicalDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if la == lb {
+		return 1
+	}
+	s, t := []rune(la), []rune(lb)
+	// Only the last three rows of the matrix are needed.
+	rows := [3][]int{make([]int, len(t)+1), make([]int, len(t)+1), make([]int, len(t)+1)}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		prev, cur := rows[(i-1)%3], rows[i%3]
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d := minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d = minInt(d, rows[(i-2)%3][j-2]+1)
+			}
+			cur[j] = d
+		}
+	}
+	return rows[len(s)%3][len(t)]
+}
+
+func minInt(a int, bs ...int) int {
+	for _, b := range bs {
+		if b < a {
+			a = b
+		}
+	}
+	return a
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestLexicalDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"a", "", 1},
+		{"name", "name", 0},
+		{"name", "Name", 1},
+		{"name", "nmae", 1},
+		{"name", "names", 1},
+		{"name", "nam", 1},
+		{"name", "game", 1},
+		{"kitten", "sitting", 3},
+		{"ca", "abc", 3},
+	} {
+		if d := lexicalDistance(test.a, test.b); d != test.expected {
+			t.Errorf("%q, %q: expected %d but got %d", test.a, test.b, test.expected, d)
+		}
+		if d := lexicalDistance(test.b, test.a); d != test.expected {
+			t.Errorf("%q, %q: expected %d but got %d", test.b, test.a, test.expected, d)
+		}
+	}
+}
+
+func TestSuggestionList(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		options  []string
+		expected []string
+	}{
+		{"", []string{"a"}, []string{"a"}},
+		{"name", nil, nil},
+		{"name", []string{"barks", "names", "NAME", "nam", "owner"}, []string{"NAME", "nam", "names"}},
+		{"ab", []string{"ba", "abc", "a", "b"}, []string{"a", "abc", "b", "ba"}},
+	} {
+		if actual := suggestionList(test.input, test.options); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q: expected %v but got %v\n%s", test.input, test.expected, actual, pretty.Diff(test.expected, actual))
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	for _, test := range []struct {
+		subMessage  string
+		suggestions []string
+		expected    string
+	}{
+		{"", nil, ""},
+		{"", []string{"a"}, ` Did you mean "a"?`},
+		{"", []string{"a", "b"}, ` Did you mean "a" or "b"?`},
+		{"", []string{"a", "b", "c"}, ` Did you mean "a", "b", or "c"?`},
+		{"", []string{"a", "b", "c", "d", "e", "f"}, ` Did you mean "a", "b", "c", "d", or "e"?`},
+		{"to use", []string{"a", "b"}, ` Did you mean to use "a" or "b"?`},
+	} {
+		if actual := didYouMean(test.subMessage, test.suggestions); actual != test.expected {
+			t.Errorf("expected %q but got %q", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Package validation implements the validation of executable GraphQL documents against a Schema, as described by the
+// spec's validation section.
+//
+// Originally ported from the javascript reference implementation:
+// https://github.com/graphql/graphql-js/tree/master/src/validation
+package validation
+
+import (
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// A Rule checks one aspect of the validity of a Document.
+type Rule interface {
+	// The Visitor method returns a Visitor checking a single Document, which reports errors to c.
+	Visitor(c *Context) *Visitor
+}
+
+// A RuleFunc is a Rule implemented by a function returning its Visitor.
+type RuleFunc func(c *Context) *Visitor
+
+func (f RuleFunc) Visitor(c *Context) *Visitor { return f(c) }
+
+// A Visitor is notified as a Document is traversed. Enter is called with each node before its children are visited,
+// and Leave after. Either may be nil.
+//
+// Nodes are passed as pointers, e.g. *ast.Field and *ast.Argument. The Context holds the type information of the
+// current node.
+type Visitor struct {
+	Enter func(n ast.Node)
+	Leave func(n ast.Node)
+}
+
+// SpecifiedRules is the set of rules defined by the spec, which every Document must satisfy.
+var SpecifiedRules = []Rule{
+	ExecutableDefinitions,
+	UniqueOperationNames,
+	LoneAnonymousOperation,
+	SingleFieldSubscriptions,
+	KnownTypeNames,
+	FragmentsOnCompositeTypes,
+	VariablesAreInputTypes,
+	ScalarLeafs,
+	FieldsOnCorrectType,
+	UniqueFragmentNames,
+	KnownFragmentNames,
+	NoUnusedFragments,
+	PossibleFragmentSpreads,
+	NoFragmentCycles,
+	UniqueVariableNames,
+	NoUndefinedVariables,
+	NoUnusedVariables,
+	KnownDirectives,
+	UniqueDirectivesPerLocation,
+	KnownArgumentNames,
+	UniqueArgumentNames,
+	ValuesOfCorrectType,
+	ProvidedRequiredArguments,
+	VariablesInAllowedPosition,
+	OverlappingFieldsCanBeMerged,
+	UniqueInputFieldNames,
+}
+
+// The Validate function checks d against s, returning the errors reported by rules. If no rules are given,
+// SpecifiedRules are used. All of the rules are run together, in a single traversal of d.
+func Validate(s *schema.Schema, d *ast.Document, rules ...Rule) []*errors.GraphQLError {
+	if len(rules) == 0 {
+		rules = SpecifiedRules
+	}
+	c := newContext(s, d)
+	w := walker{context: c}
+	for _, r := range rules {
+		if v := r.Visitor(c); v != nil {
+			w.visitors = append(w.visitors, v)
+		}
+	}
+	w.document(d)
+	return c.errs
+}
+
+// A walker traverses a Document, maintaining the type information of its Context, and notifying its visitors.
+type walker struct {
+	context  *Context
+	visitors []*Visitor
+}
+
+func (w *walker) enter(n ast.Node) {
+	w.context.enter(n)
+	for _, v := range w.visitors {
+		if v.Enter != nil {
+			v.Enter(n)
+		}
+	}
+}
+
+func (w *walker) leave(n ast.Node) {
+	for _, v := range w.visitors {
+		if v.Leave != nil {
+			v.Leave(n)
+		}
+	}
+	w.context.leave(n)
+}
+
+func (w *walker) document(d *ast.Document) {
+	w.enter(d)
+	for _, def := range d.Definitions {
+		switch def := def.(type) {
+		case *ast.OpDef:
+			w.opDef(def)
+		case *ast.FragmentDef:
+			w.fragmentDef(def)
+		default:
+			w.typeSystemDef(def)
+		}
+	}
+	w.leave(d)
+}
+
+// The typeSystemDef method traverses a type system definition. They are not executable, so only the definitions, and
+// the parts of them which may have directives, and their directives, are traversed.
+func (w *walker) typeSystemDef(def ast.Definition) {
+	w.enter(def)
+	switch def := def.(type) {
+	case *ast.SchemaDef:
+		w.directives(def.Directives)
+	case *ast.ObjTypeDef:
+		w.directives(def.Directives)
+		w.fieldDefs(def.FieldDefs)
+	case *ast.TypeExtDef:
+		w.directives(def.Directives)
+		w.fieldDefs(def.FieldDefs)
+	case *ast.InterfaceTypeDef:
+		w.directives(def.Directives)
+		w.fieldDefs(def.FieldDefs)
+	case *ast.UnionTypeDef:
+		w.directives(def.Directives)
+	case *ast.ScalarTypeDef:
+		w.directives(def.Directives)
+	case *ast.EnumTypeDef:
+		w.directives(def.Directives)
+		for i := range def.EnumValueDefs {
+			v := &def.EnumValueDefs[i]
+			w.enter(v)
+			w.directives(v.Directives)
+			w.leave(v)
+		}
+	case *ast.InputObjTypeDef:
+		w.directives(def.Directives)
+		w.inputValueDefs(def.Fields)
+	case *ast.DirectiveDef:
+		w.inputValueDefs(def.Arguments)
+	}
+	w.leave(def)
+}
+
+func (w *walker) fieldDefs(fs []ast.FieldDef) {
+	for i := range fs {
+		f := &fs[i]
+		w.enter(f)
+		w.inputValueDefs(f.Arguments)
+		w.directives(f.Directives)
+		w.leave(f)
+	}
+}
+
+func (w *walker) inputValueDefs(vs []ast.InputValueDef) {
+	for i := range vs {
+		v := &vs[i]
+		w.enter(v)
+		w.directives(v.Directives)
+		w.leave(v)
+	}
+}
+
+func (w *walker) opDef(o *ast.OpDef) {
+	w.enter(o)
+	for i := range o.VarDefs {
+		w.varDef(&o.VarDefs[i])
+	}
+	w.directives(o.Directives)
+	w.selectionSet(&o.SelectionSet)
+	w.leave(o)
+}
+
+func (w *walker) varDef(v *ast.VarDef) {
+	w.enter(v)
+	w.enter(&v.Variable)
+	w.leave(&v.Variable)
+	w.refType(v.RefType)
+	if v.DefaultValue != nil {
+		w.value(v.DefaultValue)
+	}
+	w.directives(v.Directives)
+	w.leave(v)
+}
+
+func (w *walker) refType(rt ast.RefType) {
+	w.enter(rt)
+	switch t := rt.(type) {
+	case *ast.ListType:
+		w.refType(t.RefType)
+	case *ast.NonNullType:
+		w.refType(t.RefType)
+	}
+	w.leave(rt)
+}
+
+func (w *walker) fragmentDef(f *ast.FragmentDef) {
+	w.enter(f)
+	w.enter(&f.TypeCondition)
+	w.leave(&f.TypeCondition)
+	w.directives(f.Directives)
+	w.selectionSet(&f.SelectionSet)
+	w.leave(f)
+}
+
+func (w *walker) selectionSet(ss *ast.SelectionSet) {
+	w.enter(ss)
+	for _, s := range ss.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			w.field(s)
+		case *ast.FragmentSpread:
+			w.enter(s)
+			w.directives(s.Directives)
+			w.leave(s)
+		case *ast.InlineFragment:
+			w.enter(s)
+			if s.NamedType.Value != "" {
+				w.enter(&s.NamedType)
+				w.leave(&s.NamedType)
+			}
+			w.directives(s.Directives)
+			w.selectionSet(&s.SelectionSet)
+			w.leave(s)
+		}
+	}
+	w.leave(ss)
+}
+
+func (w *walker) field(f *ast.Field) {
+	w.enter(f)
+	w.arguments(f.Arguments)
+	w.directives(f.Directives)
+	if len(f.SelectionSet.Selections) > 0 {
+		w.selectionSet(&f.SelectionSet)
+	}
+	w.leave(f)
+}
+
+func (w *walker) arguments(as []ast.Argument) {
+	for i := range as {
+		a := &as[i]
+		w.enter(a)
+		w.value(a.Value)
+		w.leave(a)
+	}
+}
+
+func (w *walker) directives(ds []ast.Directive) {
+	for i := range ds {
+		d := &ds[i]
+		w.enter(d)
+		w.arguments(d.Arguments)
+		w.leave(d)
+	}
+}
+
+func (w *walker) value(v ast.Value) {
+	w.enter(v)
+	switch v := v.(type) {
+	case *ast.List:
+		for _, e := range v.Values {
+			w.value(e)
+		}
+	case *ast.Object:
+		for i := range v.Fields {
+			f := &v.Fields[i]
+			w.enter(f)
+			w.value(f.Value)
+			w.leave(f)
+		}
+	}
+	w.leave(v)
+}
@@ -0,0 +1,247 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/parser"
+	"github.com/jmank88/gql/schema"
+	"github.com/kr/pretty"
+)
+
+const testSDL = `
+schema {query: QueryRoot, mutation: MutationRoot}
+type QueryRoot {
+	dog(name: String): Dog
+	pet: Pet
+	pets(filter: PetFilter): [Pet]
+	catOrDog: CatOrDog
+	human(id: ID!): Human
+}
+type MutationRoot {adopt(names: [String!]!): [Pet!]}
+interface Pet {name: String}
+type Dog implements Pet {name: String, barks: Boolean, doesKnowCommand(command: DogCommand!): Boolean, owner: Human}
+type Cat implements Pet {name: String, meows: Boolean}
+union CatOrDog = Cat | Dog
+type Human {name(surname: Boolean): String, pets: [Pet]}
+enum DogCommand {SIT, HEEL}
+input PetFilter {name: String, nested: [PetFilter!], command: DogCommand}
+`
+
+func mustBuild(t *testing.T, sdl string) *schema.Schema {
+	d, err := parser.ParseString(sdl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Build(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func mustParse(t *testing.T, src string) *ast.Document {
+	d, err := parser.ParseString(src)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %s", src, err)
+	}
+	return d
+}
+
+// The checkRule function validates each query against s with rule, and compares the messages of the errors.
+func checkRule(t *testing.T, s *schema.Schema, rule Rule, tests []ruleTest) {
+	for _, test := range tests {
+		var actual []string
+		for _, err := range Validate(s, mustParse(t, test.query), rule) {
+			actual = append(actual, err.Error())
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %# v but got %# v", test.query, pretty.Formatter(test.expected), pretty.Formatter(actual))
+		}
+	}
+}
+
+// A ruleTest is a query, and the expected messages of the errors validating it.
+type ruleTest struct {
+	query    string
+	expected []string
+}
+
+// The typeString function returns the String of t, or "nil".
+func typeString(t schema.Type) string {
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
+
+// A typeInfoRule records the type information of the Context upon entering nodes.
+type typeInfoRule struct {
+	log []string
+}
+
+func (r *typeInfoRule) Visitor(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.Field:
+				fieldDef := "nil"
+				if f := c.FieldDef(); f != nil {
+					fieldDef = f.Name()
+				}
+				r.log = append(r.log, fmt.Sprintf("field %s: parent %s, def %s, type %s",
+					n.Name.Value, typeString(c.ParentType()), fieldDef, typeString(c.Type())))
+			case *ast.Argument:
+				arg := "nil"
+				if a := c.Argument(); a != nil {
+					arg = a.Name()
+				}
+				r.log = append(r.log, fmt.Sprintf("argument %s: def %s, input %s", n.Name.Value, arg, typeString(c.InputType())))
+			case *ast.Directive:
+				directive := "nil"
+				if d := c.Directive(); d != nil {
+					directive = d.Name()
+				}
+				r.log = append(r.log, fmt.Sprintf("directive %s: def %s", n.Name.Value, directive))
+			case *ast.VarDef:
+				r.log = append(r.log, fmt.Sprintf("variable %s: input %s", n.Variable.Name.Value, typeString(c.InputType())))
+			case *ast.ObjectField:
+				r.log = append(r.log, fmt.Sprintf("object field %s: input %s, parent %s",
+					n.Name.Value, typeString(c.InputType()), typeString(c.ParentInputType())))
+			case *ast.Enum:
+				r.log = append(r.log, fmt.Sprintf("enum %s: input %s, parent %s",
+					n.Value, typeString(c.InputType()), typeString(c.ParentInputType())))
+			case *ast.InlineFragment:
+				r.log = append(r.log, fmt.Sprintf("inline fragment %s: type %s", n.NamedType.Value, typeString(c.Type())))
+			case *ast.FragmentDef:
+				r.log = append(r.log, fmt.Sprintf("fragment %s: type %s", n.Name.Value, typeString(c.Type())))
+			}
+		},
+	}
+}
+
+func TestTypeInfo(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	for _, test := range []struct {
+		query    string
+		expected []string
+	}{
+		{
+			`query ($f: PetFilter = {name: "a"}, $unknown: Unknown) {
+				dog(name: "Rex") {name, barks @skip(if: true), owner {name(surname: true)}}
+				pets(filter: {nested: [{command: SIT}], unknown: 1}) {name, unknown}
+				unknown {name}
+			}`,
+			[]string{
+				"variable f: input PetFilter",
+				"object field name: input String, parent PetFilter",
+				"variable unknown: input nil",
+				"field dog: parent QueryRoot, def dog, type Dog",
+				"argument name: def name, input String",
+				"field name: parent Dog, def name, type String",
+				"field barks: parent Dog, def barks, type Boolean",
+				"directive skip: def skip",
+				"argument if: def if, input Boolean!",
+				"field owner: parent Dog, def owner, type Human",
+				"field name: parent Human, def name, type String",
+				"argument surname: def surname, input Boolean",
+				"field pets: parent QueryRoot, def pets, type [Pet]",
+				"argument filter: def filter, input PetFilter",
+				"object field nested: input [PetFilter!], parent PetFilter",
+				"object field command: input DogCommand, parent PetFilter!",
+				"enum SIT: input DogCommand, parent PetFilter!",
+				"object field unknown: input nil, parent PetFilter",
+				"field name: parent Pet, def name, type String",
+				"field unknown: parent Pet, def nil, type nil",
+				"field unknown: parent QueryRoot, def nil, type nil",
+				"field name: parent nil, def nil, type nil",
+			},
+		},
+		{
+			`{catOrDog {__typename, ... on Dog {doesKnowCommand(command: HEEL)}, ... {__typename}, ...F}}
+			fragment F on Cat {meows}
+			fragment G on Unknown {name}
+			mutation {adopt(names: ["a", "b"]) {name}}`,
+			[]string{
+				"field catOrDog: parent QueryRoot, def catOrDog, type CatOrDog",
+				"field __typename: parent CatOrDog, def __typename, type String!",
+				"inline fragment Dog: type Dog",
+				"field doesKnowCommand: parent Dog, def doesKnowCommand, type Boolean",
+				"argument command: def command, input DogCommand!",
+				"enum HEEL: input DogCommand!, parent nil",
+				"inline fragment : type CatOrDog",
+				"field __typename: parent CatOrDog, def __typename, type String!",
+				"fragment F: type Cat",
+				"field meows: parent Cat, def meows, type Boolean",
+				"fragment G: type nil",
+				"field name: parent nil, def nil, type nil",
+				"field adopt: parent MutationRoot, def adopt, type [Pet!]",
+				"argument names: def names, input [String!]!",
+				"field name: parent Pet, def name, type String",
+			},
+		},
+	} {
+		r := &typeInfoRule{}
+		if errs := Validate(s, mustParse(t, test.query), r); len(errs) > 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		if !reflect.DeepEqual(r.log, test.expected) {
+			t.Errorf("%s: expected %# v but got %# v", test.query, pretty.Formatter(test.expected), pretty.Formatter(r.log))
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	d := mustParse(t, "{dog {name}} {pet {name}}")
+
+	var order []string
+	visitor := func(name string) Rule {
+		return RuleFunc(func(c *Context) *Visitor {
+			return &Visitor{
+				Enter: func(n ast.Node) {
+					if f, ok := n.(*ast.Field); ok {
+						order = append(order, name+" enter "+f.Name.Value)
+					}
+				},
+				Leave: func(n ast.Node) {
+					if f, ok := n.(*ast.Field); ok {
+						order = append(order, name+" leave "+f.Name.Value)
+						if f.Name.Value == "pet" {
+							c.Errorf(f.Loc, "%s: pet", name)
+						}
+					}
+				},
+			}
+		})
+	}
+	errs := Validate(s, d, visitor("a"), visitor("b"), RuleFunc(func(*Context) *Visitor { return nil }))
+	expectedOrder := []string{
+		"a enter dog", "b enter dog",
+		"a enter name", "b enter name", "a leave name", "b leave name",
+		"a leave dog", "b leave dog",
+		"a enter pet", "b enter pet",
+		"a enter name", "b enter name", "a leave name", "b leave name",
+		"a leave pet", "b leave pet",
+	}
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Errorf("expected %# v but got %# v", pretty.Formatter(expectedOrder), pretty.Formatter(order))
+	}
+	expectedErrs := []*errors.GraphQLError{
+		{Message: "a: pet", Locations: []ast.Loc{{Start: 14, End: 24}}},
+		{Message: "b: pet", Locations: []ast.Loc{{Start: 14, End: 24}}},
+	}
+	if !reflect.DeepEqual(errs, expectedErrs) {
+		t.Errorf("expected %# v but got %# v", pretty.Formatter(expectedErrs), pretty.Formatter(errs))
+	}
+}
+
+func TestSpecifiedRules(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	if errs := Validate(s, mustParse(t, `query Q($name: String) {dog(name: $name) {...F}} fragment F on Dog {name}`)); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/lang/printer"
+	"github.com/jmank88/gql/schema"
+)
+
+// UniqueInputFieldNames requires that each input object value has at most one field of each name.
+var UniqueInputFieldNames Rule = RuleFunc(uniqueInputFieldNames)
+
+func uniqueInputFieldNames(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			o, ok := n.(*ast.Object)
+			if !ok {
+				return
+			}
+			known := make(map[string]*ast.ObjectField, len(o.Fields))
+			for i := range o.Fields {
+				f := &o.Fields[i]
+				if first, ok := known[f.Name.Value]; ok {
+					c.Report(errors.New(fmt.Sprintf("There can be only one input field named %q.", f.Name.Value), first.Name.Loc, f.Name.Loc))
+					continue
+				}
+				known[f.Name.Value] = f
+			}
+		},
+	}
+}
+
+// ValuesOfCorrectType requires that literal values are valid for the input types expected at their positions: that
+// scalars can be parsed by ParseLiteral, that enum values are defined, and that input objects define their fields and
+// are given their required ones. Variables are checked by VariablesInAllowedPosition.
+var ValuesOfCorrectType Rule = RuleFunc(valuesOfCorrectType)
+
+func valuesOfCorrectType(c *Context) *Visitor {
+	// A value which has been reported as a whole, and whose children are not checked.
+	var skip ast.Node
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			if skip != nil {
+				return
+			}
+			switch n := n.(type) {
+			case *ast.List:
+				t := c.ParentInputType()
+				if nn, ok := t.(*schema.NonNullType); ok {
+					t = nn.OfType()
+				}
+				if _, ok := t.(*schema.ListType); !ok {
+					if !isValidValue(c, n) {
+						skip = n
+					}
+				}
+			case *ast.Object:
+				o, ok := schema.NamedTypeOf(c.InputType()).(*schema.InputObject)
+				if !ok {
+					if !isValidValue(c, n) {
+						skip = n
+					}
+					return
+				}
+				provided := make(map[string]bool, len(n.Fields))
+				for _, f := range n.Fields {
+					provided[f.Name.Value] = true
+				}
+				for _, f := range o.Fields() {
+					if _, ok := f.Type().(*schema.NonNullType); ok && f.DefaultValue() == nil && !provided[f.Name()] {
+						c.Errorf(n.Loc, "Field \"%s.%s\" of required type %q was not provided.", o.Name(), f.Name(), f.Type().String())
+					}
+				}
+			case *ast.ObjectField:
+				if o, ok := schema.NamedTypeOf(c.ParentInputType()).(*schema.InputObject); ok && c.InputType() == nil {
+					c.Errorf(n.Name.Loc, "Field %q is not defined by type %q.", n.Name.Value, o.Name())
+				}
+			case *ast.Int, *ast.Float, *ast.String, *ast.Boolean, *ast.Enum:
+				isValidValue(c, n.(ast.Value))
+			}
+		},
+		Leave: func(n ast.Node) {
+			if n == skip {
+				skip = nil
+			}
+		},
+	}
+}
+
+// The isValidValue function reports an error and returns false if v is not a valid value of the current input type.
+func isValidValue(c *Context, v ast.Value) bool {
+	locType := c.InputType()
+	if locType == nil {
+		return true
+	}
+	var err error
+	switch t := schema.NamedTypeOf(locType).(type) {
+	case *schema.Scalar:
+		_, err = t.ParseLiteral(v)
+	case *schema.Enum:
+		if e, ok := v.(*ast.Enum); !ok || t.Value(e.Value) == nil {
+			c.Errorf(valueLoc(v), "Expected value of type %q, found %s.", locType.String(), printValue(v))
+			return false
+		}
+		return true
+	default:
+		c.Errorf(valueLoc(v), "Expected value of type %q, found %s.", locType.String(), printValue(v))
+		return false
+	}
+	if err != nil {
+		c.Errorf(valueLoc(v), "Expected value of type %q, found %s; %s", locType.String(), printValue(v), err)
+		return false
+	}
+	return true
+}
+
+// The printValue function returns v in compact GraphQL syntax.
+func printValue(v ast.Value) string {
+	var b bytes.Buffer
+	if err := printer.Compact.Fprint(&b, v); err != nil {
+		return v.Kind()
+	}
+	return b.String()
+}
+
+// The valueLoc function returns the location of v.
+func valueLoc(v ast.Value) ast.Loc {
+	switch v := v.(type) {
+	case *ast.Variable:
+		return v.Loc
+	case *ast.Int:
+		return v.Loc
+	case *ast.Float:
+		return v.Loc
+	case *ast.String:
+		return v.Loc
+	case *ast.Boolean:
+		return v.Loc
+	case *ast.Enum:
+		return v.Loc
+	case *ast.List:
+		return v.Loc
+	case *ast.Object:
+		return v.Loc
+	}
+	return ast.Loc{}
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"testing"
+)
+
+const valuesSDL = `
+type Query {
+	int(a: Int): Int
+	float(a: Float): Int
+	string(a: String): Int
+	boolean(a: Boolean): Int
+	id(a: ID): Int
+	color(a: Color): Int
+	list(a: [String]): Int
+	nonNull(a: Int!, b: Int! = 1): Int
+	complex(a: Complex): Int
+	dateTime(a: DateTime): Int
+}
+enum Color {RED, GREEN}
+input Complex {required: Boolean!, optional: Int, defaulted: Boolean! = false, list: [String!], nested: Complex}
+scalar DateTime
+`
+
+func TestUniqueInputFieldNames(t *testing.T) {
+	checkRule(t, mustBuild(t, valuesSDL), UniqueInputFieldNames, []ruleTest{
+		{"{complex(a: {required: true, optional: 1, nested: {required: true, optional: 2}})}", nil},
+		{"{complex(a: {required: true, required: false, nested: {optional: 1, optional: 2}})}", []string{
+			`There can be only one input field named "required". (at positions 13, 29)`,
+			`There can be only one input field named "optional". (at positions 55, 68)`,
+		}},
+	})
+}
+
+func TestValuesOfCorrectType(t *testing.T) {
+	checkRule(t, mustBuild(t, valuesSDL), ValuesOfCorrectType, []ruleTest{
+		{`{int(a: 1) float(a: 1) f: float(a: 1.5) string(a: "s") boolean(a: true) id(a: 1) i: id(a: "1") color(a: RED)}`, nil},
+		{`{list(a: ["a", "b"]) l: list(a: "a") nonNull(a: 1) dateTime(a: "2016-01-02T15:04:05Z")}`, nil},
+		{`query ($v: Int) {int(a: $v) list(a: [$v]) complex(a: {required: $v, nested: {required: true}})}`, nil},
+		{`{int(a: "1") float(a: true) string(a: 1) boolean(a: 1.0) id(a: 1.5)}`, []string{
+			`Expected value of type "Int", found "1"; Int cannot represent a non-Int value: StringValue (at position 8)`,
+			`Expected value of type "Float", found true; Float cannot represent a non-Float value: BooleanValue (at position 22)`,
+			`Expected value of type "String", found 1; String cannot represent a non-String value: IntValue (at position 38)`,
+			`Expected value of type "Boolean", found 1.0; Boolean cannot represent a non-Boolean value: FloatValue (at position 52)`,
+			`Expected value of type "ID", found 1.5; ID cannot represent a non-string and non-integer value: FloatValue (at position 63)`,
+		}},
+		{`{int(a: 2147483648) dateTime(a: "yesterday")}`, []string{
+			`Expected value of type "Int", found 2147483648; Int cannot represent non 32-bit signed integer value: 2147483648 (at position 8)`,
+			`Expected value of type "DateTime", found "yesterday"; DateTime cannot represent an invalid date-time string: "yesterday" (at position 32)`,
+		}},
+		{`{color(a: BLUE) c: color(a: "RED") l: list(a: [1, ["a"]])}`, []string{
+			`Expected value of type "Color", found BLUE. (at position 10)`,
+			`Expected value of type "Color", found "RED". (at position 28)`,
+			`Expected value of type "String", found 1; String cannot represent a non-String value: IntValue (at position 47)`,
+			`Expected value of type "String", found ["a"]; String cannot represent a non-String value: ListValue (at position 50)`,
+		}},
+		{`{complex(a: {optional: 1, unknown: 2, nested: {required: true, list: [1]}}) c: complex(a: [true]) i: int(a: {a: 1})}`, []string{
+			`Field "Complex.required" of required type "Boolean!" was not provided. (at position 12)`,
+			`Field "unknown" is not defined by type "Complex". (at position 26)`,
+			`Expected value of type "String!", found 1; String cannot represent a non-String value: IntValue (at position 70)`,
+			`Expected value of type "Complex", found [true]. (at position 90)`,
+			`Expected value of type "Int", found {a:1}; Int cannot represent a non-Int value: ObjectValue (at position 108)`,
+		}},
+		{`query ($a: Int = "1", $b: Complex = {required: 1}) {int(a: $a)}`, []string{
+			`Expected value of type "Int", found "1"; Int cannot represent a non-Int value: StringValue (at position 17)`,
+			`Expected value of type "Boolean!", found 1; Boolean cannot represent a non-Boolean value: IntValue (at position 47)`,
+		}},
+	})
+}
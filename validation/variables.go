@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jmank88/gql/errors"
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/jmank88/gql/schema"
+)
+
+// UniqueVariableNames requires that the variables defined by each operation have unique names.
+var UniqueVariableNames Rule = RuleFunc(uniqueVariableNames)
+
+func uniqueVariableNames(c *Context) *Visitor {
+	var known map[string]*ast.VarDef
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			switch n := n.(type) {
+			case *ast.OpDef:
+				known = make(map[string]*ast.VarDef)
+			case *ast.VarDef:
+				name := n.Variable.Name.Value
+				if first, ok := known[name]; ok {
+					c.Report(errors.New(fmt.Sprintf("There can be only one variable named \"$%s\".", name), first.Variable.Loc, n.Variable.Loc))
+					return
+				}
+				known[name] = n
+			}
+		},
+	}
+}
+
+// VariablesAreInputTypes requires that variables are defined with input types: scalars, enums, input objects, or
+// lists and non-nulls of them.
+var VariablesAreInputTypes Rule = RuleFunc(variablesAreInputTypes)
+
+func variablesAreInputTypes(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			if v, ok := n.(*ast.VarDef); ok {
+				if t := c.Schema().TypeFromAST(v.RefType); t != nil && !schema.IsInputType(t) {
+					c.Errorf(refTypeLoc(v.RefType), "Variable \"$%s\" cannot be non-input type %q.", v.Variable.Name.Value, t.String())
+				}
+			}
+		},
+	}
+}
+
+// NoUndefinedVariables requires that every variable used by an operation, directly or within the fragments it
+// spreads, is defined by that operation.
+var NoUndefinedVariables Rule = RuleFunc(noUndefinedVariables)
+
+func noUndefinedVariables(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			op, ok := n.(*ast.OpDef)
+			if !ok {
+				return
+			}
+			defined := make(map[string]bool, len(op.VarDefs))
+			for _, v := range op.VarDefs {
+				defined[v.Variable.Name.Value] = true
+			}
+			for _, u := range c.RecursiveVariableUsages(op) {
+				name := u.Node.Name.Value
+				if defined[name] {
+					continue
+				}
+				var msg string
+				if op.Name.Value != "" {
+					msg = fmt.Sprintf("Variable \"$%s\" is not defined by operation %q.", name, op.Name.Value)
+				} else {
+					msg = fmt.Sprintf("Variable \"$%s\" is not defined.", name)
+				}
+				c.Report(errors.New(msg, u.Node.Loc, op.Loc))
+			}
+		},
+	}
+}
+
+// NoUnusedVariables requires that every variable defined by an operation is used, directly or within the fragments it
+// spreads.
+var NoUnusedVariables Rule = RuleFunc(noUnusedVariables)
+
+func noUnusedVariables(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			op, ok := n.(*ast.OpDef)
+			if !ok {
+				return
+			}
+			used := make(map[string]bool)
+			for _, u := range c.RecursiveVariableUsages(op) {
+				used[u.Node.Name.Value] = true
+			}
+			for _, v := range op.VarDefs {
+				name := v.Variable.Name.Value
+				if used[name] {
+					continue
+				}
+				if op.Name.Value != "" {
+					c.Errorf(v.Loc, "Variable \"$%s\" is never used in operation %q.", name, op.Name.Value)
+				} else {
+					c.Errorf(v.Loc, "Variable \"$%s\" is never used.", name)
+				}
+			}
+		},
+	}
+}
+
+// VariablesInAllowedPosition requires that variables are only used in positions expecting a compatible type. A
+// nullable variable may only be used in a non-null position if either the variable or the position has a default
+// value.
+var VariablesInAllowedPosition Rule = RuleFunc(variablesInAllowedPosition)
+
+func variablesInAllowedPosition(c *Context) *Visitor {
+	return &Visitor{
+		Enter: func(n ast.Node) {
+			op, ok := n.(*ast.OpDef)
+			if !ok {
+				return
+			}
+			defs := make(map[string]*ast.VarDef, len(op.VarDefs))
+			for i := range op.VarDefs {
+				v := &op.VarDefs[i]
+				defs[v.Variable.Name.Value] = v
+			}
+			for _, u := range c.RecursiveVariableUsages(op) {
+				def, ok := defs[u.Node.Name.Value]
+				if !ok || u.Type == nil {
+					continue
+				}
+				// Variables with unknown or non-input types are reported by other rules.
+				t := c.Schema().TypeFromAST(def.RefType)
+				if t == nil || !schema.IsInputType(t) {
+					continue
+				}
+				if !allowedVariableUsage(c.Schema(), t, def.DefaultValue, u.Type, u.DefaultValue) {
+					c.Report(errors.New(fmt.Sprintf("Variable \"$%s\" of type %q used in position expecting type %q.", u.Node.Name.Value, t.String(), u.Type.String()), def.Loc, u.Node.Loc))
+				}
+			}
+		},
+	}
+}
+
+// The allowedVariableUsage function returns true if a variable of type varType, with the default value varDefault,
+// may be used in a position expecting locType, with the default value locDefault.
+func allowedVariableUsage(s *schema.Schema, varType schema.Type, varDefault ast.Value, locType schema.Type, locDefault ast.Value) bool {
+	if nn, ok := locType.(*schema.NonNullType); ok {
+		if _, ok := varType.(*schema.NonNullType); !ok {
+			if varDefault == nil && locDefault == nil {
+				return false
+			}
+			return s.IsSubType(varType, nn.OfType())
+		}
+	}
+	return s.IsSubType(varType, locType)
+}
+
+// The refTypeLoc function returns the location of rt.
+func refTypeLoc(rt ast.RefType) ast.Loc {
+	switch rt := rt.(type) {
+	case *ast.NamedType:
+		return rt.Loc
+	case *ast.ListType:
+		return rt.Loc
+	case *ast.NonNullType:
+		return rt.Loc
+	}
+	return ast.Loc{}
+}
@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmank88/gql/lang/ast"
+	"github.com/kr/pretty"
+)
+
+func TestVariableUsages(t *testing.T) {
+	s := mustBuild(t, testSDL)
+	d := mustParse(t, `query Q($a: Boolean, $b: String) {dog(name: $b) {...F, doesKnowCommand(command: $c) @skip(if: $a)}}
+fragment F on Dog {owner {name(surname: $d)}, ...G}
+fragment G on Dog {name @include(if: $e)}`)
+	var usages []string
+	checkUsages := RuleFunc(func(c *Context) *Visitor {
+		return &Visitor{
+			Enter: func(n ast.Node) {
+				if op, ok := n.(*ast.OpDef); ok {
+					for _, u := range c.RecursiveVariableUsages(op) {
+						usages = append(usages, u.Node.Name.Value+": "+u.Type.String())
+					}
+				}
+			},
+		}
+	})
+	if errs := Validate(s, d, checkUsages); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	expected := []string{"b: String", "c: DogCommand!", "a: Boolean!", "d: Boolean", "e: Boolean!"}
+	if !reflect.DeepEqual(usages, expected) {
+		t.Errorf("expected %v but got %v\n%s", expected, usages, pretty.Diff(expected, usages))
+	}
+}
+
+func TestUniqueVariableNames(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), UniqueVariableNames, []ruleTest{
+		{"query A($x: Int, $y: String) {__typename} query B($x: String, $y: Int) {__typename}", nil},
+		{"query ($x: Int, $x: Boolean, $x: String) {__typename}", []string{
+			`There can be only one variable named "$x". (at positions 7, 16)`,
+			`There can be only one variable named "$x". (at positions 7, 29)`,
+		}},
+	})
+}
+
+func TestVariablesAreInputTypes(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), VariablesAreInputTypes, []ruleTest{
+		{"query ($a: String, $b: [Boolean!]!, $c: PetFilter, $d: DogCommand, $e: Unknown) {__typename}", nil},
+		{"query ($a: Dog, $b: [[CatOrDog!]]!, $c: Pet) {__typename}", []string{
+			`Variable "$a" cannot be non-input type "Dog". (at position 11)`,
+			`Variable "$b" cannot be non-input type "[[CatOrDog!]]!". (at position 20)`,
+			`Variable "$c" cannot be non-input type "Pet". (at position 40)`,
+		}},
+	})
+}
+
+func TestNoUndefinedVariables(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), NoUndefinedVariables, []ruleTest{
+		{"query ($a: String, $b: Boolean) {dog(name: $a) {...F}} fragment F on Dog {name @skip(if: $b)}", nil},
+		{"query Q($a: String) {dog(name: $a) {...F, barks @include(if: $x)}} fragment F on Dog {owner {name(surname: $y)}}", []string{
+			`Variable "$x" is not defined by operation "Q". (at positions 61, 0)`,
+			`Variable "$y" is not defined by operation "Q". (at positions 107, 0)`,
+		}},
+		// Fragments are checked in the context of each operation which spreads them.
+		{"query A($a: Boolean) {dog {...F}} query B {dog {...F}} fragment F on Dog {name @skip(if: $a)}", []string{
+			`Variable "$a" is not defined by operation "B". (at positions 89, 34)`,
+		}},
+		{"{pets(filter: {nested: [{name: $a}]}) {name}}", []string{`Variable "$a" is not defined. (at positions 31, 0)`}},
+	})
+}
+
+func TestNoUnusedVariables(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), NoUnusedVariables, []ruleTest{
+		{"query ($a: String, $b: Boolean) {dog(name: $a) {...F}} fragment F on Dog {...G} fragment G on Dog {name @skip(if: $b)}", nil},
+		{"query Q($a: String, $b: Boolean, $c: Int) {dog(name: $a) {...F}} fragment F on Dog {name}", []string{
+			`Variable "$b" is never used in operation "Q". (at position 20)`,
+			`Variable "$c" is never used in operation "Q". (at position 33)`,
+		}},
+		{"query ($a: String = \"a\") {__typename}", []string{`Variable "$a" is never used. (at position 7)`}},
+	})
+}
+
+func TestVariablesInAllowedPosition(t *testing.T) {
+	checkRule(t, mustBuild(t, testSDL), VariablesInAllowedPosition, []ruleTest{
+		{"query ($a: String, $b: String!, $c: [String!]!, $d: DogCommand!, $e: ID!) {dog(name: $a) {doesKnowCommand(command: $d)} d: dog(name: $b) {name} h: human(id: $e) {name}}", nil},
+		{"mutation ($a: [String!]!) {adopt(names: $a) {name}}", nil},
+		// A nullable variable may be used in a non-null position if it has a default value.
+		{"query ($a: DogCommand = SIT) {dog {doesKnowCommand(command: $a)}}", nil},
+		{"query ($a: Boolean = true) {dog @skip(if: $a) {name}}", nil},
+		{"query ($a: String) {pets(filter: {name: $a, nested: [{command: $c}]}) {name}}", nil},
+		{"query ($a: String, $b: Int) {dog(name: $b) {name} human(id: $a) {name}}", []string{
+			`Variable "$b" of type "Int" used in position expecting type "String". (at positions 19, 39)`,
+			`Variable "$a" of type "String" used in position expecting type "ID!". (at positions 7, 60)`,
+		}},
+		{"query ($a: DogCommand) {dog {...F}} fragment F on Dog {doesKnowCommand(command: $a)}", []string{
+			`Variable "$a" of type "DogCommand" used in position expecting type "DogCommand!". (at positions 7, 80)`,
+		}},
+		{"mutation ($a: [String]!, $b: [String!], $c: String!) {a: adopt(names: $a) {name} b: adopt(names: $b) {name} c: adopt(names: $c) {name}}", []string{
+			`Variable "$a" of type "[String]!" used in position expecting type "[String!]!". (at positions 10, 70)`,
+			`Variable "$b" of type "[String!]" used in position expecting type "[String!]!". (at positions 25, 97)`,
+			`Variable "$c" of type "String!" used in position expecting type "[String!]!". (at positions 40, 124)`,
+		}},
+		{"query ($a: Boolean, $f: PetFilter) {dog @include(if: $a) {name} pets(filter: {nested: $f}) {name}}", []string{
+			`Variable "$a" of type "Boolean" used in position expecting type "Boolean!". (at positions 7, 53)`,
+			`Variable "$f" of type "PetFilter" used in position expecting type "[PetFilter!]". (at positions 20, 86)`,
+		}},
+	})
+}